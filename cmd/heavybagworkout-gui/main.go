@@ -2,6 +2,7 @@ package main
 
 import (
 	"heavybagworkout/internal/gui"
+	"heavybagworkout/internal/timer"
 	"log"
 	"os"
 
@@ -22,6 +23,14 @@ func main() {
 		guiApp := gui.NewApp()
 		// Set window reference for invalidating frames on timer updates
 		guiApp.SetWindow(&w)
+		// Checkpoint workouts so they can be resumed after the window is closed
+		if checkpoints, err := timer.DefaultCheckpointStore(); err != nil {
+			log.Printf("Warning: workout checkpoints disabled: %v", err)
+		} else if sessions, err := timer.DefaultSessionLog(); err != nil {
+			log.Printf("Warning: workout checkpoints disabled: %v", err)
+		} else {
+			guiApp.SetCheckpointStore(checkpoints, sessions)
+		}
 
 		// Run the window event loop in this goroutine
 		if err := run(&w, guiApp); err != nil {
@@ -45,12 +54,9 @@ func run(w *app.Window, guiApp *gui.App) error {
 			// Window close event (Task 30)
 			// Check if workout is in progress
 			if guiApp.IsWorkoutInProgress() {
-				// Workout is in progress - we can't easily prevent window close in Gio
-				// without a modal dialog system. For now, we'll allow the close but
-				// the workout state will be lost. In a production app, you might want
-				// to implement a modal dialog system to confirm.
-				// TODO: Implement modal dialog for confirmation if needed
-				log.Println("Warning: Closing window during active workout - workout will be lost")
+				// Stopping checkpoints the workout, so it's offered for resuming on the next launch
+				guiApp.Close()
+				log.Println("Closing window during active workout - progress saved, resume it on the next launch")
 			}
 			return e.Err
 		case app.FrameEvent:
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"heavybagworkout/internal/cli"
 	"heavybagworkout/internal/config"
 	"heavybagworkout/internal/generator"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
 	"heavybagworkout/internal/timer"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 func main() {
@@ -26,10 +33,26 @@ func main() {
 		includeDefensive   = flag.Bool("include-defensive", false, "Include defensive moves in combos (use with --no-include-defensive to disable)")
 		noIncludeDefensive = flag.Bool("no-include-defensive", false, "Disable defensive moves in combos (overrides config)")
 		useLLM             = flag.Bool("use-llm", false, "Use LLM for combo generation (overrides config)")
+		promptTemplate     = flag.String("prompt-template", "", "Path to a text/template file used to build the LLM workout prompt (overrides config)")
+		goal               = flag.String("goal", "", "Describe the workout you want and let the LLM plan all of it, e.g. \"12 minutes, counters off the slip, southpaw\"")
 		openAIAPIKey       = flag.String("openai-api-key", "", "OpenAI API key (overrides config and env var)")
+		llmBaseURL         = flag.String("llm-base-url", "", "OpenAI-compatible API base URL, e.g. a local llmstub at http://127.0.0.1:8089/v1 (overrides config and OPENAI_BASE_URL)")
 		stanceFlag         = flag.String("stance", "", "Boxer's stance: orthodox or southpaw (overrides config/preset)")
+		languageFlag       = flag.String("language", "", "Language of the callouts and workout display: en, es or pt (overrides config)")
+		calloutStyleFlag   = flag.String("callout-style", "", "How combos are called out and shown: numeric (1 2 3), short, full or coach (overrides config) (default: full)")
+		cueProfileFlag     = flag.String("cue-profile", "", "Sounds that mark each round: spoken, gym or a profile defined in the config (overrides config) (default: spoken)")
 		tempoFlag          = flag.String("tempo", "", "Workout tempo: slow (5s), medium (4s), fast (3s), or superfast (2s) (default: slow)")
-		saveAudioPath      = flag.String("save", "", "Save audio output to file (e.g., workout.m4a, workout.mp3). Format determined by extension.")
+		restModeFlag       = flag.String("rest-mode", "", "Rest mode: fixed, auto (rest until ready, type g) or earned (type d to finish a round early and bank the time as rest) (default: fixed)")
+		minRest            = flag.Int("min-rest", int(timer.DefaultMinRest.Seconds()), "Shortest auto rest period in seconds")
+		maxRest            = flag.Int("max-rest", int(timer.DefaultMaxRest.Seconds()), "Longest auto rest period in seconds, also the most rest a round can earn")
+		saveAudioPath      = flag.String("save", "", "Render the workout's audio to a file without running it (e.g., workout.wav, workout.m4a). Formats other than WAV need ffmpeg.")
+		dryRun             = flag.Bool("dry-run", false, "Print the workout's timeline (every period, beat and callout) and exit without running it")
+		exportTimeline     = flag.String("export-timeline", "", "Write the workout's timeline to a JSON file")
+		ttsReport          = flag.Bool("tts-report", false, "List the text-to-speech engines found on this system and exit")
+		samplePack         = flag.String("sample-pack", "", "Directory of a sample pack to play callouts from (overrides config)")
+		generatePack       = flag.String("generate-sample-pack", "", "Record a sample pack of every callout into a directory with the text-to-speech engine and exit")
+		musicFolder        = flag.String("music", "", "Folder of WAV, MP3 or FLAC files to play during the workout, ducked under the callouts (overrides config)")
+		musicRest          = flag.String("music-rest", "", "What the music does in rest periods: play, soften or pause (overrides config) (default: play)")
 		showVersion        = flag.Bool("version", false, "Show version information")
 		showHelp           = flag.Bool("help", false, "Show help message")
 	)
@@ -99,17 +122,112 @@ func main() {
 	if *openAIAPIKey != "" {
 		appConfig.OpenAIAPIKey = *openAIAPIKey
 	}
+	if *promptTemplate != "" {
+		appConfig.Generator.PromptTemplate = *promptTemplate
+	}
+	if *llmBaseURL != "" {
+		appConfig.Generator.BaseURL = *llmBaseURL
+	}
+	if *samplePack != "" {
+		appConfig.Audio.SamplePack = *samplePack
+	}
+	if *languageFlag != "" {
+		appConfig.Language = *languageFlag
+	}
+	if *calloutStyleFlag != "" {
+		appConfig.Audio.CalloutStyle = *calloutStyleFlag
+	}
+	if *cueProfileFlag != "" {
+		appConfig.Audio.CueProfile = *cueProfileFlag
+	}
+	if *musicFolder != "" {
+		appConfig.Audio.Music.Folder = *musicFolder
+	}
+	if *musicRest != "" {
+		appConfig.Audio.Music.Rest = *musicRest
+	}
 
 	// Validate configuration after applying overrides
 	if err := appConfig.Validate(); err != nil {
 		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
 		os.Exit(1)
 	}
+	locale, err := i18n.Load(appConfig.GetLanguage())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	calloutStyle := models.ParseCalloutStyle(appConfig.Audio.CalloutStyle)
+	cueProfile, _ := appConfig.Audio.GetCueProfile() // Checked with the config
+
+	// Find the text-to-speech engine for the callouts
+	tts, ttsStatuses, ttsErr := timer.SelectTTSEngine(appConfig.Audio.TTS.Engine, ttsSettings(appConfig.Audio.TTS, locale.Language))
+	if *ttsReport {
+		timer.WriteTTSReport(os.Stdout, ttsStatuses, tts)
+		if ttsErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", ttsErr)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if *generatePack != "" {
+		if ttsErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", ttsErr)
+			os.Exit(1)
+		}
+		rounds := max(timer.DefaultSamplePackRounds, appConfig.Workout.TotalRounds)
+		fmt.Printf("Recording %s sample pack with %s (round numbers up to %d)...\n", locale.Name, tts.Name(), rounds)
+		if err := timer.GenerateSamplePack(*generatePack, tts, rounds, locale); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating sample pack: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Sample pack saved to: %s\n", *generatePack)
+		os.Exit(0)
+	}
+
+	// Load the sample pack before anything else, so a broken pack is reported straight away
+	var pack *timer.SamplePack
+	if appConfig.Audio.SamplePack != "" {
+		pack, err = timer.LoadSamplePack(appConfig.Audio.SamplePack)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading sample pack: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	// Generate workout
 	fmt.Println("Puppy Power - Heavy Bag Workout App")
 	fmt.Println("=====================================")
-	fmt.Println("\nGenerating workout...")
+	if ttsErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, callouts will be silent (see --tts-report)\n", ttsErr)
+	} else {
+		fmt.Printf("Speech: %s\n", tts.Name())
+	}
+	if pack != nil {
+		fmt.Printf("Sample pack: %s\n", pack.Name)
+		if pack.MaxRound() < appConfig.Workout.TotalRounds {
+			fmt.Fprintf(os.Stderr, "Warning: sample pack %s only counts to %d, later round callouts will be spoken\n", pack.Name, pack.MaxRound())
+		}
+		if pack.Language != "" && !strings.EqualFold(pack.Language, locale.Language) {
+			fmt.Fprintf(os.Stderr, "Warning: sample pack %s was recorded in %q, the workout is in %q\n", pack.Name, pack.Language, locale.Language)
+		}
+	}
+
+	// Offer to resume a workout that was cut short
+	var resume *timer.Checkpoint
+	checkpoints, err := timer.DefaultCheckpointStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: workout checkpoints disabled: %v\n", err)
+	} else if sessions, err := timer.DefaultSessionLog(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: workout checkpoints disabled: %v\n", err)
+		checkpoints = nil
+	} else if !*dryRun && *saveAudioPath == "" {
+		resume = offerResume(bufio.NewReader(os.Stdin), os.Stdout, checkpoints, sessions, time.Now())
+	}
+
+	// Generate workout
+	if resume == nil {
+		fmt.Println("\nGenerating workout...")
+	}
 
 	workoutConfig := appConfig.Workout.ToModelsWorkoutConfig()
 	workoutPattern := appConfig.Pattern.ToModelsWorkoutPattern()
@@ -145,26 +263,76 @@ func main() {
 		}
 	}
 
+	// Parse and validate rest mode flag
+	restMode := models.ParseRestMode(*restModeFlag)
+	if restMode == models.RestUnknown {
+		fmt.Fprintf(os.Stderr, "Error: invalid rest mode '%s'. Must be one of: fixed, auto, earned\n", *restModeFlag)
+		os.Exit(1)
+	}
+
 	// Validate max moves against tempo limit (after tempo is parsed)
-	if appConfig.Pattern.MaxMoves > tempo.MaxMovesLimit() {
+	// Goal plans choose their own pattern and tempo, and are validated when generated
+	if *goal == "" && appConfig.Pattern.MaxMoves > tempo.MaxMovesLimit() {
 		fmt.Fprintf(os.Stderr, "Error: maximum moves per combo cannot exceed %d for %s tempo (got %d)\n", tempo.MaxMovesLimit(), tempo.DisplayName(), appConfig.Pattern.MaxMoves)
 		os.Exit(1)
 	}
 
 	var workout models.Workout
 
-	if appConfig.Generator.UseLLM {
+	if resume != nil {
+		// The checkpoint replaces the configured workout, stance and tempo
+		workout = resume.Workout
+		stance = &resume.Stance
+		fmt.Printf("\nResuming workout at %s\n", resume.Summary())
+	} else if *goal != "" {
+		apiKey := appConfig.GetOpenAIAPIKey()
+		if apiKey == "" && appConfig.Generator.RequiresAPIKey() {
+			fmt.Fprintf(os.Stderr, "\nError: OpenAI API key required for goal-based planning.\n")
+			fmt.Fprintf(os.Stderr, "Set OPENAI_API_KEY environment variable or use --openai-api-key flag.\n")
+			os.Exit(1)
+		}
+
+		fmt.Println("  Planning workout from goal with LLM...")
+		llmGenerator, err := generator.NewLLMWorkoutGeneratorFromConfig(apiKey, appConfig.Generator)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError setting up LLM generator: %v\n", err)
+			os.Exit(1)
+		}
+		llmGenerator.SetLocale(locale)
+		plan, err := llmGenerator.PlanWorkoutFromGoal(*goal)
+		if usage := llmGenerator.LastUsage(); len(usage.Attempts) > 0 {
+			fmt.Printf("  %s\n", usage.Summary())
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError planning workout from goal: %v\n", err)
+			os.Exit(1)
+		}
+
+		// The plan replaces the configured workout, pattern, stance and tempo
+		workout = plan.Workout
+		stance = &plan.Stance
+		tempo = plan.Tempo
+		printPlanSummary(plan)
+	} else if appConfig.Generator.UseLLM {
 		apiKey := appConfig.GetOpenAIAPIKey()
-		if apiKey == "" {
+		if apiKey == "" && appConfig.Generator.RequiresAPIKey() {
 			fmt.Fprintf(os.Stderr, "\nError: OpenAI API key required for LLM generation.\n")
 			fmt.Fprintf(os.Stderr, "Set OPENAI_API_KEY environment variable or use --openai-api-key flag.\n")
 			os.Exit(1)
 		}
 
 		fmt.Println("  Using LLM generator...")
-		llmGenerator := generator.NewLLMWorkoutGenerator(apiKey)
-		var err error
+		llmGenerator, err := generator.NewLLMWorkoutGeneratorFromConfig(apiKey, appConfig.Generator)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError setting up LLM generator: %v\n", err)
+			os.Exit(1)
+		}
+		llmGenerator.SetLocale(locale)
 		workout, err = llmGenerator.GenerateWorkoutWithStance(workoutConfig, workoutPattern, *stance)
+		// Show usage even when generation failed, since failed attempts are still billed
+		if usage := llmGenerator.LastUsage(); len(usage.Attempts) > 0 {
+			fmt.Printf("  %s\n", usage.Summary())
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\nError generating workout with LLM: %v\n", err)
 			os.Exit(1)
@@ -180,52 +348,169 @@ func main() {
 		}
 	}
 
-	fmt.Println("  Workout generated successfully!")
+	if resume == nil {
+		fmt.Println("  Workout generated successfully!")
+	}
 	fmt.Printf("  Total rounds: %d\n", len(workout.Rounds))
 	fmt.Println()
 
 	// Convert tempo to duration for the CLI interface
 	tempoDuration := tempo.Duration()
+	if resume != nil && resume.Tempo > 0 {
+		tempoDuration = resume.Tempo
+	}
+
+	if *dryRun || *exportTimeline != "" || *saveAudioPath != "" {
+		timeline := timer.CompileTimeline(workout, timer.TimelineOptions{
+			Stance:       *stance,
+			Tempo:        tempoDuration,
+			AudioPreRoll: timer.DefaultAudioPreRoll,
+			Locale:       locale,
+			CalloutStyle: calloutStyle,
+			CueProfile:   &cueProfile,
+		})
+		if *exportTimeline != "" {
+			if err := writeTimeline(*exportTimeline, timeline); err != nil {
+				fmt.Fprintf(os.Stderr, "Error exporting timeline: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("  Timeline saved to: %s\n", *exportTimeline)
+		}
+		if *dryRun {
+			fmt.Println("Timeline:")
+			if err := timeline.Format(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error printing timeline: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		if *saveAudioPath != "" {
+			fmt.Println("  Rendering workout audio...")
+			rendered := time.Now()
+			if err := saveWorkoutAudio(*saveAudioPath, timeline, tts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving workout audio: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Audio saved to: %s (rendered in %v)\n", *saveAudioPath, time.Since(rendered).Round(time.Millisecond))
+			return
+		}
+	}
 
-	// Create audio handler (enabled by default)
-	var audioHandler timer.AudioCueHandler
-	var recordingHandler *timer.RecordingAudioCueHandler
+	// Play background music through a mixer that the cues are mixed into
+	music := appConfig.Audio.Music
+	var mixer *synth.Mixer
+	if music.Folder != "" {
+		var playlist *synth.Playlist
+		if mixer, playlist, err = synth.StartMusic(music.Folder); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, playing without music\n", err)
+		} else {
+			defer mixer.Close()
+			mixer.SetDuckLevel(music.GetDuckLevel())
+			fmt.Printf("Music: %d tracks from %s\n\n", len(playlist.Files()), music.Folder)
+		}
+	}
+	rest, _ := timer.ParseMusicRest(music.Rest) // Checked with the config
 
-	if *saveAudioPath != "" {
-		// Create recording audio handler with workout and tempo information
-		var err error
-		recordingHandler, err = timer.NewRecordingAudioCueHandlerWithWorkout(*saveAudioPath, workout, tempoDuration)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating audio recording handler: %v\n", err)
-			os.Exit(1)
+	// Create the speakers' audio handler (enabled by default), playing callouts from the sample pack if there is one
+	var speakers timer.AudioCueHandler
+	if pack != nil {
+		fileHandler := timer.NewFileAudioCueHandler(true, pack)
+		fileHandler.SetTTSEngine(tts)
+		fileHandler.SetLocale(locale)
+		fileHandler.SetCalloutStyle(calloutStyle, tempoDuration)
+		if mixer != nil {
+			fileHandler.SetMusic(mixer, music.GetVolume(), rest)
 		}
-		audioHandler = recordingHandler
-		fmt.Printf("  Audio will be saved to: %s\n", *saveAudioPath)
-		fmt.Println()
+		speakers = fileHandler
 	} else {
-		audioHandler = timer.NewDefaultAudioCueHandler(true)
+		defaultHandler := timer.NewDefaultAudioCueHandler(true)
+		defaultHandler.SetTTSEngine(tts)
+		defaultHandler.SetLocale(locale)
+		defaultHandler.SetCalloutStyle(calloutStyle, tempoDuration)
+		if mixer != nil {
+			defaultHandler.SetMusic(mixer, music.GetVolume(), rest)
+		}
+		speakers = defaultHandler
+	}
+
+	// Send the cues to every configured output at once, or just play them on the speakers
+	audioHandler := speakers
+	var outputs *timer.CompositeAudioCueHandler
+	var recordings []*timer.RecordingAudioCueHandler
+	if len(appConfig.Audio.Outputs) > 0 {
+		var fanOut []timer.AudioOutput
+		fanOut, recordings = newAudioOutputs(appConfig.Audio.Outputs, speakers)
+		outputs = timer.NewCompositeAudioCueHandler(fanOut...)
+		audioHandler = outputs
 	}
 
 	// Create and run CLI interface with stance and tempo
 	workoutInterface := cli.NewWorkoutInterfaceWithStanceAndTempo(workout, audioHandler, *stance, tempoDuration)
-	if err := workoutInterface.Run(); err != nil {
-		// Cleanup recording handler if workout was interrupted
-		if recordingHandler != nil {
-			recordingHandler.Cleanup()
+	workoutInterface.SetLocale(locale)
+	workoutInterface.SetCalloutStyle(calloutStyle)
+	workoutInterface.SetCueProfile(&cueProfile)
+	if err := workoutInterface.SetRestMode(restMode, time.Duration(*minRest)*time.Second, time.Duration(*maxRest)*time.Second); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if checkpoints != nil {
+		workoutInterface.SetCheckpointStore(checkpoints)
+	}
+	if resume != nil {
+		workoutInterface.ResumeFrom(*resume)
+	}
+	err = workoutInterface.Run()
+	if outputs != nil {
+		outputs.Close() // Let the outputs in the background finish
+		for _, recording := range recordings {
+			recording.StopRecording() // Every output has played the last cue
 		}
+		for _, outputErr := range outputs.Errors() {
+			fmt.Fprintf(os.Stderr, "Warning: audio output %v\n", outputErr)
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running workout: %v\n", err)
 		os.Exit(1)
 	}
+	if mixer != nil && mixer.MusicErr() != nil {
+		fmt.Fprintf(os.Stderr, "Warning: the music stopped: %v\n", mixer.MusicErr())
+	}
+}
 
-	// If recording handler exists and workout completed, wait for finalization to complete
-	if recordingHandler != nil {
-		fmt.Println("\nFinalizing audio recording...")
-		if err := recordingHandler.WaitForFinalization(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error finalizing audio recording: %v\n", err)
-			os.Exit(1)
+// newAudioOutputs creates the configured audio outputs, with speakers as the speakers. Recordings record the
+// system's audio, starting straight away so the workout's first cues are caught, and are returned to be stopped
+// once it is over. Outputs that can't start are left out with a warning.
+func newAudioOutputs(configs []config.AudioOutputConfig, speakers timer.AudioCueHandler) ([]timer.AudioOutput, []*timer.RecordingAudioCueHandler) {
+	var outputs []timer.AudioOutput
+	var recordings []*timer.RecordingAudioCueHandler
+	for _, outputConfig := range configs {
+		output := timer.AudioOutput{
+			Name:       strings.ToLower(outputConfig.Type),
+			Background: outputConfig.IsBackground(),
+			Timeout:    outputConfig.GetTimeout(),
 		}
-		fmt.Printf("Audio saved to: %s\n", *saveAudioPath)
+		switch output.Name {
+		case "speakers":
+			output.Handler = speakers
+		case "recording":
+			recording, err := timer.NewRecordingAudioCueHandler(outputConfig.Path)
+			if err == nil {
+				err = recording.StartRecording()
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: not recording to %s: %v\n", outputConfig.Path, err)
+				continue
+			}
+			recording.SetPlayback(false) // It records what the other outputs play
+			output.Name = "recording " + outputConfig.Path
+			output.Handler = recording
+			recordings = append(recordings, recording)
+			fmt.Printf("Recording: %s\n", outputConfig.Path)
+		}
+		outputs = append(outputs, output)
 	}
+	return outputs, recordings
 }
 
 func loadConfig(configFile, preset string) (*config.AppConfig, error) {
@@ -256,20 +541,47 @@ func printHelp() {
 	fmt.Println("  --include-defensive       Include defensive moves in combos")
 	fmt.Println("  --no-include-defensive    Disable defensive moves in combos")
 	fmt.Println("  --use-llm                 Use LLM for combo generation (overrides config)")
+	fmt.Println("  --prompt-template string  Path to a text/template file used to build the LLM workout prompt (overrides config)")
+	fmt.Println("  --goal string             Describe the workout you want and let the LLM plan all of it (timing, pattern, tempo, stance, combos)")
 	fmt.Println("  --openai-api-key string   OpenAI API key (overrides config and env var)")
+	fmt.Println("  --llm-base-url string     OpenAI-compatible API base URL, e.g. a local llmstub (overrides config and OPENAI_BASE_URL)")
 	fmt.Println("  --stance string           Boxer's stance: orthodox or southpaw (overrides config/preset, defaults to orthodox if not set)")
+	fmt.Println("  --language string         Language of the callouts and workout display: en, es or pt (overrides config, default: en)")
+	fmt.Println("  --callout-style string    How combos are called out and shown: numeric (1 2 3), short, full or coach (overrides config, default: full)")
+	fmt.Println("  --cue-profile string      Sounds that mark each round: spoken, gym (bells and a 10s clapper) or a profile from the config (default: spoken)")
 	fmt.Println("  --tempo string            Workout tempo: slow (5s), medium (4s), fast (3s), or superfast (2s) (default: slow)")
-	fmt.Println("  --save string             Save audio output to file (e.g., workout.m4a, workout.mp3). Format determined by extension.")
+	fmt.Println("  --rest-mode string        Rest mode: fixed, auto (rest until ready) or earned (finish rounds early to bank rest) (default: fixed)")
+	fmt.Println("  --min-rest int            Shortest auto rest period in seconds (default: 10)")
+	fmt.Println("  --max-rest int            Longest auto rest period in seconds, also caps earned rest (default: 120)")
+	fmt.Println("  --save string             Render the workout's audio to a file without running it (e.g., workout.wav, workout.m4a). Formats other than WAV need ffmpeg.")
+	fmt.Println("  --dry-run                 Print the workout's timeline (every period, beat and callout) and exit")
+	fmt.Println("  --export-timeline string  Write the workout's timeline to a JSON file")
+	fmt.Println("  --tts-report              List the text-to-speech engines found on this system and exit")
+	fmt.Println("  --sample-pack string      Directory of a sample pack to play callouts from (overrides config)")
+	fmt.Println("  --generate-sample-pack string  Record a sample pack of every callout with the text-to-speech engine and exit")
+	fmt.Println("  --music string            Folder of WAV, MP3 or FLAC files to play during the workout, ducked under the callouts (overrides config)")
+	fmt.Println("  --music-rest string       What the music does in rest periods: play, soften or pause (overrides config, default: play)")
 	fmt.Println("  --version                 Show version information")
 	fmt.Println("  --help                    Show this help message")
 	fmt.Println("\nExamples:")
 	fmt.Println("  heavybagworkout --preset beta_style")
 	fmt.Println("  heavybagworkout --config configs/custom.json")
 	fmt.Println("  heavybagworkout --work-duration 30 --rounds 10 --use-llm")
+	fmt.Println("  heavybagworkout --use-llm --prompt-template prompts/strict-coach.tmpl")
+	fmt.Println("  heavybagworkout --use-llm --llm-base-url http://127.0.0.1:8089/v1")
+	fmt.Println("  heavybagworkout --goal \"12 minutes, focus on counters off the slip, southpaw, finish hard\"")
 	fmt.Println("  heavybagworkout --preset beta_style --stance southpaw")
 	fmt.Println("  heavybagworkout --pattern pyramid --min-moves 2 --max-moves 6 --include-defensive")
 	fmt.Println("  heavybagworkout --preset power --tempo fast")
+	fmt.Println("  heavybagworkout --preset power --tempo superfast --callout-style numeric")
+	fmt.Println("  heavybagworkout --preset beta_style --cue-profile gym")
+	fmt.Println("  heavybagworkout --preset endurance --rest-mode auto --min-rest 15 --max-rest 90")
 	fmt.Println("  heavybagworkout --preset beta_style --save workout.m4a")
+	fmt.Println("  heavybagworkout --preset power --tempo fast --dry-run --export-timeline timeline.json")
+	fmt.Println("  heavybagworkout --generate-sample-pack packs/coach")
+	fmt.Println("  heavybagworkout --preset beta_style --sample-pack packs/coach")
+	fmt.Println("  heavybagworkout --preset beta_style --language es")
+	fmt.Println("  heavybagworkout --preset endurance --music ~/Music/workout --music-rest soften")
 	fmt.Println("\nConfiguration Priority:")
 	fmt.Println("  1. Command-line flags (highest priority)")
 	fmt.Println("  2. Config file (--config)")
@@ -277,6 +589,138 @@ func printHelp() {
 	fmt.Println("  4. Default configuration (lowest priority)")
 }
 
+// saveWorkoutAudio renders a workout's timeline to an audio file, with silent callouts if there is no text-to-speech
+// engine. WAV files are written directly; other formats are converted from a rendered WAV file with ffmpeg.
+func saveWorkoutAudio(path string, timeline *timer.Timeline, tts timer.TTSEngine) error {
+	var clips timer.SpeechClipSource = timer.NewSilentSpeechClips()
+	if tts != nil {
+		clips = timer.NewTTSSpeechClips(tts)
+	}
+	renderer := timer.NewOfflineRenderer(clips)
+
+	wavPath := path
+	if !strings.EqualFold(filepath.Ext(path), ".wav") {
+		if _, err := exec.LookPath("ffmpeg"); err != nil {
+			return fmt.Errorf("ffmpeg is needed to save %s files, save to a .wav file instead", filepath.Ext(path))
+		}
+		dir, err := os.MkdirTemp("", "heavybag-render-*")
+		if err != nil {
+			return fmt.Errorf("failed to create render directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		wavPath = filepath.Join(dir, "workout.wav")
+	}
+
+	file, err := os.Create(wavPath)
+	if err != nil {
+		return fmt.Errorf("failed to create audio file: %w", err)
+	}
+	writer := bufio.NewWriter(file)
+	if err := renderer.RenderWAV(writer, timeline); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to render workout: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write audio file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write audio file: %w", err)
+	}
+
+	if wavPath != path {
+		if output, err := exec.Command("ffmpeg", "-y", "-loglevel", "error", "-i", wavPath, path).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to convert audio with ffmpeg: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// ttsSettings converts text-to-speech config to timer.TTSSettings for speaking a language
+func ttsSettings(tc config.TTSConfig, language string) timer.TTSSettings {
+	return timer.TTSSettings{
+		Language:     language,
+		Voice:        tc.Voice,
+		Rate:         tc.Rate,
+		Volume:       tc.Volume,
+		SpeakCommand: tc.SpeakCommand,
+		FileCommand:  tc.FileCommand,
+	}
+}
+
+// writeTimeline writes a workout's timeline to a JSON file
+func writeTimeline(path string, timeline *timer.Timeline) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create timeline file: %w", err)
+	}
+	if err := timeline.WriteJSON(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write timeline: %w", err)
+	}
+	return file.Close()
+}
+
+// offerResume asks whether to resume the workout left in the checkpoint store, log it as partially completed
+// or discard it. It returns the checkpoint to resume from, or nil to start a new workout.
+func offerResume(in *bufio.Reader, out io.Writer, checkpoints *timer.CheckpointStore, sessions *timer.SessionLog, now time.Time) *timer.Checkpoint {
+	checkpoint, err := checkpoints.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: discarding unreadable workout checkpoint: %v\n", err)
+		checkpoints.Clear()
+		return nil
+	}
+	if checkpoint == nil {
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nFound an unfinished workout from %s: %s.\n", checkpoint.SavedAt.Local().Format("Mon Jan 2 15:04"), checkpoint.Summary())
+	for {
+		fmt.Fprint(out, "[r]esume it, [l]og it as partially completed, or [d]iscard it? (r) ")
+		input, err := in.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(input))
+		if err != nil && answer == "" {
+			return nil // No answer: leave the checkpoint for next time
+		}
+
+		switch answer {
+		case "", "r", "resume":
+			return checkpoint
+		case "l", "log":
+			if err := sessions.Append(checkpoint.PartialSession(now)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				return nil // Keep the checkpoint so the session isn't lost
+			}
+			fmt.Fprintf(out, "Logged %d of %d rounds as partially completed.\n", checkpoint.RoundsCompleted(), len(checkpoint.Workout.Rounds))
+		case "d", "discard":
+			fmt.Fprintln(out, "Discarded.")
+		default:
+			continue
+		}
+		if err := checkpoints.Clear(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return nil
+	}
+}
+
+// printPlanSummary prints the settings the LLM chose for a goal-based plan
+func printPlanSummary(plan generator.WorkoutPlan) {
+	fmt.Printf("  Plan: %d rounds of %ds work / %ds rest, %s pattern (%d-%d moves), %s tempo, %s stance\n",
+		plan.Config.Workout.TotalRounds,
+		plan.Config.Workout.WorkDurationSeconds,
+		plan.Config.Workout.RestDurationSeconds,
+		plan.Config.Pattern.Type,
+		plan.Config.Pattern.MinMoves,
+		plan.Config.Pattern.MaxMoves,
+		plan.Tempo,
+		plan.Stance,
+	)
+	if plan.Notes != "" {
+		fmt.Printf("  Coach notes: %s\n", plan.Notes)
+	}
+}
+
 // parseStance parses a stance string and returns the corresponding Stance value
 func parseStance(s string) *models.Stance {
 	s = strings.ToLower(strings.TrimSpace(s))
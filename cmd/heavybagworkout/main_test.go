@@ -1,10 +1,15 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"flag"
 	"heavybagworkout/internal/config"
 	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/timer"
+	"heavybagworkout/internal/types"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -480,3 +485,59 @@ func TestTempoFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestOfferResume(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectResume   bool
+		expectCleared  bool
+		expectSessions int
+	}{
+		{name: "default resumes", input: "\n", expectResume: true},
+		{name: "resume", input: "r\n", expectResume: true},
+		{name: "log as partial", input: "l\n", expectCleared: true, expectSessions: 1},
+		{name: "discard", input: "discard\n", expectCleared: true},
+		{name: "asks again on an unknown answer", input: "x\nd\n", expectCleared: true},
+		{name: "no answer keeps the checkpoint", input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			checkpoints := timer.NewCheckpointStore(filepath.Join(dir, timer.CheckpointFileName))
+			sessions := timer.NewSessionLog(filepath.Join(dir, timer.SessionLogFileName))
+			round := models.NewWorkoutRound(1, models.NewCombo([]models.Move{models.NewPunchMove(models.Jab)}), 20*time.Second, 10*time.Second)
+			workout := models.NewWorkout(models.NewWorkoutConfig(20*time.Second, 10*time.Second, 2), []models.WorkoutRound{round, round})
+			checkpoint := timer.Checkpoint{Workout: workout, Round: 2, Period: types.PeriodWork, Remaining: 5 * time.Second}
+			if err := checkpoints.Save(checkpoint); err != nil {
+				t.Fatalf("unexpected error saving checkpoint: %v", err)
+			}
+
+			var out bytes.Buffer
+			resume := offerResume(bufio.NewReader(strings.NewReader(tt.input)), &out, checkpoints, sessions, time.Now())
+			if (resume != nil) != tt.expectResume {
+				t.Errorf("expected resume %v, got %v", tt.expectResume, resume)
+			}
+			if resume != nil && resume.Round != 2 {
+				t.Errorf("expected to resume at round 2, got %d", resume.Round)
+			}
+			if !strings.Contains(out.String(), "round 2 of 2, 5s left in the work period") {
+				t.Errorf("expected the prompt to describe the checkpoint, got %q", out.String())
+			}
+			if stored, _ := checkpoints.Load(); (stored == nil) != tt.expectCleared {
+				t.Errorf("expected checkpoint cleared %v, got %v", tt.expectCleared, stored == nil)
+			}
+			entries, err := sessions.Entries()
+			if err != nil {
+				t.Fatalf("unexpected error reading session log: %v", err)
+			}
+			if len(entries) != tt.expectSessions {
+				t.Fatalf("expected %d logged sessions, got %d", tt.expectSessions, len(entries))
+			}
+			if len(entries) == 1 && (entries[0].RoundsCompleted != 1 || entries[0].TotalRounds != 2 || entries[0].Completed) {
+				t.Errorf("expected a partial session of 1 of 2 rounds, got %+v", entries[0])
+			}
+		})
+	}
+}
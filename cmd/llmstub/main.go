@@ -0,0 +1,125 @@
+// Command llmstub runs a local OpenAI-compatible server for testing the LLM paths offline.
+//
+// Point the app at it with --llm-base-url http://127.0.0.1:8089/v1 (or generator.base_url in a config file).
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"heavybagworkout/internal/llmstub"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+func main() {
+	var (
+		addr       = flag.String("addr", "127.0.0.1:8089", "Address to listen on")
+		scriptFile = flag.String("script", "", "Path to a JSON array of scripted steps ({\"content\", \"fault\", \"delay_ms\"}), answered in order")
+		faultFlag  = flag.String("fault", "", "Fault for every unscripted request: bad_json, wrong_round_count, rate_limit, or server_error")
+		delay      = flag.Duration("delay", 0, "Delay before every unscripted response (e.g. 2s)")
+		recordFile = flag.String("record", "", "Append every request to this JSON lines file")
+		showHelp   = flag.Bool("help", false, "Show help message")
+	)
+	flag.Parse()
+
+	if *showHelp {
+		printHelp()
+		return
+	}
+
+	fault, err := llmstub.ParseFault(*faultFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	stub := llmstub.NewStub()
+	stub.SetDefault(llmstub.Step{Fault: fault, DelayMS: int(*delay / time.Millisecond)})
+
+	if *scriptFile != "" {
+		steps, err := loadScript(*scriptFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading script: %v\n", err)
+			os.Exit(1)
+		}
+		stub.Enqueue(steps...)
+		fmt.Printf("Loaded %d scripted steps from %s\n", len(steps), *scriptFile)
+	}
+
+	var encoder *json.Encoder
+	if *recordFile != "" {
+		f, err := os.OpenFile(*recordFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening record file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		encoder = json.NewEncoder(f)
+	}
+
+	var mu sync.Mutex
+	stub.OnRequest(func(req llmstub.RecordedRequest) {
+		mu.Lock()
+		defer mu.Unlock()
+		fault := string(req.Step.Fault)
+		if fault == "" {
+			fault = "none"
+		}
+		fmt.Printf("%s  model=%s prompt=%d chars fault=%s delay=%s\n", req.Time.Format("15:04:05"), req.Model, len(req.Prompt), fault, req.Step.Delay())
+		if encoder != nil {
+			if err := encoder.Encode(req); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to record request: %v\n", err)
+			}
+		}
+	})
+
+	fmt.Printf("LLM stub listening on http://%s/v1\n", *addr)
+	if err := http.ListenAndServe(*addr, stub); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadScript reads a JSON array of steps
+func loadScript(path string) ([]llmstub.Step, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	var steps []llmstub.Step
+	if err := json.Unmarshal(data, &steps); err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+	for i, step := range steps {
+		if _, err := llmstub.ParseFault(string(step.Fault)); err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return steps, nil
+}
+
+func printHelp() {
+	fmt.Println("llmstub - local OpenAI-compatible server for offline testing")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  llmstub [flags]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	flag.PrintDefaults()
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  # Rule-based workouts that always pass validation")
+	fmt.Println("  llmstub")
+	fmt.Println()
+	fmt.Println("  # Every request is rate limited")
+	fmt.Println("  llmstub --fault rate_limit")
+	fmt.Println()
+	fmt.Println("  # Bad JSON first, then a valid workout (exercises the retry)")
+	fmt.Println("  echo '[{\"fault\": \"bad_json\"}]' > script.json && llmstub --script script.json")
+	fmt.Println()
+	fmt.Println("  # Point the CLI at the stub")
+	fmt.Println("  heavybagworkout --use-llm --llm-base-url http://127.0.0.1:8089/v1")
+}
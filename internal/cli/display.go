@@ -2,62 +2,89 @@ package cli
 
 import (
 	"fmt"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
 	"heavybagworkout/internal/timer"
 	"heavybagworkout/internal/types"
 	"strings"
+	"sync"
 	"time"
 )
 
-// WorkoutDisplay handles the CLI display of workout information
+// WorkoutDisplay handles the CLI display of workout information.
+// The workout timer and the tempo beats update it from different goroutines, so its methods take mu.
 type WorkoutDisplay struct {
-	workout           models.Workout
-	stance            models.Stance
-	currentComboIdx   int
-	totalRounds       int
-	currentRound      int
-	currentPeriod     types.PeriodType
-	remainingTime     time.Duration
-	isPaused          bool
-	comboUpdateTicker *time.Ticker
-	comboUpdateDone   chan bool
-	audioHandler      timer.AudioCueHandler // Audio handler for beeps
-	tempo             time.Duration         // Interval between beeps during work period
-}
-
-// NewWorkoutDisplay creates a new workout display with orthodox stance (default) and slow tempo (5 seconds)
+	mu              sync.Mutex
+	workout         models.Workout
+	stance          models.Stance
+	currentComboIdx int
+	totalRounds     int
+	currentRound    int
+	currentPeriod   types.PeriodType
+	remainingTime   time.Duration
+	elapsedTime     time.Duration // Time rested so far in an open-ended rest period
+	openPeriod      bool          // The current period lasts until the boxer is ready, so the display counts up
+	restMode        models.RestMode
+	isPaused        bool
+	audioHandler    timer.AudioCueHandler // Audio handler for beeps
+	locale          *i18n.Locale          // Language of move names, periods and rounds, nil for English
+	calloutStyle    models.CalloutStyle   // How combo moves are named
+}
+
+// NewWorkoutDisplay creates a new workout display with orthodox stance (default)
 func NewWorkoutDisplay(workout models.Workout) *WorkoutDisplay {
 	return NewWorkoutDisplayWithStance(workout, models.Orthodox)
 }
 
-// NewWorkoutDisplayWithStance creates a new workout display with the specified stance and slow tempo (5 seconds)
+// NewWorkoutDisplayWithStance creates a new workout display with the specified stance
 func NewWorkoutDisplayWithStance(workout models.Workout, stance models.Stance) *WorkoutDisplay {
-	return NewWorkoutDisplayWithStanceAndTempo(workout, stance, 5*time.Second)
-}
-
-// NewWorkoutDisplayWithStanceAndTempo creates a new workout display with the specified stance and tempo
-func NewWorkoutDisplayWithStanceAndTempo(workout models.Workout, stance models.Stance, tempo time.Duration) *WorkoutDisplay {
 	return &WorkoutDisplay{
 		workout:      workout,
 		stance:       stance,
 		totalRounds:  len(workout.Rounds),
 		currentRound: 0,
-		tempo:        tempo,
 	}
 }
 
-// SetAudioHandler sets the audio handler for 2-second beeps during work periods
+// SetAudioHandler sets the audio handler for the tempo beeps during work periods
 func (wd *WorkoutDisplay) SetAudioHandler(handler timer.AudioCueHandler) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.audioHandler = handler
 }
 
 // SetStance sets the boxer's stance
 func (wd *WorkoutDisplay) SetStance(stance models.Stance) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.stance = stance
 }
 
+// SetLocale sets the language of move names, periods and rounds
+func (wd *WorkoutDisplay) SetLocale(locale *i18n.Locale) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	wd.locale = locale
+}
+
+// SetCalloutStyle sets how combo moves are named: numbers, short names, full names or coach style
+func (wd *WorkoutDisplay) SetCalloutStyle(style models.CalloutStyle) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	wd.calloutStyle = style
+}
+
+// SetRestMode sets the rest mode, which adds its control to the instructions
+func (wd *WorkoutDisplay) SetRestMode(mode models.RestMode) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	wd.restMode = mode
+}
+
 // OnWorkoutStart is called when the workout starts
 func (wd *WorkoutDisplay) OnWorkoutStart(totalRounds int) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.totalRounds = totalRounds
 	wd.currentRound = 1
 	wd.currentComboIdx = 0
@@ -69,23 +96,38 @@ func (wd *WorkoutDisplay) OnWorkoutStart(totalRounds int) {
 
 // OnPeriodStart is called when a period (work or rest) starts
 func (wd *WorkoutDisplay) OnPeriodStart(periodType types.PeriodType, roundNumber int, duration time.Duration) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.currentPeriod = periodType
 	wd.currentRound = roundNumber
 	wd.remainingTime = duration
+	wd.openPeriod = false
 	wd.currentComboIdx = 0
 
 	if periodType == types.PeriodWork {
-		wd.startComboUpdates()
 		wd.printWorkPeriodStart()
 	} else {
-		wd.stopComboUpdates()
 		wd.printRestPeriodStart()
 	}
 }
 
 // OnTimerUpdate is called on each timer tick
 func (wd *WorkoutDisplay) OnTimerUpdate(remaining time.Duration, periodType types.PeriodType, roundNumber int) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.remainingTime = remaining
+	wd.openPeriod = false
+	wd.currentPeriod = periodType
+	wd.currentRound = roundNumber
+	wd.updateDisplay()
+}
+
+// OnElapsedUpdate is called on each tick of an open-ended (auto rest) period
+func (wd *WorkoutDisplay) OnElapsedUpdate(elapsed time.Duration, periodType types.PeriodType, roundNumber int) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	wd.elapsedTime = elapsed
+	wd.openPeriod = true
 	wd.currentPeriod = periodType
 	wd.currentRound = roundNumber
 	wd.updateDisplay()
@@ -93,23 +135,31 @@ func (wd *WorkoutDisplay) OnTimerUpdate(remaining time.Duration, periodType type
 
 // OnPeriodEnd is called when a period ends
 func (wd *WorkoutDisplay) OnPeriodEnd(periodType types.PeriodType, roundNumber int) {
-	if periodType == types.PeriodWork {
-		wd.stopComboUpdates()
-	}
+	// The next period's start redraws the display
 }
 
 // OnWorkoutComplete is called when the workout completes
 func (wd *WorkoutDisplay) OnWorkoutComplete() {
-	wd.stopComboUpdates()
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.clearScreen()
 	wd.printWorkoutComplete()
 }
 
 // SetPaused sets the paused state
 func (wd *WorkoutDisplay) SetPaused(paused bool) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
 	wd.isPaused = paused
 }
 
+// paused reports whether the display shows the workout as paused
+func (wd *WorkoutDisplay) paused() bool {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	return wd.isPaused
+}
+
 // clearScreen clears the terminal screen
 func (wd *WorkoutDisplay) clearScreen() {
 	fmt.Print("\033[2J\033[H") // ANSI escape codes to clear screen and move cursor to top
@@ -125,7 +175,15 @@ func (wd *WorkoutDisplay) printHeader() {
 
 // printInstructions prints keyboard instructions
 func (wd *WorkoutDisplay) printInstructions() {
-	fmt.Println("Use Ctrl+C to cancel workout")
+	fmt.Println(wd.locale.Text("display.controls", nil))
+	fmt.Printf("  %s\n", wd.locale.Text("display.control_keys", nil))
+	switch wd.restMode {
+	case models.RestAuto:
+		fmt.Printf("  %s\n", wd.locale.Text("display.control_ready", nil))
+	case models.RestEarned:
+		fmt.Printf("  %s\n", wd.locale.Text("display.control_done", nil))
+	}
+	fmt.Println(wd.locale.Text("display.cancel_hint", nil))
 	fmt.Println()
 	fmt.Println("──────────────────────────────────────────────────────────────")
 	fmt.Println()
@@ -133,16 +191,22 @@ func (wd *WorkoutDisplay) printInstructions() {
 
 // printWorkPeriodStart prints the start of a work period
 func (wd *WorkoutDisplay) printWorkPeriodStart() {
-	fmt.Printf("🔥 ROUND %d - WORK PERIOD 🔥\n", wd.currentRound)
+	fmt.Printf("🔥 %s 🔥\n", wd.periodHeading(types.PeriodWork))
 	fmt.Println()
 }
 
 // printRestPeriodStart prints the start of a rest period
 func (wd *WorkoutDisplay) printRestPeriodStart() {
-	fmt.Printf("💤 ROUND %d - REST PERIOD 💤\n", wd.currentRound)
+	fmt.Printf("💤 %s 💤\n", wd.periodHeading(types.PeriodRest))
 	fmt.Println()
 }
 
+// periodHeading returns the heading for the start of a period, e.g. "ROUND 2 - REST PERIOD"
+func (wd *WorkoutDisplay) periodHeading(periodType types.PeriodType) string {
+	heading := wd.locale.Text("display.round_period", i18n.Vars{"round": wd.currentRound, "period": wd.locale.PeriodName(periodType)})
+	return strings.ToUpper(heading)
+}
+
 // updateDisplay updates the main display area
 func (wd *WorkoutDisplay) updateDisplay() {
 	// Clear and redraw the main content area
@@ -170,10 +234,13 @@ func (wd *WorkoutDisplay) updateDisplay() {
 		wd.printCurrentCombo()
 	} else {
 		fmt.Println()
-		fmt.Println("  Rest and recover...")
+		fmt.Printf("  %s\n", wd.locale.Text("display.rest_and_recover", nil))
 		fmt.Println()
 	}
 
+	// Print the round's coaching cue (work and rest)
+	wd.printCoachingCue()
+
 	// Print status (paused indicator)
 	wd.printStatus()
 
@@ -185,7 +252,7 @@ func (wd *WorkoutDisplay) updateDisplay() {
 func (wd *WorkoutDisplay) printRoundNumber() {
 	fmt.Println()
 	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-	fmt.Printf("                      ROUND %d of %d\n", wd.currentRound, wd.totalRounds)
+	fmt.Printf("                      %s\n", strings.ToUpper(wd.locale.Text("display.round_of", i18n.Vars{"round": wd.currentRound, "total": wd.totalRounds})))
 	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
 	fmt.Println()
 }
@@ -213,28 +280,28 @@ func (wd *WorkoutDisplay) printProgress() {
 	}
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 
-	fmt.Printf("Progress: [%s] %.0f%% (%d/%d rounds completed)\n", bar, progress, roundsCompleted, wd.totalRounds)
+	fmt.Printf("%s: [%s] %.0f%% (%s)\n", wd.locale.Text("display.progress", nil), bar, progress, wd.locale.Plural("display.rounds_completed", wd.totalRounds, i18n.Vars{"completed": roundsCompleted}))
 }
 
-// printTimer prints the countdown timer prominently
+// printTimer prints the countdown timer prominently, or the time rested so far in an open-ended rest period
 func (wd *WorkoutDisplay) printTimer() {
-	minutes := int(wd.remainingTime.Minutes())
-	seconds := int(wd.remainingTime.Seconds()) % 60
-	totalSeconds := int(wd.remainingTime.Seconds())
+	shown := wd.remainingTime
+	if wd.openPeriod {
+		shown = wd.elapsedTime
+	}
+	minutes := int(shown.Minutes())
+	seconds := int(shown.Seconds()) % 60
+	totalSeconds := int(shown.Seconds())
 
-	var periodLabel string
-	var periodEmoji string
+	periodLabel := strings.ToUpper(wd.locale.PeriodName(wd.currentPeriod))
+	periodEmoji := "💤"
 	if wd.currentPeriod == types.PeriodWork {
-		periodLabel = "WORK"
 		periodEmoji = "🔥"
-	} else {
-		periodLabel = "REST"
-		periodEmoji = "💤"
 	}
 
 	var pauseLabel string
 	if wd.isPaused {
-		pauseLabel = " ⏸ PAUSED"
+		pauseLabel = " ⏸ " + strings.ToUpper(wd.locale.Text("display.paused", nil))
 	}
 
 	// Display timer prominently with visual emphasis
@@ -248,8 +315,10 @@ func (wd *WorkoutDisplay) printTimer() {
 	fmt.Printf("└─────────────────┘\n")
 
 	// Show seconds remaining as a secondary indicator for quick reference
-	if totalSeconds > 0 {
-		fmt.Printf("   (%d seconds remaining)\n", totalSeconds)
+	if wd.openPeriod {
+		fmt.Printf("   (%s)\n", wd.locale.Plural("display.seconds_rested", totalSeconds, nil))
+	} else if totalSeconds > 0 {
+		fmt.Printf("   (%s)\n", wd.locale.Plural("display.seconds_remaining", totalSeconds, nil))
 	}
 
 	fmt.Println()
@@ -264,51 +333,72 @@ func (wd *WorkoutDisplay) printCurrentCombo() {
 	round := wd.workout.Rounds[wd.currentRound-1]
 	combo := round.Combo
 
-	fmt.Println("  🥊 COMBO:")
+	fmt.Printf("  🥊 %s:\n", strings.ToUpper(wd.locale.Text("display.combo", nil)))
 
 	// Display combo in a formatted way, highlighting defensive moves
 	if len(combo.Moves) == 0 {
-		fmt.Println("     (empty combo)")
+		fmt.Printf("     (%s)\n", wd.locale.Text("display.empty_combo", nil))
 		fmt.Println()
 		return
 	}
 
-	// Build formatted combo string with visual distinction for defensive moves
+	// Display as a sequence with separators, with visual distinction for defensive moves
+	fmt.Printf("     %s\n", wd.formatCombo(combo))
+
+	// Show move count breakdown if there are defensive moves
+	if counts := wd.moveCounts(combo); counts != "" {
+		fmt.Printf("     (%s)\n", counts)
+	}
+
+	fmt.Println()
+}
+
+// formatCombo formats a combo's moves as a sequence in the callout style, defensive moves marked with a shield
+func (wd *WorkoutDisplay) formatCombo(combo models.Combo) string {
 	formattedMoves := make([]string, 0, len(combo.Moves))
 	for _, move := range combo.Moves {
-		if move.IsPunch() && move.Punch != nil {
-			// Punches shown as names based on stance
-			punchName := move.Punch.NameForStance(wd.stance)
-			formattedMoves = append(formattedMoves, punchName)
-		} else if move.IsDefensive() && move.Defensive != nil {
-			// Defensive moves shown with shield emoji and name
-			formattedMoves = append(formattedMoves, fmt.Sprintf("🛡 %s", move.String()))
-		} else {
-			formattedMoves = append(formattedMoves, move.String())
+		name := wd.locale.StyledMoveName(move, wd.stance, wd.calloutStyle)
+		if move.IsDefensive() {
+			name = "🛡 " + name
 		}
+		formattedMoves = append(formattedMoves, name)
 	}
+	return strings.Join(formattedMoves, " → ")
+}
 
-	// Display as a sequence with separators
-	comboStr := strings.Join(formattedMoves, " → ")
-	fmt.Printf("     %s\n", comboStr)
-
-	// Show move count breakdown if there are defensive moves
-	hasDefensive := false
+// moveCounts returns how many punches and defensive moves a combo has, e.g. "2 punches, 1 defensive move",
+// or "" if it doesn't mix them
+func (wd *WorkoutDisplay) moveCounts(combo models.Combo) string {
 	punchCount := 0
 	defensiveCount := 0
 	for _, move := range combo.Moves {
 		if move.IsPunch() {
 			punchCount++
 		} else if move.IsDefensive() {
-			hasDefensive = true
 			defensiveCount++
 		}
 	}
+	if defensiveCount == 0 || punchCount == 0 {
+		return ""
+	}
+	return wd.locale.Plural("display.punches", punchCount, nil) + ", " + wd.locale.Plural("display.defensive_moves", defensiveCount, nil)
+}
 
-	if hasDefensive && punchCount > 0 {
-		fmt.Printf("     (%d punches, %d defensive moves)\n", punchCount, defensiveCount)
+// currentCue returns the coaching cue for the current round in the display's language, or "" if there isn't one
+func (wd *WorkoutDisplay) currentCue() string {
+	if wd.currentRound < 1 || wd.currentRound > len(wd.workout.Rounds) {
+		return ""
 	}
+	return wd.locale.Cue(wd.workout.Rounds[wd.currentRound-1].Cue)
+}
 
+// printCoachingCue prints the coaching cue for the current round
+func (wd *WorkoutDisplay) printCoachingCue() {
+	cue := wd.currentCue()
+	if cue == "" {
+		return
+	}
+	fmt.Printf("  💡 %s: %s\n", strings.ToUpper(wd.locale.Text("display.coach", nil)), cue)
 	fmt.Println()
 }
 
@@ -317,88 +407,38 @@ func (wd *WorkoutDisplay) printStatus() {
 	// Status display (currently no status to show)
 }
 
-// startComboUpdates starts a ticker that plays a beep at the configured tempo interval during work periods
-// The combo remains the same throughout the round - this is just a reminder cue
-func (wd *WorkoutDisplay) startComboUpdates() {
-	wd.stopComboUpdates() // Stop any existing ticker
-
-	// Use tempo if set, otherwise default to 5 seconds
-	tempoInterval := wd.tempo
-	if tempoInterval == 0 {
-		tempoInterval = 5 * time.Second
-	}
-
-	wd.comboUpdateTicker = time.NewTicker(tempoInterval)
-	wd.comboUpdateDone = make(chan bool, 1) // Buffered channel to allow non-blocking send
-
-	// Capture channel references before starting goroutine to avoid nil pointer issues
-	tickerChan := wd.comboUpdateTicker.C
-	doneChan := wd.comboUpdateDone
-
-	go func() {
-		for {
-			select {
-			case <-tickerChan:
-				if wd.currentPeriod == types.PeriodWork && !wd.isPaused {
-					// Play beep as a reminder to execute the combo again
-					// The combo stays the same throughout the round
-					if wd.audioHandler != nil {
-						wd.audioHandler.PlayBeep()
-					} else {
-						fmt.Print("\a") // Fallback to system bell if no audio handler
-					}
-					// Refresh display (combo remains the same)
-					wd.updateDisplay()
-				}
-			case <-doneChan:
-				return
-			}
+// followBeats handles the workout timer's tempo beats until the subscription is closed.
+// Run it on its own goroutine.
+func (wd *WorkoutDisplay) followBeats(sub *timer.Subscription) {
+	for event := range sub.Events() {
+		if event.Type == timer.EventBeat {
+			wd.onBeat()
 		}
-	}()
-}
-
-// stopComboUpdates stops the combo update ticker
-func (wd *WorkoutDisplay) stopComboUpdates() {
-	if wd.comboUpdateTicker != nil {
-		wd.comboUpdateTicker.Stop()
-
-		// Drain any pending values from the ticker channel to prevent
-		// the goroutine from processing stale ticks after we signal shutdown
-		for {
-			select {
-			case <-wd.comboUpdateTicker.C:
-				// Drain one pending tick value
-			default:
-				// No more pending values, done draining
-				goto tickerDrained
-			}
-		}
-	tickerDrained:
-		// Safe to set to nil now since goroutine uses captured tickerChan reference
-		wd.comboUpdateTicker = nil
 	}
-	if wd.comboUpdateDone != nil {
-		// Send shutdown signal to goroutine (non-blocking with buffered channel)
-		select {
-		case wd.comboUpdateDone <- true:
-			// Shutdown signal sent successfully
-		default:
-			// Channel already has a value (already signaled), skip
-		}
-		// Safe to set to nil now since goroutine uses captured doneChan reference
-		wd.comboUpdateDone = nil
+}
+
+// onBeat plays the tempo click as a reminder to execute the combo again and refreshes the display.
+// The combo stays the same throughout the round.
+func (wd *WorkoutDisplay) onBeat() {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	if wd.audioHandler != nil {
+		timer.PlayTempoBeat(wd.audioHandler)
+	} else {
+		fmt.Print("\a") // Fallback to system bell if no audio handler
 	}
+	wd.updateDisplay()
 }
 
 // printWorkoutComplete prints the workout completion message
 func (wd *WorkoutDisplay) printWorkoutComplete() {
 	wd.printHeader()
 	fmt.Println()
-	fmt.Println("  🎉 WORKOUT COMPLETE! 🎉")
+	fmt.Printf("  🎉 %s 🎉\n", strings.ToUpper(wd.locale.Text("display.workout_complete", nil)))
 	fmt.Println()
-	fmt.Printf("  Completed %d rounds\n", wd.totalRounds)
+	fmt.Printf("  %s\n", wd.locale.Plural("display.completed_rounds", wd.totalRounds, nil))
 	fmt.Println()
-	fmt.Println("  Great job! You did it! 💪")
+	fmt.Printf("  %s 💪\n", wd.locale.Text("display.great_job", nil))
 	fmt.Println()
 }
 
@@ -406,79 +446,57 @@ func (wd *WorkoutDisplay) printWorkoutComplete() {
 func (wd *WorkoutDisplay) PrintInitialDisplay() {
 	wd.clearScreen()
 	wd.printHeader()
-	fmt.Println("Workout Configuration:")
-	fmt.Printf("  Total Rounds: %d\n", wd.totalRounds)
+	fmt.Println(wd.locale.Text("display.configuration", nil))
+	fmt.Printf("  %s\n", wd.locale.Text("display.total_rounds", i18n.Vars{"rounds": wd.totalRounds}))
 	if len(wd.workout.Rounds) > 0 {
-		fmt.Printf("  Work Duration: %.0f seconds\n", wd.workout.Rounds[0].WorkDuration.Seconds())
-		fmt.Printf("  Rest Duration: %.0f seconds\n", wd.workout.Rounds[0].RestDuration.Seconds())
+		fmt.Printf("  %s\n", wd.locale.Plural("display.work_duration", int(wd.workout.Rounds[0].WorkDuration.Seconds()), nil))
+		fmt.Printf("  %s\n", wd.locale.Plural("display.rest_duration", int(wd.workout.Rounds[0].RestDuration.Seconds()), nil))
 	}
 	fmt.Println()
-	fmt.Println("Press [Enter] to view workout preview, or [Q] to quit...")
+	fmt.Println(wd.locale.Text("display.press_enter_preview", nil))
 }
 
 // PrintWorkoutPreview displays all combos for all rounds before the workout starts
 func (wd *WorkoutDisplay) PrintWorkoutPreview() {
 	wd.clearScreen()
 	wd.printHeader()
-	fmt.Println("WORKOUT PREVIEW")
+	fmt.Println(strings.ToUpper(wd.locale.Text("display.preview", nil)))
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
-	fmt.Printf("Total Rounds: %d\n", wd.totalRounds)
+	fmt.Println(wd.locale.Text("display.total_rounds", i18n.Vars{"rounds": wd.totalRounds}))
 	if len(wd.workout.Rounds) > 0 {
-		fmt.Printf("Work Duration: %.0f seconds per round\n", wd.workout.Rounds[0].WorkDuration.Seconds())
-		fmt.Printf("Rest Duration: %.0f seconds per round\n", wd.workout.Rounds[0].RestDuration.Seconds())
+		fmt.Println(wd.locale.Plural("display.work_duration_per_round", int(wd.workout.Rounds[0].WorkDuration.Seconds()), nil))
+		fmt.Println(wd.locale.Plural("display.rest_duration_per_round", int(wd.workout.Rounds[0].RestDuration.Seconds()), nil))
 	}
 	fmt.Println()
-	fmt.Println("Round-by-Round Combos:")
+	fmt.Println(wd.locale.Text("display.round_by_round", nil))
 	fmt.Println("──────────────────────────────────────────────────────────────")
 	fmt.Println()
 
 	if len(wd.workout.Rounds) == 0 {
-		fmt.Println("  No rounds in this workout.")
+		fmt.Printf("  %s\n", wd.locale.Text("display.no_rounds", nil))
 		fmt.Println()
 	} else {
 		for i, round := range wd.workout.Rounds {
-			fmt.Printf("Round %d:\n", round.RoundNumber)
+			fmt.Printf("%s:\n", wd.locale.Text("display.round", i18n.Vars{"round": round.RoundNumber}))
 
 			combo := round.Combo
 
 			if len(combo.Moves) == 0 {
-				fmt.Println("  (empty combo)")
+				fmt.Printf("  (%s)\n", wd.locale.Text("display.empty_combo", nil))
 				fmt.Println()
 				continue
 			}
 
-			// Build formatted combo string
-			formattedMoves := make([]string, 0, len(combo.Moves))
-			for _, move := range combo.Moves {
-				if move.IsPunch() && move.Punch != nil {
-					punchName := move.Punch.NameForStance(wd.stance)
-					formattedMoves = append(formattedMoves, punchName)
-				} else if move.IsDefensive() && move.Defensive != nil {
-					formattedMoves = append(formattedMoves, fmt.Sprintf("🛡 %s", move.String()))
-				} else {
-					formattedMoves = append(formattedMoves, move.String())
-				}
-			}
-
-			comboStr := strings.Join(formattedMoves, " → ")
-			fmt.Printf("  %s\n", comboStr)
+			fmt.Printf("  %s\n", wd.formatCombo(combo))
 
 			// Show move count breakdown if there are defensive moves
-			hasDefensive := false
-			punchCount := 0
-			defensiveCount := 0
-			for _, move := range combo.Moves {
-				if move.IsPunch() {
-					punchCount++
-				} else if move.IsDefensive() {
-					hasDefensive = true
-					defensiveCount++
-				}
+			if counts := wd.moveCounts(combo); counts != "" {
+				fmt.Printf("    (%s)\n", counts)
 			}
 
-			if hasDefensive && punchCount > 0 {
-				fmt.Printf("    (%d punches, %d defensive moves)\n", punchCount, defensiveCount)
+			if round.Cue != "" {
+				fmt.Printf("    💡 %s\n", wd.locale.Cue(round.Cue))
 			}
 
 			fmt.Println()
@@ -493,5 +511,5 @@ func (wd *WorkoutDisplay) PrintWorkoutPreview() {
 
 	fmt.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
-	fmt.Println("Press [Enter] to start the workout, or [Q] to quit...")
+	fmt.Println(wd.locale.Text("display.press_enter_start", nil))
 }
@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/mocks"
 	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/timer"
 	"heavybagworkout/internal/types"
 	"strings"
 	"testing"
@@ -32,8 +34,6 @@ func TestNewWorkoutDisplay(t *testing.T) {
 		t.Errorf("expected stance Orthodox, got %v", display.stance)
 	}
 
-	// Clean up
-	display.stopComboUpdates()
 }
 
 func TestNewWorkoutDisplayWithStance(t *testing.T) {
@@ -120,9 +120,6 @@ func TestWorkoutDisplay_OnPeriodStart(t *testing.T) {
 		t.Errorf("expected remainingTime 10s, got %v", display.remainingTime)
 	}
 
-	// Clean up combo updates that were started during work period
-	display.stopComboUpdates()
-	time.Sleep(100 * time.Millisecond) // Wait for goroutine to stop
 }
 
 func TestWorkoutDisplay_OnTimerUpdate(t *testing.T) {
@@ -135,9 +132,6 @@ func TestWorkoutDisplay_OnTimerUpdate(t *testing.T) {
 
 	display.OnTimerUpdate(15*time.Second, types.PeriodWork, 1)
 
-	// Clean up
-	display.stopComboUpdates()
-
 	if display.remainingTime != 15*time.Second {
 		t.Errorf("expected remainingTime 15s, got %v", display.remainingTime)
 	}
@@ -149,6 +143,26 @@ func TestWorkoutDisplay_OnTimerUpdate(t *testing.T) {
 	}
 }
 
+func TestWorkoutDisplay_OnElapsedUpdate(t *testing.T) {
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 1),
+		[]models.WorkoutRound{},
+	)
+
+	display := NewWorkoutDisplay(workout)
+
+	display.OnElapsedUpdate(7*time.Second, types.PeriodRest, 1)
+	if !display.openPeriod || display.elapsedTime != 7*time.Second {
+		t.Errorf("expected an open period with 7s elapsed, got open %v with %v", display.openPeriod, display.elapsedTime)
+	}
+
+	// Ready turns the period back into a countdown
+	display.OnTimerUpdate(3*time.Second, types.PeriodRest, 1)
+	if display.openPeriod {
+		t.Error("expected a countdown after a timer update")
+	}
+}
+
 func TestWorkoutDisplay_SetPaused(t *testing.T) {
 	workout := models.NewWorkout(
 		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 1),
@@ -167,9 +181,6 @@ func TestWorkoutDisplay_SetPaused(t *testing.T) {
 		t.Error("expected isPaused false, got true")
 	}
 
-	// Clean up any combo updates that might have been started
-	display.stopComboUpdates()
-	time.Sleep(50 * time.Millisecond) // Wait for goroutine cleanup if any
 }
 
 func TestWorkoutDisplay_printRoundNumber(t *testing.T) {
@@ -386,7 +397,7 @@ func TestWorkoutDisplay_printCurrentCombo_EmptyCombo(t *testing.T) {
 	}
 }
 
-func TestWorkoutDisplay_startComboUpdates(t *testing.T) {
+func TestWorkoutDisplay_FollowBeats(t *testing.T) {
 	workout := models.NewWorkout(
 		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 1),
 		[]models.WorkoutRound{
@@ -394,144 +405,142 @@ func TestWorkoutDisplay_startComboUpdates(t *testing.T) {
 		},
 	)
 
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAudioHandler := mocks.NewMockAudioCueHandler(ctrl)
+	// One beep per beat, none for the moves within it
+	mockAudioHandler.EXPECT().PlayBeep().Times(2)
+
 	display := NewWorkoutDisplay(workout)
+	display.SetAudioHandler(mockAudioHandler)
 	display.currentPeriod = types.PeriodWork
 	display.currentRound = 1
-	display.isPaused = false
 
-	display.startComboUpdates()
+	bus := timer.NewEventBus()
+	sub := bus.SubscribeBlocking(0)
+	done := make(chan struct{})
+	go func() {
+		display.followBeats(sub)
+		close(done)
+	}()
 
-	if display.comboUpdateTicker == nil {
-		t.Error("expected comboUpdateTicker to be set")
+	for _, eventType := range []timer.EventType{timer.EventBeat, timer.EventComboDone, timer.EventTick, timer.EventBeat} {
+		bus.Publish(timer.Event{Type: eventType, Round: 1, Period: types.PeriodWork})
 	}
-	if display.comboUpdateDone == nil {
-		t.Error("expected comboUpdateDone to be set")
-	}
-
-	// Wait a bit to ensure ticker is running
-	time.Sleep(100 * time.Millisecond)
-
-	display.stopComboUpdates()
+	sub.Unsubscribe()
+	<-done
+}
 
-	if display.comboUpdateTicker != nil {
-		t.Error("expected comboUpdateTicker to be nil after stop")
+// advanceUntilDone advances the fake clock a second at a time until done is closed
+func advanceUntilDone(clock *timer.FakeClock, done <-chan struct{}, maxSteps int) bool {
+	for step := 0; step < maxSteps; step++ {
+		for clock.Waiters() == 0 {
+			select {
+			case <-done:
+				return true
+			case <-time.After(time.Millisecond):
+			}
+		}
+		select {
+		case <-done:
+			return true
+		default:
+		}
+		clock.Advance(time.Second)
 	}
-	if display.comboUpdateDone != nil {
-		t.Error("expected comboUpdateDone to be nil after stop")
+	select {
+	case <-done:
+		return true
+	default:
+		return false
 	}
 }
 
-func TestWorkoutDisplay_stopComboUpdates(t *testing.T) {
-	workout := models.NewWorkout(
-		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 1),
-		[]models.WorkoutRound{},
-	)
-
-	display := NewWorkoutDisplay(workout)
-
-	// Stop when not started should not panic
-	display.stopComboUpdates()
-
-	// Start then stop
-	display.startComboUpdates()
-	time.Sleep(50 * time.Millisecond)
-	display.stopComboUpdates()
-
-	// Stop again should not panic
-	display.stopComboUpdates()
-}
-
-func TestWorkoutDisplay_TempoControlsBeepInterval(t *testing.T) {
+// runBeatWorkout runs a workout of one 20s round on a fake clock with the display following the tempo beats.
+// The control function is called once the work period has started.
+func runBeatWorkout(t *testing.T, tempo time.Duration, audioHandler timer.AudioCueHandler, control func(wi *WorkoutInterface, clock *timer.FakeClock)) {
+	t.Helper()
 	workout := models.NewWorkout(
 		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 1),
 		[]models.WorkoutRound{
 			models.NewWorkoutRound(1, models.NewCombo([]models.Move{models.NewPunchMove(models.Jab)}), 20*time.Second, 10*time.Second),
 		},
 	)
+	clock := timer.NewFakeClock(time.Unix(0, 0))
+	wi := NewWorkoutInterfaceWithStanceAndTempo(workout, nil, models.Orthodox, tempo)
+	wi.workoutTimer.SetClock(clock)
+	wi.workoutTimer.SetDisplayHandler(wi.display)
+	wi.display.SetAudioHandler(audioHandler)
+	completed := make(chan struct{})
+	wi.workoutTimer.OnWorkoutComplete(func() { close(completed) })
+
+	beats := wi.workoutTimer.Events().SubscribeBlocking(beatBufferSize)
+	followed := make(chan struct{})
+	go func() {
+		wi.display.followBeats(beats)
+		close(followed)
+	}()
+
+	if err := wi.workoutTimer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout: %v", err)
+	}
+	if control != nil {
+		clock.BlockUntil(1)
+		control(wi, clock)
+	}
+	if !advanceUntilDone(clock, completed, 60) {
+		t.Fatal("workout did not complete")
+	}
+	beats.Unsubscribe()
+	<-followed
+}
 
+func TestWorkoutDisplay_TempoControlsBeepInterval(t *testing.T) {
 	testCases := []struct {
-		name             string
-		tempo            time.Duration
-		expectedInterval time.Duration
+		name          string
+		tempo         time.Duration
+		expectedBeeps int
 	}{
-		{
-			name:             "slow tempo (5 seconds)",
-			tempo:            5 * time.Second,
-			expectedInterval: 5 * time.Second,
-		},
-		{
-			name:             "medium tempo (4 seconds)",
-			tempo:            4 * time.Second,
-			expectedInterval: 4 * time.Second,
-		},
-		{
-			name:             "fast tempo (3 seconds)",
-			tempo:            3 * time.Second,
-			expectedInterval: 3 * time.Second,
-		},
-		{
-			name:             "superfast tempo (2 seconds)",
-			tempo:            2 * time.Second,
-			expectedInterval: 2 * time.Second,
-		},
-		{
-			name:             "default tempo (0 defaults to 5 seconds)",
-			tempo:            0,
-			expectedInterval: 5 * time.Second,
-		},
+		{name: "slow tempo (5 seconds)", tempo: 5 * time.Second, expectedBeeps: 4},
+		{name: "medium tempo (4 seconds)", tempo: 4 * time.Second, expectedBeeps: 5},
+		{name: "fast tempo (3 seconds)", tempo: 3 * time.Second, expectedBeeps: 7},
+		{name: "superfast tempo (2 seconds)", tempo: 2 * time.Second, expectedBeeps: 10},
+		{name: "default tempo (0 defaults to 5 seconds)", tempo: 0, expectedBeeps: 4},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			display := NewWorkoutDisplayWithStanceAndTempo(workout, models.Orthodox, tt.tempo)
-			display.currentPeriod = types.PeriodWork
-			display.currentRound = 1
-			display.isPaused = false
-
-			// Verify tempo field is set correctly
-			if tt.tempo == 0 {
-				// When tempo is 0, it should still be 0 in the struct (defaulting happens in startComboUpdates)
-				// But the expected interval is 5 seconds
-			} else {
-				if display.tempo != tt.tempo {
-					t.Errorf("expected tempo field to be %v, got %v", tt.tempo, display.tempo)
-				}
-			}
-
-			// Create a mock audio handler using gomock
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockAudioHandler := mocks.NewMockAudioCueHandler(ctrl)
 
-			// Expect at least one beep call after the first interval
-			mockAudioHandler.EXPECT().PlayBeep().MinTimes(1).MaxTimes(10)
-
-			display.SetAudioHandler(mockAudioHandler)
-
-			// Start combo updates
-			display.startComboUpdates()
-			defer display.stopComboUpdates()
-
-			if display.comboUpdateTicker == nil {
-				t.Fatal("expected comboUpdateTicker to be set")
-			}
-
-			// Wait for at least one tick to fire (ticker fires after the first interval)
-			// Wait slightly longer than the expected interval to account for timing variations
-			waitTime := tt.expectedInterval + 500*time.Millisecond
-			time.Sleep(waitTime)
-
-			// For faster tempos, wait for another interval to verify multiple beeps
-			if tt.expectedInterval <= 3*time.Second {
-				time.Sleep(tt.expectedInterval + 500*time.Millisecond)
-			}
+			// A beep as the 20s work period starts and then every tempo interval, none in the rest period
+			mockAudioHandler.EXPECT().PlayBeep().Times(tt.expectedBeeps)
 
-			// Give a small buffer for goroutine to process
-			time.Sleep(100 * time.Millisecond)
+			runBeatWorkout(t, tt.tempo, mockAudioHandler, nil)
 		})
 	}
 }
 
+func TestWorkoutDisplay_NoBeepsWhilePaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAudioHandler := mocks.NewMockAudioCueHandler(ctrl)
+	// Beats at 0s, 5s, 10s and 15s of work time, however long the pause
+	mockAudioHandler.EXPECT().PlayBeep().Times(4)
+
+	runBeatWorkout(t, 5*time.Second, mockAudioHandler, func(wi *WorkoutInterface, clock *timer.FakeClock) {
+		clock.Advance(3 * time.Second)
+		if err := wi.handleCommand("p"); err != nil {
+			t.Fatalf("unexpected error pausing: %v", err)
+		}
+		clock.Advance(time.Minute)
+		if err := wi.handleCommand("p"); err != nil {
+			t.Fatalf("unexpected error resuming: %v", err)
+		}
+	})
+}
+
 func TestWorkoutDisplay_OnPeriodEnd(t *testing.T) {
 	workout := models.NewWorkout(
 		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 1),
@@ -542,16 +551,9 @@ func TestWorkoutDisplay_OnPeriodEnd(t *testing.T) {
 
 	display := NewWorkoutDisplay(workout)
 	display.currentPeriod = types.PeriodWork
-	display.startComboUpdates()
-
-	// Wait a bit to let goroutine start
-	time.Sleep(50 * time.Millisecond)
 
+	// Should not panic
 	display.OnPeriodEnd(types.PeriodWork, 1)
-
-	// Clean up
-	display.stopComboUpdates()
-	time.Sleep(100 * time.Millisecond) // Wait for goroutine to stop
 }
 
 func TestWorkoutDisplay_OnWorkoutComplete(t *testing.T) {
@@ -564,18 +566,9 @@ func TestWorkoutDisplay_OnWorkoutComplete(t *testing.T) {
 	display.totalRounds = 3
 	display.currentRound = 1
 	display.currentPeriod = types.PeriodWork
-	display.startComboUpdates()
-
-	// Wait a bit to let goroutine start
-	time.Sleep(50 * time.Millisecond)
 
+	// Should not panic
 	display.OnWorkoutComplete()
-
-	// Verify combo updates are stopped
-	time.Sleep(100 * time.Millisecond) // Wait for goroutine to stop
-	if display.comboUpdateTicker != nil {
-		t.Error("expected comboUpdateTicker to be nil after workout complete")
-	}
 }
 
 func TestWorkoutDisplay_printProgress_Calculation(t *testing.T) {
@@ -671,10 +664,7 @@ func TestWorkoutDisplay_ComboRemainsConstantPerRound(t *testing.T) {
 		}
 	}
 
-	// Stop combo updates for round 1
 	display.OnPeriodEnd(types.PeriodWork, 1)
-	display.stopComboUpdates()
-	time.Sleep(100 * time.Millisecond)
 
 	// Start Round 2: combo index should reset to 0
 	display.OnPeriodStart(types.PeriodWork, 2, 20*time.Second)
@@ -706,12 +696,10 @@ func TestWorkoutDisplay_ComboRemainsConstantPerRound(t *testing.T) {
 		// This is just a sanity check - the important thing is that combo stayed constant within each round
 	}
 
-	display.stopComboUpdates()
-	time.Sleep(100 * time.Millisecond)
 }
 
-func TestWorkoutDisplay_ComboConstantDuringTickerFires(t *testing.T) {
-	// Test that even when the 2-second ticker fires multiple times, the combo remains the same
+func TestWorkoutDisplay_ComboConstantDuringBeats(t *testing.T) {
+	// Test that even when the tempo beats fire multiple times, the combo remains the same
 	punch1 := models.NewPunchMove(models.Jab)
 	punch2 := models.NewPunchMove(models.Cross)
 
@@ -738,22 +726,16 @@ func TestWorkoutDisplay_ComboConstantDuringTickerFires(t *testing.T) {
 	round := workout.Rounds[0]
 	initialCombo := round.Combo
 
-	// Verify combo updates are started
-	if display.comboUpdateTicker == nil {
-		t.Error("expected comboUpdateTicker to be started")
-	}
-
 	for i := 0; i < 3; i++ {
-		time.Sleep(2500 * time.Millisecond)
-		display.updateDisplay()
+		display.onBeat()
+		if display.currentComboIdx != 0 {
+			t.Errorf("beat %d: expected currentComboIdx to remain 0, got %d", i+1, display.currentComboIdx)
+		}
 		currentCombo := round.Combo
 		if len(currentCombo.Moves) != len(initialCombo.Moves) {
-			t.Errorf("ticker fire %d: combo changed - initial had %d moves, current has %d moves", i+1, len(initialCombo.Moves), len(currentCombo.Moves))
+			t.Errorf("beat %d: combo changed - initial had %d moves, current has %d moves", i+1, len(initialCombo.Moves), len(currentCombo.Moves))
 		}
 	}
-
-	display.stopComboUpdates()
-	time.Sleep(100 * time.Millisecond)
 }
 
 func TestWorkoutDisplay_ComboIndexResetsOnNewRound(t *testing.T) {
@@ -790,8 +772,6 @@ func TestWorkoutDisplay_ComboIndexResetsOnNewRound(t *testing.T) {
 		t.Errorf("expected currentComboIdx 0 at start of round 2 (should reset), got %d", display.currentComboIdx)
 	}
 
-	display.stopComboUpdates()
-	time.Sleep(50 * time.Millisecond)
 }
 
 func TestWorkoutDisplay_ComboStringFormat(t *testing.T) {
@@ -846,3 +826,73 @@ func TestWorkoutDisplay_ComboStringFormat(t *testing.T) {
 		t.Error("expected combo string to contain arrow separator")
 	}
 }
+
+func TestWorkoutDisplay_formatCombo_CalloutStyles(t *testing.T) {
+	combo := models.NewCombo([]models.Move{
+		models.NewPunchMove(models.Jab),
+		models.NewDefensiveMove(models.LeftSlip),
+		models.NewPunchMove(models.RearHook),
+	})
+	tests := []struct {
+		style models.CalloutStyle
+		want  string
+	}{
+		{models.CalloutNumeric, "1 → 🛡 slip → 4"},
+		{models.CalloutShort, "jab → 🛡 slip → hook"},
+		{models.CalloutFull, "jab → 🛡 left slip → right hook"},
+		{models.CalloutCoach, "jab to the head → 🛡 slip left off the line → right hook to the body"},
+	}
+
+	display := NewWorkoutDisplayWithStance(models.Workout{}, models.Orthodox)
+	for _, tt := range tests {
+		display.SetCalloutStyle(tt.style)
+		if got := display.formatCombo(combo); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.style, tt.want, got)
+		}
+	}
+}
+
+func TestWorkoutDisplay_currentCue(t *testing.T) {
+	combo := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab)})
+	cuedRound := models.NewWorkoutRound(1, combo, 20*time.Second, 10*time.Second)
+	cuedRound.Cue = "Snap the jab back"
+	bankRound := models.NewWorkoutRound(3, combo, 20*time.Second, 10*time.Second)
+	bankRound.Cue = "cue.exhale"
+
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3),
+		[]models.WorkoutRound{
+			cuedRound,
+			models.NewWorkoutRound(2, combo, 20*time.Second, 10*time.Second),
+			bankRound,
+		},
+	)
+
+	display := NewWorkoutDisplay(workout)
+	spanish, err := i18n.Load("es")
+	if err != nil {
+		t.Fatalf("failed to load es: %v", err)
+	}
+	display.SetLocale(spanish)
+
+	tests := []struct {
+		name  string
+		round int
+		want  string
+	}{
+		{name: "before workout starts", round: 0, want: ""},
+		{name: "round with cue", round: 1, want: "Snap the jab back"},
+		{name: "round without cue", round: 2, want: ""},
+		{name: "cue from the bank", round: 3, want: "Exhala en cada golpe"},
+		{name: "past last round", round: 4, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			display.currentRound = tt.round
+			if got := display.currentCue(); got != tt.want {
+				t.Errorf("currentCue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
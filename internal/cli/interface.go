@@ -3,20 +3,33 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
 	"heavybagworkout/internal/timer"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 )
 
+// addTimeStep is how much time the add-time command adds to the current period
+const addTimeStep = 30 * time.Second
+
+// defaultTempo is the interval between beats when no tempo is given
+const defaultTempo = 5 * time.Second
+
+// beatBufferSize is how many events the display can fall behind by before the workout waits for it
+const beatBufferSize = 16
+
 // WorkoutInterface manages the CLI interface for running workouts
 type WorkoutInterface struct {
 	workout      models.Workout
 	workoutTimer *timer.WorkoutTimer
 	display      *WorkoutDisplay
 	audioHandler timer.AudioCueHandler
+	resume       *timer.Checkpoint // Point to start the workout from, nil to start from the beginning
 	quitChan     chan bool
 }
 
@@ -32,12 +45,17 @@ func NewWorkoutInterfaceWithStance(workout models.Workout, audioHandler timer.Au
 
 // NewWorkoutInterfaceWithStanceAndTempo creates a new workout interface with the specified stance and tempo
 func NewWorkoutInterfaceWithStanceAndTempo(workout models.Workout, audioHandler timer.AudioCueHandler, stance models.Stance, tempo time.Duration) *WorkoutInterface {
+	if tempo <= 0 {
+		tempo = defaultTempo
+	}
 	wt := timer.NewWorkoutTimer(workout)
 	wt.SetStance(stance) // Set stance for combo callouts
+	wt.SetTempo(tempo)   // Beeps at tempo intervals during work periods
+	wt.SetAudioPreRoll(timer.DefaultAudioPreRoll)
 	return &WorkoutInterface{
 		workout:      workout,
 		workoutTimer: wt,
-		display:      NewWorkoutDisplayWithStanceAndTempo(workout, stance, tempo),
+		display:      NewWorkoutDisplayWithStance(workout, stance),
 		audioHandler: audioHandler,
 		quitChan:     make(chan bool, 1),
 	}
@@ -49,6 +67,45 @@ func (wi *WorkoutInterface) SetStance(stance models.Stance) {
 	wi.workoutTimer.SetStance(stance)
 }
 
+// SetLocale sets the language of the display and of the timeline's callouts. Callouts are spoken in the audio
+// handler's language.
+func (wi *WorkoutInterface) SetLocale(locale *i18n.Locale) {
+	wi.display.SetLocale(locale)
+	wi.workoutTimer.SetLocale(locale)
+}
+
+// SetCalloutStyle sets how combos are shown and compiled into the timeline. Callouts are spoken in the audio
+// handler's style.
+func (wi *WorkoutInterface) SetCalloutStyle(style models.CalloutStyle) {
+	wi.display.SetCalloutStyle(style)
+	wi.workoutTimer.SetCalloutStyle(style)
+}
+
+// SetCueProfile sets the sounds that mark the moments of each round, nil for the spoken profile
+func (wi *WorkoutInterface) SetCueProfile(profile *models.CueProfile) {
+	wi.workoutTimer.SetCueProfile(profile)
+}
+
+// SetRestMode sets how long rest periods last, and the bounds of auto rest periods
+func (wi *WorkoutInterface) SetRestMode(mode models.RestMode, minRest, maxRest time.Duration) error {
+	if err := wi.workoutTimer.SetRestBounds(minRest, maxRest); err != nil {
+		return err
+	}
+	wi.workoutTimer.SetRestMode(mode)
+	wi.display.SetRestMode(mode)
+	return nil
+}
+
+// SetCheckpointStore checkpoints the workout as it runs, so it can be resumed if the app is closed
+func (wi *WorkoutInterface) SetCheckpointStore(store *timer.CheckpointStore) {
+	wi.workoutTimer.SetCheckpointStore(store)
+}
+
+// ResumeFrom starts the workout from a checkpoint of it instead of from the beginning
+func (wi *WorkoutInterface) ResumeFrom(checkpoint timer.Checkpoint) {
+	wi.resume = &checkpoint
+}
+
 // Run starts the workout interface and handles user input
 func (wi *WorkoutInterface) Run() error {
 	// Set up display handler
@@ -57,14 +114,12 @@ func (wi *WorkoutInterface) Run() error {
 	// Set up audio handler if provided
 	if wi.audioHandler != nil {
 		wi.workoutTimer.SetAudioHandler(wi.audioHandler)
-		// Also set audio handler in display for 2-second beeps
+		// Also set audio handler in display for the tempo beeps
 		wi.display.SetAudioHandler(wi.audioHandler)
 	}
 
 	// Set up callbacks
-	wi.workoutTimer.OnWorkoutComplete(func() {
-		wi.quitChan <- true
-	})
+	wi.workoutTimer.OnWorkoutComplete(wi.quit)
 
 	// Print initial display
 	wi.display.PrintInitialDisplay()
@@ -89,8 +144,17 @@ func (wi *WorkoutInterface) Run() error {
 		return nil
 	}
 
+	// Follow the tempo beats
+	beats := wi.workoutTimer.Events().SubscribeBlocking(beatBufferSize)
+	defer beats.Unsubscribe()
+	go wi.display.followBeats(beats)
+
 	// Start the workout
-	if err := wi.workoutTimer.Start(); err != nil {
+	start := wi.workoutTimer.Start
+	if wi.resume != nil {
+		start = func() error { return wi.workoutTimer.StartFrom(*wi.resume) }
+	}
+	if err := start(); err != nil {
 		return fmt.Errorf("failed to start workout: %w", err)
 	}
 
@@ -114,25 +178,59 @@ func (wi *WorkoutInterface) Run() error {
 	}
 }
 
-// handleInput handles keyboard input during the workout
+// handleInput handles keyboard input during the workout.
+// The terminal stays in line mode, so each command is a key followed by Enter.
 func (wi *WorkoutInterface) handleInput(reader *bufio.Reader) {
 	for {
-		// Set terminal to raw mode would be better, but for simplicity
-		// we'll use a simpler approach with ReadString
-		// Note: This is a simplified version. For better UX, consider using
-		// a library like github.com/eiannone/keyboard for non-blocking input
+		line, err := reader.ReadString('\n')
+		if strings.TrimSpace(line) != "" {
+			if cmdErr := wi.handleCommand(line); cmdErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", cmdErr)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
 
-		// For now, we'll check for input in a non-blocking way
-		// This is a basic implementation - in production, you'd want
-		// to use a proper terminal input library
+// handleCommand runs a workout control command:
+// p (pause/resume), s (skip rest), r (repeat round), + (add 30 seconds), j N (jump to round N),
+// g (ready, with auto rest), d (done, with earned rest), q (quit)
+func (wi *WorkoutInterface) handleCommand(line string) error {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) == 0 {
+		return nil
+	}
 
-		select {
-		case <-wi.quitChan:
-			return
-		default:
-			// Try to read input (this will block, so we need a better approach)
-			// For now, we'll handle this differently
+	switch fields[0] {
+	case "p":
+		return wi.TogglePause()
+	case "s":
+		return wi.workoutTimer.SkipRest()
+	case "r":
+		return wi.workoutTimer.RepeatRound()
+	case "+":
+		return wi.workoutTimer.AddTime(addTimeStep)
+	case "j":
+		if len(fields) != 2 {
+			return fmt.Errorf("usage: j <round>")
 		}
+		round, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fmt.Errorf("invalid round number %q", fields[1])
+		}
+		return wi.workoutTimer.JumpToRound(round)
+	case "g":
+		return wi.workoutTimer.Ready()
+	case "d":
+		return wi.workoutTimer.Done()
+	case "q":
+		fmt.Println("\n\nWorkout stopped.")
+		wi.Stop()
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", fields[0])
 	}
 }
 
@@ -154,12 +252,20 @@ func (wi *WorkoutInterface) Resume() error {
 // Stop stops the workout
 func (wi *WorkoutInterface) Stop() {
 	wi.workoutTimer.Stop()
-	wi.quitChan <- true
+	wi.quit()
+}
+
+// quit signals Run to return. The workout completing and q can both signal it; the second signal is dropped.
+func (wi *WorkoutInterface) quit() {
+	select {
+	case wi.quitChan <- true:
+	default:
+	}
 }
 
 // TogglePause toggles between pause and resume
 func (wi *WorkoutInterface) TogglePause() error {
-	if wi.display.isPaused {
+	if wi.display.paused() {
 		return wi.Resume()
 	}
 	wi.Pause()
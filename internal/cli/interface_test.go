@@ -4,6 +4,7 @@ import (
 	"heavybagworkout/internal/models"
 	"heavybagworkout/internal/timer"
 	"heavybagworkout/internal/types"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -157,6 +158,7 @@ func TestWorkoutInterface_Stop(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 
 	workoutInterface.Stop()
+	workoutInterface.Stop() // Quitting twice, e.g. q as the workout completes, must not block
 
 	if workoutInterface.workoutTimer.CurrentRound() != 0 {
 		t.Errorf("expected currentRound 0 after stop, got %d", workoutInterface.workoutTimer.CurrentRound())
@@ -294,7 +296,10 @@ func TestWorkoutInterface_DisplayHandlerIntegration(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 
 	// Verify display state is updated
-	if workoutInterface.display.currentRound == 0 {
+	workoutInterface.display.mu.Lock()
+	currentRound := workoutInterface.display.currentRound
+	workoutInterface.display.mu.Unlock()
+	if currentRound == 0 {
 		t.Error("expected currentRound to be set after workout start")
 	}
 
@@ -358,3 +363,60 @@ func TestWorkoutInterface_CurrentRoundAndPeriod(t *testing.T) {
 
 	workoutInterface.Stop()
 }
+
+func TestWorkoutInterface_HandleCommand(t *testing.T) {
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(10*time.Second, 10*time.Second, 2),
+		[]models.WorkoutRound{
+			models.NewWorkoutRound(1, models.NewCombo([]models.Move{}), 10*time.Second, 10*time.Second),
+			models.NewWorkoutRound(2, models.NewCombo([]models.Move{}), 10*time.Second, 10*time.Second),
+		},
+	)
+
+	workoutInterface := NewWorkoutInterface(workout, timer.NewNoOpAudioCueHandler())
+	if err := workoutInterface.workoutTimer.Start(); err != nil {
+		t.Fatalf("failed to start workout: %v", err)
+	}
+	defer workoutInterface.workoutTimer.Stop()
+
+	tests := []struct {
+		line    string
+		wantErr bool
+	}{
+		{line: "+\n", wantErr: false},
+		{line: "J 2\n", wantErr: false},
+		{line: "r\n", wantErr: false},
+		{line: "s\n", wantErr: true}, // Not in a rest period
+		{line: "j\n", wantErr: true},
+		{line: "j two\n", wantErr: true},
+		{line: "j 3\n", wantErr: true},
+		{line: "g\n", wantErr: true}, // Not using auto rest
+		{line: "d\n", wantErr: true}, // Not using earned rest
+		{line: "x\n", wantErr: true},
+		{line: "   \n", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.line), func(t *testing.T) {
+			err := workoutInterface.handleCommand(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handleCommand(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := workoutInterface.handleCommand("p\n"); err != nil {
+		t.Fatalf("failed to pause: %v", err)
+	}
+	if !workoutInterface.display.isPaused {
+		t.Error("expected p to pause the workout")
+	}
+
+	if err := workoutInterface.handleCommand("q\n"); err != nil {
+		t.Fatalf("failed to quit: %v", err)
+	}
+	select {
+	case <-workoutInterface.quitChan:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("expected quit signal after q")
+	}
+}
@@ -3,8 +3,12 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
+	"net"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -13,7 +17,9 @@ type AppConfig struct {
 	Workout      WorkoutConfig   `json:"workout"`
 	Pattern      PatternConfig   `json:"pattern"`
 	Generator    GeneratorConfig `json:"generator"`
+	Audio        AudioConfig     `json:"audio"`
 	Stance       string          `json:"stance,omitempty"`         // "orthodox" or "southpaw", defaults to "orthodox"
+	Language     string          `json:"language,omitempty"`       // Language of the callouts and workout display: "en" (default), "es" or "pt"
 	OpenAIAPIKey string          `json:"openai_api_key,omitempty"` // Optional, can be set via env var
 }
 
@@ -34,8 +40,68 @@ type PatternConfig struct {
 
 // GeneratorConfig represents combo generation method
 type GeneratorConfig struct {
-	UseLLM   bool   `json:"use_llm"`             // true = LLM, false = in-house
-	LLMModel string `json:"llm_model,omitempty"` // Optional, defaults to gpt-4o-mini
+	UseLLM           bool                    `json:"use_llm"`                      // true = LLM, false = in-house
+	LLMModel         string                  `json:"llm_model,omitempty"`          // Optional, defaults to gpt-4o-mini
+	Pricing          map[string]ModelPricing `json:"pricing,omitempty"`            // Optional per-model price overrides, keyed by model name
+	MonthlyBudgetUSD float64                 `json:"monthly_budget_usd,omitempty"` // Optional, 0 = no budget
+	PromptTemplate   string                  `json:"prompt_template,omitempty"`    // Optional path to a text/template prompt file
+	BaseURL          string                  `json:"base_url,omitempty"`           // Optional OpenAI-compatible API base URL (e.g. a local stub server)
+}
+
+// AudioConfig represents audio cue configuration
+type AudioConfig struct {
+	TTS          TTSConfig           `json:"tts"`
+	SamplePack   string              `json:"sample_pack,omitempty"`   // Optional directory of a sample pack to play callouts from
+	CalloutStyle string              `json:"callout_style,omitempty"` // "full" (default), "numeric", "short" or "coach"
+	Music        MusicConfig         `json:"music"`
+	Outputs      []AudioOutputConfig `json:"outputs,omitempty"`      // Where the cues are played, the speakers if there are none
+	CueProfile   string              `json:"cue_profile,omitempty"`  // "spoken" (default), "gym" or the name of one of CueProfiles
+	CueProfiles  []CueProfileConfig  `json:"cue_profiles,omitempty"` // User-defined cue profiles, which can replace the built-in ones
+}
+
+// CueProfileConfig represents a user-defined cue profile. Each sound is "bell", "clapper", "beep", "voice" or
+// "none" (the default), with an optional count such as "bell x3".
+type CueProfileConfig struct {
+	Name           string `json:"name"`
+	RoundStart     string `json:"round_start,omitempty"`     // When a work period starts
+	RoundEnd       string `json:"round_end,omitempty"`       // When a work period ends
+	Warning        string `json:"warning,omitempty"`         // WarningSeconds before the end of a work period
+	WarningSeconds int    `json:"warning_seconds,omitempty"` // How long before the end of a work period the warning plays, 10 if not set
+	Halfway        string `json:"halfway,omitempty"`         // Halfway through a work period
+	LastRound      string `json:"last_round,omitempty"`      // Before the last round is called out
+	Countdown      string `json:"countdown,omitempty"`       // Each of the last 3 seconds of a rest period
+}
+
+// AudioOutputConfig represents one of the outputs the cues are sent to at once
+type AudioOutputConfig struct {
+	Type      string `json:"type"`                 // "speakers" or "recording"
+	Path      string `json:"path,omitempty"`       // "recording": the file the system's audio is recorded to
+	Timing    string `json:"timing,omitempty"`     // "wait" (default): the workout waits for the output's cues; "background": it doesn't
+	TimeoutMs int    `json:"timeout_ms,omitempty"` // Longest the workout waits for one of the output's cues, 0 = as long as it takes
+}
+
+// MusicConfig represents background music played during the workout
+type MusicConfig struct {
+	Folder    string  `json:"folder,omitempty"`     // Folder of WAV, MP3 and FLAC files to play in name order, empty for no music
+	Volume    float64 `json:"volume,omitempty"`     // Music volume from 0 to 1, 0.5 if not set
+	DuckLevel float64 `json:"duck_level,omitempty"` // Fraction of its volume the music drops to under callouts, 0.25 if not set
+	Rest      string  `json:"rest,omitempty"`       // "play" (default), "soften" or "pause" during rest periods
+}
+
+// TTSConfig represents the text-to-speech engine that speaks the callouts
+type TTSConfig struct {
+	Engine       string  `json:"engine,omitempty"`        // "auto" (default), "say", "espeak-ng", "espeak", "festival", "piper", "sapi" or "command"
+	Voice        string  `json:"voice,omitempty"`         // Optional engine voice; for piper, the path of a voice model
+	Rate         int     `json:"rate,omitempty"`          // Optional speaking rate in words per minute, 0 = engine default
+	Volume       float64 `json:"volume,omitempty"`        // Optional volume from 0 to 1, 0 = full volume
+	SpeakCommand string  `json:"speak_command,omitempty"` // "command" engine: speaks {text} aloud ({voice}, {rate}, {volume} and {language} are filled in)
+	FileCommand  string  `json:"file_command,omitempty"`  // "command" engine: speaks {text} into the WAV file {output}
+}
+
+// ModelPricing represents the price of one LLM model in USD per million tokens
+type ModelPricing struct {
+	InputPerMillionUSD  float64 `json:"input_per_million_usd"`
+	OutputPerMillionUSD float64 `json:"output_per_million_usd"`
 }
 
 // Validate validates the configuration
@@ -49,6 +115,47 @@ func (c *AppConfig) Validate() error {
 	if err := c.Generator.Validate(); err != nil {
 		return fmt.Errorf("generator config: %w", err)
 	}
+	if err := c.Audio.TTS.Validate(); err != nil {
+		return fmt.Errorf("tts config: %w", err)
+	}
+	if err := c.Audio.Music.Validate(); err != nil {
+		return fmt.Errorf("music config: %w", err)
+	}
+	speakers := 0
+	for i := range c.Audio.Outputs {
+		if err := c.Audio.Outputs[i].Validate(); err != nil {
+			return fmt.Errorf("audio output %d: %w", i+1, err)
+		}
+		if strings.EqualFold(c.Audio.Outputs[i].Type, "speakers") {
+			speakers++
+		}
+	}
+	if speakers > 1 {
+		return fmt.Errorf("audio config: only one output can be the speakers, got %d", speakers)
+	}
+	if len(c.Audio.Outputs) > 0 && speakers == 0 {
+		return fmt.Errorf("audio config: outputs must include the speakers, recordings capture what they play")
+	}
+	names := make(map[string]bool)
+	for i := range c.Audio.CueProfiles {
+		profile := &c.Audio.CueProfiles[i]
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("cue profile %d: %w", i+1, err)
+		}
+		if name := strings.ToLower(profile.Name); names[name] {
+			return fmt.Errorf("cue profile %d: the name %s is used twice", i+1, profile.Name)
+		}
+		names[strings.ToLower(profile.Name)] = true
+	}
+	if _, err := c.Audio.GetCueProfile(); err != nil {
+		return fmt.Errorf("audio config: %w", err)
+	}
+	if models.ParseCalloutStyle(c.Audio.CalloutStyle) == models.CalloutUnknown {
+		return fmt.Errorf("audio config: callout_style must be full, numeric, short or coach, got %q", c.Audio.CalloutStyle)
+	}
+	if _, err := i18n.Load(c.Language); err != nil {
+		return fmt.Errorf("language: %w", err)
+	}
 	// Set stance to "orthodox" by default if not specified
 	if c.Stance == "" {
 		c.Stance = "orthodox"
@@ -71,6 +178,123 @@ func (wc *WorkoutConfig) Validate() error {
 	return nil
 }
 
+// Validate validates music configuration
+func (mc *MusicConfig) Validate() error {
+	if mc.Volume < 0 || mc.Volume > 1 {
+		return fmt.Errorf("volume must be between 0 and 1, got %.2f", mc.Volume)
+	}
+	if mc.DuckLevel < 0 || mc.DuckLevel > 1 {
+		return fmt.Errorf("duck_level must be between 0 and 1, got %.2f", mc.DuckLevel)
+	}
+	switch strings.ToLower(mc.Rest) {
+	case "", "play", "soften", "pause":
+		return nil
+	default:
+		return fmt.Errorf("rest must be one of: play, soften, pause, got %s", mc.Rest)
+	}
+}
+
+// GetVolume returns the music volume, defaulting to 0.5 if not set
+func (mc *MusicConfig) GetVolume() float64 {
+	if mc.Volume == 0 {
+		return 0.5
+	}
+	return mc.Volume
+}
+
+// GetDuckLevel returns the fraction of its volume the music drops to under callouts, defaulting to 0.25 if not set
+func (mc *MusicConfig) GetDuckLevel() float64 {
+	if mc.DuckLevel == 0 {
+		return 0.25
+	}
+	return mc.DuckLevel
+}
+
+// Validate validates an audio output
+func (oc *AudioOutputConfig) Validate() error {
+	switch strings.ToLower(oc.Type) {
+	case "speakers":
+	case "recording":
+		if oc.Path == "" {
+			return fmt.Errorf("a recording needs a path")
+		}
+	default:
+		return fmt.Errorf("type must be one of: speakers, recording, got %q", oc.Type)
+	}
+	switch strings.ToLower(oc.Timing) {
+	case "", "wait", "background":
+	default:
+		return fmt.Errorf("timing must be one of: wait, background, got %s", oc.Timing)
+	}
+	if oc.TimeoutMs < 0 {
+		return fmt.Errorf("timeout_ms must be non-negative, got %d", oc.TimeoutMs)
+	}
+	return nil
+}
+
+// IsBackground reports whether the workout carries on without waiting for the output's cues
+func (oc *AudioOutputConfig) IsBackground() bool {
+	return strings.EqualFold(oc.Timing, "background")
+}
+
+// GetTimeout returns the longest the workout waits for one of the output's cues, 0 for as long as it takes
+func (oc *AudioOutputConfig) GetTimeout() time.Duration {
+	return time.Duration(oc.TimeoutMs) * time.Millisecond
+}
+
+// Validate validates a user-defined cue profile
+func (pc *CueProfileConfig) Validate() error {
+	if strings.TrimSpace(pc.Name) == "" {
+		return fmt.Errorf("a cue profile needs a name")
+	}
+	if pc.WarningSeconds < 0 {
+		return fmt.Errorf("warning_seconds must be non-negative, got %d", pc.WarningSeconds)
+	}
+	_, err := pc.ToModelsCueProfile()
+	return err
+}
+
+// ToModelsCueProfile converts config to models.CueProfile
+func (pc *CueProfileConfig) ToModelsCueProfile() (models.CueProfile, error) {
+	profile := models.CueProfile{Name: pc.Name, WarningBefore: 10 * time.Second}
+	if pc.WarningSeconds > 0 {
+		profile.WarningBefore = time.Duration(pc.WarningSeconds) * time.Second
+	}
+	sounds := []struct {
+		field string
+		value string
+		sound *models.CueSound
+	}{
+		{"round_start", pc.RoundStart, &profile.RoundStart},
+		{"round_end", pc.RoundEnd, &profile.RoundEnd},
+		{"warning", pc.Warning, &profile.Warning},
+		{"halfway", pc.Halfway, &profile.Halfway},
+		{"last_round", pc.LastRound, &profile.LastRound},
+		{"countdown", pc.Countdown, &profile.Countdown},
+	}
+	for _, s := range sounds {
+		sound, err := models.ParseCueSound(s.value)
+		if err != nil {
+			return models.CueProfile{}, fmt.Errorf("%s: %w", s.field, err)
+		}
+		*s.sound = sound
+	}
+	return profile, nil
+}
+
+// GetCueProfile returns the cue profile named by CueProfile, from CueProfiles or the built-in profiles
+func (ac *AudioConfig) GetCueProfile() (models.CueProfile, error) {
+	custom := make([]models.CueProfile, 0, len(ac.CueProfiles))
+	for i := range ac.CueProfiles {
+		profile, err := ac.CueProfiles[i].ToModelsCueProfile()
+		if err != nil {
+			return models.CueProfile{}, fmt.Errorf("cue profile %s: %w", ac.CueProfiles[i].Name, err)
+		}
+		custom = append(custom, profile)
+	}
+	return models.FindCueProfile(ac.CueProfile, custom)
+}
+
 // Validate validates pattern configuration
 func (pc *PatternConfig) Validate() error {
 	validTypes := map[string]bool{
@@ -96,6 +320,66 @@ func (gc *GeneratorConfig) Validate() error {
 	if gc.UseLLM && gc.LLMModel == "" {
 		// Allow empty model, will use default in LLM generator
 	}
+	if gc.MonthlyBudgetUSD < 0 {
+		return fmt.Errorf("monthly_budget_usd must be non-negative, got %.2f", gc.MonthlyBudgetUSD)
+	}
+	for model, price := range gc.Pricing {
+		if model == "" {
+			return fmt.Errorf("pricing entries must have a model name")
+		}
+		if price.InputPerMillionUSD < 0 || price.OutputPerMillionUSD < 0 {
+			return fmt.Errorf("pricing for %s must be non-negative", model)
+		}
+	}
+	if gc.BaseURL != "" {
+		if _, err := parseBaseURL(gc.BaseURL); err != nil {
+			return fmt.Errorf("base_url %w", err)
+		}
+	} else if env := os.Getenv("OPENAI_BASE_URL"); env != "" {
+		if _, err := parseBaseURL(env); err != nil {
+			return fmt.Errorf("OPENAI_BASE_URL %w", err)
+		}
+	}
+	return nil
+}
+
+// parseBaseURL parses an LLM API base URL, which must be an http or https URL with a host
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("must be an http or https URL, got %s", baseURL)
+	}
+	return u, nil
+}
+
+// Validate validates text-to-speech configuration
+func (tc *TTSConfig) Validate() error {
+	validEngines := map[string]bool{
+		"":          true,
+		"auto":      true,
+		"say":       true,
+		"espeak-ng": true,
+		"espeak":    true,
+		"festival":  true,
+		"piper":     true,
+		"sapi":      true,
+		"command":   true,
+	}
+	if !validEngines[tc.Engine] {
+		return fmt.Errorf("engine must be one of: auto, say, espeak-ng, espeak, festival, piper, sapi, command, got %s", tc.Engine)
+	}
+	if tc.Engine == "command" && tc.SpeakCommand == "" && tc.FileCommand == "" {
+		return fmt.Errorf("the command engine needs a speak_command or file_command")
+	}
+	if tc.FileCommand != "" && !strings.Contains(tc.FileCommand, "{output}") {
+		return fmt.Errorf("file_command must write to {output}, got %s", tc.FileCommand)
+	}
+	if tc.Rate < 0 {
+		return fmt.Errorf("rate must be non-negative, got %d", tc.Rate)
+	}
+	if tc.Volume < 0 || tc.Volume > 1 {
+		return fmt.Errorf("volume must be between 0 and 1, got %.2f", tc.Volume)
+	}
 	return nil
 }
 
@@ -229,6 +513,42 @@ func (c *AppConfig) GetOpenAIAPIKey() string {
 	return ""
 }
 
+// GetBaseURL returns the LLM API base URL from config or the OPENAI_BASE_URL environment variable,
+// or "" to use the OpenAI API
+func (gc *GeneratorConfig) GetBaseURL() string {
+	if gc.BaseURL != "" {
+		return gc.BaseURL
+	}
+	return os.Getenv("OPENAI_BASE_URL")
+}
+
+// RequiresAPIKey returns true if the LLM endpoint needs an API key. Only a base URL on this machine, such as a
+// local stub, may go without one; hosted APIs are assumed to need one.
+func (gc *GeneratorConfig) RequiresAPIKey() bool {
+	baseURL := gc.GetBaseURL()
+	if baseURL == "" {
+		return true
+	}
+	u, err := parseBaseURL(baseURL)
+	if err != nil {
+		return true
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// GetLanguage returns the language code from config, defaulting to English if not set
+func (c *AppConfig) GetLanguage() string {
+	if c.Language == "" {
+		return i18n.DefaultLanguage
+	}
+	return strings.ToLower(c.Language)
+}
+
 // GetStance returns the stance from config, defaulting to orthodox if not set
 func (c *AppConfig) GetStance() string {
 	if c.Stance == "" {
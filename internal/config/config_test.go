@@ -1,7 +1,10 @@
 package config
 
 import (
+	"heavybagworkout/internal/models"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -274,6 +277,52 @@ func TestPatternConfig_ToModelsWorkoutPattern(t *testing.T) {
 	}
 }
 
+func TestAppConfig_Language(t *testing.T) {
+	tests := []struct {
+		name         string
+		language     string
+		wantLanguage string
+		wantErr      bool
+	}{
+		{name: "empty language defaults to English", language: "", wantLanguage: "en"},
+		{name: "spanish", language: "es", wantLanguage: "es"},
+		{name: "language codes ignore case", language: "PT", wantLanguage: "pt"},
+		{name: "unsupported language", language: "fr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := LoadDefault()
+			config.Language = tt.language
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && config.GetLanguage() != tt.wantLanguage {
+				t.Errorf("GetLanguage() = %s, want %s", config.GetLanguage(), tt.wantLanguage)
+			}
+		})
+	}
+}
+
+func TestAppConfig_CalloutStyle(t *testing.T) {
+	for _, tt := range []struct {
+		style   string
+		wantErr bool
+	}{
+		{"", false},
+		{"numeric", false},
+		{"Coach", false},
+		{"verbose", true},
+	} {
+		config := LoadDefault()
+		config.Audio.CalloutStyle = tt.style
+		if err := config.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with callout style %q error = %v, wantErr %v", tt.style, err, tt.wantErr)
+		}
+	}
+}
+
 func TestAppConfig_GetStance(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -611,3 +660,395 @@ func TestAppConfig_SaveToFile(t *testing.T) {
 		t.Errorf("loaded Stance = %s, want southpaw", loadedConfig.Stance)
 	}
 }
+
+func TestGeneratorConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  GeneratorConfig
+		wantErr bool
+	}{
+		{
+			name:    "no pricing or budget",
+			config:  GeneratorConfig{UseLLM: true},
+			wantErr: false,
+		},
+		{
+			name: "valid pricing and budget",
+			config: GeneratorConfig{
+				UseLLM:           true,
+				Pricing:          map[string]ModelPricing{"gpt-4.1-nano": {InputPerMillionUSD: 0.1, OutputPerMillionUSD: 0.4}},
+				MonthlyBudgetUSD: 5,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "negative budget",
+			config:  GeneratorConfig{MonthlyBudgetUSD: -1},
+			wantErr: true,
+		},
+		{
+			name: "negative price",
+			config: GeneratorConfig{
+				Pricing: map[string]ModelPricing{"gpt-4.1-nano": {InputPerMillionUSD: -0.1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty model name",
+			config: GeneratorConfig{
+				Pricing: map[string]ModelPricing{"": {InputPerMillionUSD: 0.1}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "local base URL",
+			config:  GeneratorConfig{UseLLM: true, BaseURL: "http://127.0.0.1:8089/v1"},
+			wantErr: false,
+		},
+		{
+			name:    "base URL without scheme",
+			config:  GeneratorConfig{BaseURL: "localhost:8089"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GeneratorConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTTSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TTSConfig
+		wantErr bool
+	}{
+		{
+			name:    "auto by default",
+			config:  TTSConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "espeak-ng with voice, rate and volume",
+			config:  TTSConfig{Engine: "espeak-ng", Voice: "en-us", Rate: 170, Volume: 0.8},
+			wantErr: false,
+		},
+		{
+			name:    "unknown engine",
+			config:  TTSConfig{Engine: "alexa"},
+			wantErr: true,
+		},
+		{
+			name:    "command engine with a file command",
+			config:  TTSConfig{Engine: "command", FileCommand: "mimic3 --voice {voice} --output {output} {text}"},
+			wantErr: false,
+		},
+		{
+			name:    "command engine without commands",
+			config:  TTSConfig{Engine: "command"},
+			wantErr: true,
+		},
+		{
+			name:    "file command without an output",
+			config:  TTSConfig{Engine: "command", FileCommand: "mimic3 {text}"},
+			wantErr: true,
+		},
+		{
+			name:    "negative rate",
+			config:  TTSConfig{Rate: -1},
+			wantErr: true,
+		},
+		{
+			name:    "volume above 1",
+			config:  TTSConfig{Volume: 1.5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TTSConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneratorConfig_GetBaseURL(t *testing.T) {
+	t.Setenv("OPENAI_BASE_URL", "http://127.0.0.1:9000/v1")
+
+	gc := GeneratorConfig{}
+	if got := gc.GetBaseURL(); got != "http://127.0.0.1:9000/v1" {
+		t.Errorf("expected base URL from environment, got %q", got)
+	}
+	if gc.RequiresAPIKey() {
+		t.Error("a custom base URL should not require an API key")
+	}
+
+	gc.BaseURL = "http://127.0.0.1:8089/v1"
+	if got := gc.GetBaseURL(); got != "http://127.0.0.1:8089/v1" {
+		t.Errorf("expected config base URL to override environment, got %q", got)
+	}
+
+	t.Setenv("OPENAI_BASE_URL", "")
+	if !(&GeneratorConfig{}).RequiresAPIKey() {
+		t.Error("the OpenAI API should require an API key")
+	}
+
+	for baseURL, want := range map[string]bool{
+		"http://localhost:8089/v1":        false,
+		"http://[::1]:8089/v1":            false,
+		"https://api.example.com/v1":      true,
+		"http://192.168.1.20:8089/v1":     true,
+		"http://127.0.0.1.example.com/v1": true,
+	} {
+		if got := (&GeneratorConfig{BaseURL: baseURL}).RequiresAPIKey(); got != want {
+			t.Errorf("%s: expected RequiresAPIKey() = %v, got %v", baseURL, want, got)
+		}
+	}
+
+	// The environment's base URL is validated like the config's
+	t.Setenv("OPENAI_BASE_URL", "localhost:8089")
+	if err := (&GeneratorConfig{}).Validate(); err == nil || !strings.Contains(err.Error(), "OPENAI_BASE_URL must be an http or https URL") {
+		t.Errorf("expected the environment's base URL to be rejected, got %v", err)
+	}
+	if err := (&GeneratorConfig{BaseURL: "http://127.0.0.1:8089/v1"}).Validate(); err != nil {
+		t.Errorf("expected the config's base URL to override the environment's, got %v", err)
+	}
+}
+
+func TestDataDir(t *testing.T) {
+	t.Run("env override", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "data")
+		t.Setenv("HEAVYBAG_DATA_DIR", dir)
+
+		got, err := DataDir()
+		if err != nil {
+			t.Fatalf("DataDir() error = %v", err)
+		}
+		if got != dir {
+			t.Errorf("DataDir() = %s, want %s", got, dir)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("expected data directory to be created, stat error = %v", err)
+		}
+	})
+
+	t.Run("xdg data home", func(t *testing.T) {
+		xdg := t.TempDir()
+		t.Setenv("HEAVYBAG_DATA_DIR", "")
+		t.Setenv("XDG_DATA_HOME", xdg)
+
+		got, err := DataDir()
+		if err != nil {
+			t.Fatalf("DataDir() error = %v", err)
+		}
+		want := filepath.Join(xdg, "heavybagworkout")
+		if got != want {
+			t.Errorf("DataDir() = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestMusicConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  MusicConfig
+		wantErr bool
+	}{
+		{
+			name:    "no music by default",
+			config:  MusicConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "folder softened in rest periods",
+			config:  MusicConfig{Folder: "music", Volume: 0.7, DuckLevel: 0.2, Rest: "soften"},
+			wantErr: false,
+		},
+		{
+			name:    "unknown rest",
+			config:  MusicConfig{Rest: "stop"},
+			wantErr: true,
+		},
+		{
+			name:    "duck level above 1",
+			config:  MusicConfig{DuckLevel: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MusicConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAudioConfig_Outputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []AudioOutputConfig
+		wantErr string
+	}{
+		{
+			name:    "speakers by default",
+			outputs: nil,
+		},
+		{
+			name: "speakers and a background recording",
+			outputs: []AudioOutputConfig{
+				{Type: "speakers", TimeoutMs: 5000},
+				{Type: "recording", Path: "workout.mp3", Timing: "background"},
+			},
+		},
+		{
+			name:    "recording without a path",
+			outputs: []AudioOutputConfig{{Type: "recording"}},
+			wantErr: "needs a path",
+		},
+		{
+			name:    "unknown type",
+			outputs: []AudioOutputConfig{{Type: "radio"}},
+			wantErr: "type must be one of",
+		},
+		{
+			name:    "unknown timing",
+			outputs: []AudioOutputConfig{{Type: "speakers", Timing: "later"}},
+			wantErr: "timing must be one of",
+		},
+		{
+			name:    "negative timeout",
+			outputs: []AudioOutputConfig{{Type: "speakers", TimeoutMs: -1}},
+			wantErr: "timeout_ms",
+		},
+		{
+			name:    "speakers twice",
+			outputs: []AudioOutputConfig{{Type: "speakers"}, {Type: "Speakers"}},
+			wantErr: "only one output can be the speakers",
+		},
+		{
+			name:    "recording without the speakers",
+			outputs: []AudioOutputConfig{{Type: "recording", Path: "workout.mp3"}},
+			wantErr: "outputs must include the speakers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := LoadDefault()
+			config.Audio.Outputs = tt.outputs
+			err := config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	output := AudioOutputConfig{Type: "recording", Path: "workout.mp3", Timing: "Background", TimeoutMs: 1500}
+	if !output.IsBackground() || output.GetTimeout() != 1500*time.Millisecond {
+		t.Errorf("expected a background output with a 1.5s timeout, got %v and %v", output.IsBackground(), output.GetTimeout())
+	}
+}
+
+func TestAudioConfig_CueProfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  string
+		profiles []CueProfileConfig
+		wantErr  string
+	}{
+		{name: "spoken by default"},
+		{name: "built-in gym profile", profile: "Gym"},
+		{
+			name:     "user-defined profile",
+			profile:  "sparring",
+			profiles: []CueProfileConfig{{Name: "sparring", RoundStart: "bell x2", RoundEnd: "bell x3", Warning: "clapper x2", WarningSeconds: 30, Countdown: "voice"}},
+		},
+		{
+			name:    "unknown profile",
+			profile: "church",
+			wantErr: "unknown cue profile",
+		},
+		{
+			name:     "unknown sound",
+			profiles: []CueProfileConfig{{Name: "sparring", RoundStart: "gong"}},
+			wantErr:  "round_start: sound must be one of",
+		},
+		{
+			name:     "repeated voice",
+			profiles: []CueProfileConfig{{Name: "sparring", LastRound: "voice x2"}},
+			wantErr:  "can't be repeated",
+		},
+		{
+			name:     "bad count",
+			profiles: []CueProfileConfig{{Name: "sparring", RoundEnd: "bell x0"}},
+			wantErr:  "count must be x1 or more",
+		},
+		{
+			name:     "no name",
+			profiles: []CueProfileConfig{{RoundStart: "bell"}},
+			wantErr:  "needs a name",
+		},
+		{
+			name:     "name used twice",
+			profiles: []CueProfileConfig{{Name: "sparring"}, {Name: "Sparring"}},
+			wantErr:  "used twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := LoadDefault()
+			config.Audio.CueProfile = tt.profile
+			config.Audio.CueProfiles = tt.profiles
+			err := config.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	audio := AudioConfig{
+		CueProfile:  "gym",
+		CueProfiles: []CueProfileConfig{{Name: "gym", RoundStart: "bell x2", Warning: "clapper"}},
+	}
+	profile, err := audio.GetCueProfile()
+	if err != nil {
+		t.Fatalf("GetCueProfile failed: %v", err)
+	}
+	want := models.CueProfile{
+		Name:          "gym",
+		RoundStart:    models.CueSound{Sound: models.SoundBell, Times: 2},
+		RoundEnd:      models.CueSound{Sound: models.SoundNone},
+		Warning:       models.CueSound{Sound: models.SoundClapper, Times: 1},
+		WarningBefore: 10 * time.Second,
+		Halfway:       models.CueSound{Sound: models.SoundNone},
+		LastRound:     models.CueSound{Sound: models.SoundNone},
+		Countdown:     models.CueSound{Sound: models.SoundNone},
+	}
+	if profile != want {
+		t.Errorf("expected the user-defined profile to replace the built-in one, got %+v", profile)
+	}
+}
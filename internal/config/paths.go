@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// appDirName is the directory name used under the platform's per-user data location
+const appDirName = "heavybagworkout"
+
+// DataDir returns the per-user directory where the app keeps files it writes on its own
+// (spend logs, saved state, etc.). The directory is created if it does not exist.
+//
+// Resolution order:
+//  1. HEAVYBAG_DATA_DIR environment variable (used as-is)
+//  2. $XDG_DATA_HOME/heavybagworkout
+//  3. ~/.local/share/heavybagworkout on Linux, the user config dir on macOS/Windows
+func DataDir() (string, error) {
+	dir, err := dataDirPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create data directory %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// dataDirPath resolves the data directory path without creating it
+func dataDirPath() (string, error) {
+	if dir := os.Getenv("HEAVYBAG_DATA_DIR"); dir != "" {
+		return dir, nil
+	}
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, appDirName), nil
+	}
+	if runtime.GOOS == "linux" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate home directory: %w", err)
+		}
+		return filepath.Join(home, ".local", "share", appDirName), nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(base, appDirName), nil
+}
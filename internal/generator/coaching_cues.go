@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"heavybagworkout/internal/models"
+	"strings"
+)
+
+// maxCoachingCueLength caps LLM-provided cues so they stay short enough to speak during rest
+const maxCoachingCueLength = 80
+
+// punchCues are the fallback coaching cues for each punch. Cues from the bank are message keys in the locale files,
+// so they are shown and spoken in the workout's language.
+var punchCues = map[models.Punch][]string{
+	models.Jab:          {"cue.jab_snap_back", "cue.jab_step_in"},
+	models.Cross:        {"cue.cross_hip", "cue.cross_chin"},
+	models.LeadHook:     {"cue.lead_hook_pivot", "cue.lead_hook_elbow"},
+	models.RearHook:     {"cue.rear_hook_shoulders", "cue.rear_hook_compact"},
+	models.LeadUppercut: {"cue.lead_uppercut_knees", "cue.lead_uppercut_tight"},
+	models.RearUppercut: {"cue.rear_uppercut_drive", "cue.rear_uppercut_return"},
+}
+
+// defensiveCues are the fallback coaching cues for each defensive move
+var defensiveCues = map[models.DefensiveMove][]string{
+	models.LeftSlip:  {"cue.left_slip"},
+	models.RightSlip: {"cue.right_slip"},
+	models.LeftRoll:  {"cue.left_roll"},
+	models.RightRoll: {"cue.right_roll"},
+	models.PullBack:  {"cue.pull_back"},
+	models.Duck:      {"cue.duck"},
+}
+
+// generalCues are used when a combo has no moves with a specific cue
+var generalCues = []string{
+	"cue.exhale",
+	"cue.hands_back",
+	"cue.light_feet",
+}
+
+// CoachingCueForCombo returns a fallback coaching cue for a combo, drawn from the cues for its moves.
+// The choice rotates with the round number so repeated combos don't always get the same cue.
+// The cue is a message key, put into words with i18n.Locale.Cue when it is shown or spoken.
+func CoachingCueForCombo(combo models.Combo, roundNumber int) string {
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, move := range combo.Moves {
+		var cues []string
+		switch {
+		case move.IsPunch() && move.Punch != nil:
+			cues = punchCues[*move.Punch]
+		case move.IsDefensive() && move.Defensive != nil:
+			cues = defensiveCues[*move.Defensive]
+		}
+		for _, cue := range cues {
+			if !seen[cue] {
+				seen[cue] = true
+				candidates = append(candidates, cue)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = generalCues
+	}
+	if roundNumber < 1 {
+		roundNumber = 1
+	}
+	return candidates[(roundNumber-1)%len(candidates)]
+}
+
+// coachingCueFromResponse cleans up a cue returned by the LLM, falling back to the cue bank
+// if the LLM left it out or returned something too long to speak during rest
+func coachingCueFromResponse(cue string, combo models.Combo, roundNumber int) string {
+	cue = strings.Join(strings.Fields(cue), " ")
+	if cue == "" || len(cue) > maxCoachingCueLength {
+		return CoachingCueForCombo(combo, roundNumber)
+	}
+	return cue
+}
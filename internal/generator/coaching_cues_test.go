@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"heavybagworkout/internal/i18n"
+	"heavybagworkout/internal/mocks"
+	"heavybagworkout/internal/models"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestCoachingCueForCombo(t *testing.T) {
+	jab := models.NewPunchMove(models.Jab)
+	cross := models.NewPunchMove(models.Cross)
+	slip := models.NewDefensiveMove(models.LeftSlip)
+
+	tests := []struct {
+		name        string
+		combo       models.Combo
+		roundNumber int
+		want        string
+	}{
+		{name: "first jab cue", combo: models.NewCombo([]models.Move{jab}), roundNumber: 1, want: "cue.jab_snap_back"},
+		{name: "rotates with round", combo: models.NewCombo([]models.Move{jab}), roundNumber: 2, want: "cue.jab_step_in"},
+		{name: "wraps around", combo: models.NewCombo([]models.Move{jab}), roundNumber: 3, want: "cue.jab_snap_back"},
+		{name: "draws from later moves", combo: models.NewCombo([]models.Move{jab, cross}), roundNumber: 3, want: "cue.cross_hip"},
+		{name: "defensive move", combo: models.NewCombo([]models.Move{slip}), roundNumber: 1, want: "cue.left_slip"},
+		{name: "empty combo uses general cues", combo: models.NewCombo(nil), roundNumber: 1, want: "cue.exhale"},
+		{name: "invalid round number", combo: models.NewCombo([]models.Move{jab}), roundNumber: 0, want: "cue.jab_snap_back"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CoachingCueForCombo(tt.combo, tt.roundNumber); got != tt.want {
+				t.Errorf("CoachingCueForCombo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoachingCues_InEveryLocale(t *testing.T) {
+	var cues []string
+	for _, bank := range punchCues {
+		cues = append(cues, bank...)
+	}
+	for _, bank := range defensiveCues {
+		cues = append(cues, bank...)
+	}
+	cues = append(cues, generalCues...)
+
+	for _, language := range i18n.Languages() {
+		locale, err := i18n.Load(language)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", language, err)
+		}
+		for _, cue := range cues {
+			if text := locale.Cue(cue); text == cue || text == "" {
+				t.Errorf("%s: cue %q has no message", language, cue)
+			}
+		}
+	}
+}
+
+func TestWorkoutGenerator_SetsFallbackCues(t *testing.T) {
+	wg := NewWorkoutGenerator()
+	workout, err := wg.GenerateWorkout(models.NewWorkoutConfig(20*time.Second, 10*time.Second, 5), models.NewWorkoutPattern(models.PatternRandom, 1, 3, true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, round := range workout.Rounds {
+		if round.Cue == "" {
+			t.Errorf("round %d: expected a fallback cue", round.RoundNumber)
+		}
+	}
+}
+
+func TestLLMWorkoutGenerator_UsesCuesFromResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	longCue := strings.Repeat("keep going ", 20)
+	content := `{"rounds":[` +
+		`{"round_number":1,"combo":{"moves":[1,2]},"cue":"  exhale on   every punch "},` +
+		`{"round_number":2,"combo":{"moves":[1,2]}},` +
+		`{"round_number":3,"combo":{"moves":[1,2]},"cue":"` + longCue + `"}]}`
+
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+	mockHTTP.EXPECT().
+		Do(gomock.Any()).
+		Return(newHTTPResponse(http.StatusOK, chatResponseBody(t, content)), nil)
+
+	gen := NewLLMWorkoutGeneratorWithOpenAIClient(NewOpenAIClientWithHTTPClient("test-key", mockHTTP))
+	config := models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3)
+	pattern := models.NewWorkoutPattern(models.PatternConstant, 2, 2, false)
+
+	workout, err := gen.GenerateWorkout(config, pattern)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	combo := workout.Rounds[1].Combo
+	want := []string{
+		"exhale on every punch",
+		CoachingCueForCombo(combo, 2),
+		CoachingCueForCombo(combo, 3),
+	}
+	for i, round := range workout.Rounds {
+		if round.Cue != want[i] {
+			t.Errorf("round %d: expected cue %q, got %q", round.RoundNumber, want[i], round.Cue)
+		}
+	}
+}
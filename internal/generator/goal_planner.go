@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"heavybagworkout/internal/config"
+	"heavybagworkout/internal/models"
+	"strings"
+)
+
+// Bounds on what a goal-based plan may ask for, so a confused response can't produce an absurd workout
+const (
+	maxPlanRounds              = 30
+	maxPlanWorkDurationSeconds = 600
+	maxPlanRestDurationSeconds = 300
+)
+
+// WorkoutPlan represents a complete workout proposed by the LLM from a natural-language goal
+type WorkoutPlan struct {
+	Goal    string
+	Config  config.AppConfig // Workout, pattern, generator and stance settings for the plan
+	Tempo   models.Tempo
+	Stance  models.Stance
+	Workout models.Workout
+	Notes   string // Short explanation of the plan from the LLM (may be empty)
+}
+
+// PlanWorkoutFromGoal asks the LLM to propose a whole workout (timing, pattern, tempo, stance and combos)
+// from a goal such as "12 minutes, focus on counters off the slip, southpaw, finish hard".
+// The proposal is validated like a config file and retried once with the validation error.
+func (lg *LLMWorkoutGenerator) PlanWorkoutFromGoal(goal string) (WorkoutPlan, error) {
+	goal = strings.TrimSpace(goal)
+	if goal == "" {
+		return WorkoutPlan{}, fmt.Errorf("workout goal must not be empty")
+	}
+
+	lg.lastUsage = GenerationUsage{}
+	if err := lg.checkBudget(); err != nil {
+		return WorkoutPlan{}, err
+	}
+	defer lg.recordSpend()
+
+	// First attempt
+	plan, err := lg.planWorkoutAttempt(lg.buildGoalPrompt(goal, ""), goal)
+	if err == nil {
+		return plan, nil
+	}
+
+	// If first attempt failed, retry once with the error message
+	plan, retryErr := lg.planWorkoutAttempt(lg.buildGoalPrompt(goal, err.Error()), goal)
+	if retryErr != nil {
+		return WorkoutPlan{}, fmt.Errorf("failed to plan workout after retry: first attempt error: %v, retry error: %w", err, retryErr)
+	}
+
+	return plan, nil
+}
+
+// planWorkoutAttempt requests and validates one workout plan
+func (lg *LLMWorkoutGenerator) planWorkoutAttempt(prompt string, goal string) (plan WorkoutPlan, err error) {
+	response, usage, err := lg.openAIClient.GenerateWorkoutRequestWithUsage(prompt)
+	defer func() { lg.recordAttempt(usage, err) }()
+	if err != nil {
+		return WorkoutPlan{}, fmt.Errorf("failed to plan workout: %w", err)
+	}
+
+	var planResp WorkoutPlanResponseJSON
+	if err := json.Unmarshal([]byte(cleanJSONResponse(response)), &planResp); err != nil {
+		return WorkoutPlan{}, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	return lg.planFromResponse(planResp, goal)
+}
+
+// planFromResponse validates a plan proposed by the LLM and converts it to a WorkoutPlan
+func (lg *LLMWorkoutGenerator) planFromResponse(planResp WorkoutPlanResponseJSON, goal string) (WorkoutPlan, error) {
+	appConfig := config.AppConfig{
+		Workout: planResp.Workout,
+		Pattern: planResp.Pattern,
+		Generator: config.GeneratorConfig{
+			UseLLM: true,
+		},
+		Stance: strings.ToLower(strings.TrimSpace(planResp.Stance)),
+	}
+	appConfig.Pattern.Type = strings.ToLower(strings.TrimSpace(appConfig.Pattern.Type))
+	if err := appConfig.Validate(); err != nil {
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: %w", err)
+	}
+
+	var stance models.Stance
+	switch appConfig.Stance {
+	case "orthodox":
+		stance = models.Orthodox
+	case "southpaw":
+		stance = models.Southpaw
+	default:
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: stance must be orthodox or southpaw, got %s", planResp.Stance)
+	}
+
+	tempo := models.ParseTempo(planResp.Tempo)
+	if tempo == models.TempoUnknown {
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: tempo must be one of: slow, medium, fast, superfast, got %s", planResp.Tempo)
+	}
+	if appConfig.Pattern.MaxMoves > tempo.MaxMovesLimit() {
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: max_moves (%d) cannot exceed %d for %s tempo", appConfig.Pattern.MaxMoves, tempo.MaxMovesLimit(), tempo.DisplayName())
+	}
+
+	if appConfig.Workout.TotalRounds > maxPlanRounds {
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: total_rounds must be at most %d, got %d", maxPlanRounds, appConfig.Workout.TotalRounds)
+	}
+	if appConfig.Workout.WorkDurationSeconds > maxPlanWorkDurationSeconds {
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: work_duration_seconds must be at most %d, got %d", maxPlanWorkDurationSeconds, appConfig.Workout.WorkDurationSeconds)
+	}
+	if appConfig.Workout.RestDurationSeconds > maxPlanRestDurationSeconds {
+		return WorkoutPlan{}, fmt.Errorf("invalid plan: rest_duration_seconds must be at most %d, got %d", maxPlanRestDurationSeconds, appConfig.Workout.RestDurationSeconds)
+	}
+
+	workout, err := lg.workoutFromResponse(planResp.Rounds, appConfig.Workout.ToModelsWorkoutConfig(), appConfig.Pattern.ToModelsWorkoutPattern())
+	if err != nil {
+		return WorkoutPlan{}, err
+	}
+
+	return WorkoutPlan{
+		Goal:    goal,
+		Config:  appConfig,
+		Tempo:   tempo,
+		Stance:  stance,
+		Workout: workout,
+		Notes:   strings.TrimSpace(planResp.Notes),
+	}, nil
+}
+
+// buildGoalPrompt constructs the prompt asking OpenAI to plan a whole workout from a goal, with an optional error message from a previous attempt
+func (lg *LLMWorkoutGenerator) buildGoalPrompt(goal string, previousError string) string {
+	var sb strings.Builder
+
+	// If this is a retry, add error information at the top
+	if previousError != "" {
+		sb.WriteString("═══════════════════════════════════════════════════════════════\n")
+		sb.WriteString("PREVIOUS ATTEMPT FAILED - PLEASE FIX THE FOLLOWING ERROR:\n")
+		sb.WriteString("═══════════════════════════════════════════════════════════════\n")
+		sb.WriteString(previousError)
+		sb.WriteString("\n\n")
+		sb.WriteString("The plan you generated violated the constraints below. Please regenerate the whole plan, paying special attention to the error message.\n")
+		sb.WriteString("═══════════════════════════════════════════════════════════════\n\n")
+	}
+
+	sb.WriteString("Design a complete heavy bag boxing workout for the following goal:\n\n")
+	sb.WriteString(fmt.Sprintf("GOAL: %s\n\n", goal))
+	sb.WriteString("You decide the round timing, combo pattern, tempo, stance and every combo. Follow the goal closely: ")
+	sb.WriteString("if it gives a total time, choose rounds, work and rest durations that add up to roughly that time; ")
+	sb.WriteString("if it names a stance, use it, otherwise use orthodox.\n\n")
+
+	sb.WriteString("Move numbers (stance-specific names):\n")
+	sb.WriteString(lg.moveMapping.GetMappingDescriptionWithStance(models.Orthodox))
+	sb.WriteString("For a southpaw boxer the same numbers are used, with lead and rear hands swapped.\n\n")
+
+	sb.WriteString("Constraints:\n")
+	sb.WriteString(fmt.Sprintf("- workout.total_rounds: 1-%d\n", maxPlanRounds))
+	sb.WriteString(fmt.Sprintf("- workout.work_duration_seconds: 1-%d\n", maxPlanWorkDurationSeconds))
+	sb.WriteString(fmt.Sprintf("- workout.rest_duration_seconds: 0-%d\n", maxPlanRestDurationSeconds))
+	sb.WriteString("- pattern.type: one of linear, pyramid, random, constant\n")
+	sb.WriteString("- pattern.min_moves >= 1 and pattern.max_moves >= pattern.min_moves\n")
+	sb.WriteString("- tempo: one of slow, medium, fast, superfast. The tempo limits max_moves: ")
+	tempoLimits := make([]string, 0, len(models.AllTempos()))
+	for _, tempo := range models.AllTempos() {
+		tempoLimits = append(tempoLimits, fmt.Sprintf("%s ≤ %d", tempo, tempo.MaxMovesLimit()))
+	}
+	sb.WriteString(strings.Join(tempoLimits, ", "))
+	sb.WriteString("\n")
+	sb.WriteString("- stance: orthodox or southpaw\n")
+	sb.WriteString("- pattern.include_defensive must be true if any combo uses defensive moves (7-12)\n")
+	sb.WriteString("- rounds: exactly workout.total_rounds entries numbered 1 to total_rounds, one combo each\n")
+	sb.WriteString("- every combo must have between pattern.min_moves and pattern.max_moves moves\n")
+	sb.WriteString("- for a linear pattern, each round must have at least as many moves as the previous round\n")
+	sb.WriteString(fmt.Sprintf("- cue (optional, per round): a short coaching cue for that round's combo, under 10 words, written in %s\n", lg.cueLanguage()))
+	sb.WriteString("- notes: one or two sentences explaining how the plan meets the goal\n\n")
+
+	sb.WriteString("Return the plan in the following JSON format:\n")
+	sb.WriteString(`{
+  "workout": {"work_duration_seconds": 60, "rest_duration_seconds": 30, "total_rounds": 8},
+  "pattern": {"type": "linear", "min_moves": 2, "max_moves": 4, "include_defensive": true},
+  "tempo": "medium",
+  "stance": "southpaw",
+  "notes": "Slip-counter combos build from two to four moves so the last rounds finish hard.",
+  "rounds": [
+    {"round_number": 1, "combo": {"moves": [7, 2]}, "cue": "Slip small and keep your eyes up"},
+    {"round_number": 2, "combo": {"moves": [1, 7, 2]}, "cue": "Exhale on every punch"}
+  ]
+}`)
+	sb.WriteString("\n\n")
+	sb.WriteString("- Return ONLY valid JSON, no additional text or explanation\n")
+
+	return sb.String()
+}
@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"encoding/json"
+	"heavybagworkout/internal/mocks"
+	"heavybagworkout/internal/models"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// chatResponseBody wraps content in an OpenAI chat completion response body
+func chatResponseBody(t *testing.T, content string) string {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{
+		"choices": []map[string]interface{}{
+			{"message": map[string]string{"content": content}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal chat response: %v", err)
+	}
+	return string(body)
+}
+
+const validPlanJSON = `{
+  "workout": {"work_duration_seconds": 90, "rest_duration_seconds": 30, "total_rounds": 3},
+  "pattern": {"type": "linear", "min_moves": 2, "max_moves": 4, "include_defensive": true},
+  "tempo": "medium",
+  "stance": "Southpaw",
+  "notes": "Slip-counters that build so the last round finishes hard.",
+  "rounds": [
+    {"round_number": 1, "combo": {"moves": [7, 2]}},
+    {"round_number": 2, "combo": {"moves": [1, 7, 2]}},
+    {"round_number": 3, "combo": {"moves": [1, 7, 2, 3]}}
+  ]
+}`
+
+func TestLLMWorkoutGeneratorPlanWorkoutFromGoal_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+	var capturedPrompt string
+	mockHTTP.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			capturedPrompt = string(body)
+			return newHTTPResponse(http.StatusOK, chatResponseBody(t, validPlanJSON)), nil
+		})
+
+	gen := NewLLMWorkoutGeneratorWithOpenAIClient(NewOpenAIClientWithHTTPClient("test-key", mockHTTP))
+	plan, err := gen.PlanWorkoutFromGoal("6 minutes, counters off the slip, southpaw, finish hard")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(capturedPrompt, "counters off the slip") {
+		t.Errorf("expected prompt to contain the goal")
+	}
+	if plan.Stance != models.Southpaw || plan.Config.Stance != "southpaw" {
+		t.Errorf("expected southpaw stance, got %v / %s", plan.Stance, plan.Config.Stance)
+	}
+	if plan.Tempo != models.TempoMedium {
+		t.Errorf("expected medium tempo, got %v", plan.Tempo)
+	}
+	if plan.Workout.RoundCount() != 3 {
+		t.Fatalf("expected 3 rounds, got %d", plan.Workout.RoundCount())
+	}
+	if plan.Workout.Rounds[0].WorkDuration != 90*time.Second || plan.Workout.Rounds[0].RestDuration != 30*time.Second {
+		t.Errorf("expected round timing from plan, got %v/%v", plan.Workout.Rounds[0].WorkDuration, plan.Workout.Rounds[0].RestDuration)
+	}
+	if plan.Notes == "" {
+		t.Errorf("expected plan notes to be kept")
+	}
+}
+
+func TestLLMWorkoutGeneratorPlanWorkoutFromGoal_RetriesInvalidPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Superfast tempo only allows 2 moves per combo, so the first plan must be rejected
+	invalidPlan := strings.Replace(validPlanJSON, `"tempo": "medium"`, `"tempo": "superfast"`, 1)
+
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+	var retryPrompt string
+	gomock.InOrder(
+		mockHTTP.EXPECT().
+			Do(gomock.Any()).
+			Return(newHTTPResponse(http.StatusOK, chatResponseBody(t, invalidPlan)), nil),
+		mockHTTP.EXPECT().
+			Do(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				retryPrompt = string(body)
+				return newHTTPResponse(http.StatusOK, chatResponseBody(t, validPlanJSON)), nil
+			}),
+	)
+
+	gen := NewLLMWorkoutGeneratorWithOpenAIClient(NewOpenAIClientWithHTTPClient("test-key", mockHTTP))
+	plan, err := gen.PlanWorkoutFromGoal("fast and short")
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if !strings.Contains(retryPrompt, "cannot exceed 2 for Superfast tempo") {
+		t.Errorf("expected retry prompt to include the validation error")
+	}
+	if plan.Tempo != models.TempoMedium {
+		t.Errorf("expected plan from retry, got tempo %v", plan.Tempo)
+	}
+	if len(gen.LastUsage().Attempts) != 2 {
+		t.Errorf("expected 2 attempts recorded, got %d", len(gen.LastUsage().Attempts))
+	}
+}
+
+func TestLLMWorkoutGeneratorPlanFromResponse_Validation(t *testing.T) {
+	gen := NewLLMWorkoutGenerator("")
+
+	tests := []struct {
+		name    string
+		modify  func(p *WorkoutPlanResponseJSON)
+		wantErr string
+	}{
+		{
+			name:    "invalid pattern type",
+			modify:  func(p *WorkoutPlanResponseJSON) { p.Pattern.Type = "zigzag" },
+			wantErr: "pattern type must be one of",
+		},
+		{
+			name:    "invalid stance",
+			modify:  func(p *WorkoutPlanResponseJSON) { p.Stance = "sideways" },
+			wantErr: "stance must be orthodox or southpaw",
+		},
+		{
+			name:    "invalid tempo",
+			modify:  func(p *WorkoutPlanResponseJSON) { p.Tempo = "ludicrous" },
+			wantErr: "tempo must be one of",
+		},
+		{
+			name:    "too many rounds",
+			modify:  func(p *WorkoutPlanResponseJSON) { p.Workout.TotalRounds = 100 },
+			wantErr: "total_rounds must be at most",
+		},
+		{
+			name:    "round count mismatch",
+			modify:  func(p *WorkoutPlanResponseJSON) { p.Rounds = p.Rounds[:2] },
+			wantErr: "workout has 2 rounds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var planResp WorkoutPlanResponseJSON
+			if err := json.Unmarshal([]byte(validPlanJSON), &planResp); err != nil {
+				t.Fatalf("failed to parse plan fixture: %v", err)
+			}
+			tt.modify(&planResp)
+
+			_, err := gen.planFromResponse(planResp, "goal")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLLMWorkoutGeneratorPlanWorkoutFromGoal_EmptyGoal(t *testing.T) {
+	gen := NewLLMWorkoutGenerator("")
+	if _, err := gen.PlanWorkoutFromGoal("   "); err == nil {
+		t.Fatalf("expected error for empty goal")
+	}
+}
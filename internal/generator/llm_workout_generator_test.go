@@ -6,6 +6,7 @@ import (
 	"heavybagworkout/internal/models"
 	"io"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -367,3 +368,75 @@ func TestLLMWorkoutGenerator_ExcludesDefensiveMovesWhenDisabled(t *testing.T) {
 		}
 	}
 }
+
+func TestLLMWorkoutGenerator_RecordsUsageForEachAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+	gomock.InOrder(
+		mockHTTP.EXPECT().
+			Do(gomock.Any()).
+			Return(newHTTPResponse(http.StatusOK, `{"model":"gpt-4.1-nano","usage":{"prompt_tokens":1000,"completion_tokens":100,"total_tokens":1100},"choices":[{"message":{"content":"not json"}}]}`), nil),
+		mockHTTP.EXPECT().
+			Do(gomock.Any()).
+			Return(newHTTPResponse(http.StatusOK, `{"model":"gpt-4.1-nano","usage":{"prompt_tokens":1200,"completion_tokens":150,"total_tokens":1350},"choices":[{"message":{"content":"{\"rounds\":[{\"round_number\":1,\"combo\":{\"moves\":[1,2]}}]}"}}]}`), nil),
+	)
+
+	gen := NewLLMWorkoutGeneratorWithOpenAIClient(NewOpenAIClientWithHTTPClient("test-key", mockHTTP))
+	spendLog := NewSpendLog(filepath.Join(t.TempDir(), SpendLogFileName))
+	gen.SetSpendLog(spendLog, 0)
+
+	config := models.WorkoutConfig{WorkDuration: 20 * time.Second, RestDuration: 10 * time.Second, TotalRounds: 1}
+	pattern := models.NewWorkoutPattern(models.PatternConstant, 1, 3, false)
+	if _, err := gen.GenerateWorkout(config, pattern); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	usage := gen.LastUsage()
+	if len(usage.Attempts) != 2 {
+		t.Fatalf("expected 2 attempts recorded, got %d", len(usage.Attempts))
+	}
+	if usage.Attempts[0].Error == "" {
+		t.Errorf("expected failed first attempt to record its error")
+	}
+	if usage.Attempts[1].Error != "" {
+		t.Errorf("expected successful retry to have no error, got %s", usage.Attempts[1].Error)
+	}
+	if total := usage.TotalTokens(); total.TotalTokens != 2450 {
+		t.Errorf("expected 2450 total tokens, got %d", total.TotalTokens)
+	}
+	if !usage.FullyPriced() || usage.TotalCostUSD() <= 0 {
+		t.Errorf("expected priced usage, got %+v", usage)
+	}
+
+	entries, err := spendLog.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Attempts != 2 || entries[0].TotalTokens != 2450 {
+		t.Errorf("expected one spend entry covering both attempts, got %+v", entries)
+	}
+}
+
+func TestLLMWorkoutGenerator_RefusesWhenMonthlyBudgetExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No HTTP call is expected once the budget is used up
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+
+	gen := NewLLMWorkoutGeneratorWithOpenAIClient(NewOpenAIClientWithHTTPClient("test-key", mockHTTP))
+	spendLog := NewSpendLog(filepath.Join(t.TempDir(), SpendLogFileName))
+	if err := spendLog.Append(SpendEntry{Time: time.Now(), Model: "gpt-4.1-nano", CostUSD: 2.5}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	gen.SetSpendLog(spendLog, 2.0)
+
+	config := models.WorkoutConfig{WorkDuration: 20 * time.Second, RestDuration: 10 * time.Second, TotalRounds: 1}
+	pattern := models.NewWorkoutPattern(models.PatternConstant, 1, 3, false)
+	_, err := gen.GenerateWorkout(config, pattern)
+	if !errors.Is(err, ErrMonthlyBudgetExceeded) {
+		t.Fatalf("expected ErrMonthlyBudgetExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"heavybagworkout/internal/config"
+	"heavybagworkout/internal/llmstub"
+	"heavybagworkout/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newStubGenerator creates an LLM generator pointed at a local stub server, with spend recorded in a temp dir
+func newStubGenerator(t *testing.T) (*LLMWorkoutGenerator, *llmstub.Server) {
+	t.Helper()
+	t.Setenv("HEAVYBAG_DATA_DIR", t.TempDir())
+
+	srv := llmstub.NewServer()
+	t.Cleanup(srv.Close)
+
+	gen, err := NewLLMWorkoutGeneratorFromConfig("", config.GeneratorConfig{UseLLM: true, BaseURL: srv.BaseURL()})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+	return gen, srv
+}
+
+func TestLLMStub_GenerateWorkoutEndToEnd(t *testing.T) {
+	patterns := []models.WorkoutPatternType{models.PatternLinear, models.PatternPyramid, models.PatternRandom, models.PatternConstant}
+	for _, patternType := range patterns {
+		t.Run(string(patternType), func(t *testing.T) {
+			gen, srv := newStubGenerator(t)
+			config := models.NewWorkoutConfig(20*time.Second, 10*time.Second, 6)
+			pattern := models.NewWorkoutPattern(patternType, 1, 4, true)
+
+			workout, err := gen.GenerateWorkout(config, pattern)
+			if err != nil {
+				t.Fatalf("expected stub workout to pass validation, got %v", err)
+			}
+			if workout.RoundCount() != 6 {
+				t.Errorf("expected 6 rounds, got %d", workout.RoundCount())
+			}
+			if requests := srv.Requests(); len(requests) != 1 {
+				t.Errorf("expected 1 request, got %d", len(requests))
+			}
+			if usage := gen.LastUsage(); usage.TotalTokens().TotalTokens == 0 {
+				t.Error("expected token usage from the stub")
+			}
+		})
+	}
+}
+
+func TestLLMStub_RetriesAfterFault(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault llmstub.Fault
+	}{
+		{name: "bad JSON", fault: llmstub.FaultBadJSON},
+		{name: "wrong round count", fault: llmstub.FaultWrongRoundCount},
+		{name: "rate limit", fault: llmstub.FaultRateLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, srv := newStubGenerator(t)
+			srv.Enqueue(llmstub.Step{Fault: tt.fault})
+
+			workout, err := gen.GenerateWorkout(models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3), models.NewWorkoutPattern(models.PatternConstant, 2, 3, false))
+			if err != nil {
+				t.Fatalf("expected retry to succeed, got %v", err)
+			}
+			if workout.RoundCount() != 3 {
+				t.Errorf("expected 3 rounds, got %d", workout.RoundCount())
+			}
+
+			requests := srv.Requests()
+			if len(requests) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(requests))
+			}
+			if !strings.Contains(requests[1].Prompt, "PREVIOUS ATTEMPT FAILED") {
+				t.Error("expected the retry prompt to include the previous error")
+			}
+		})
+	}
+}
+
+func TestLLMStub_FailsWhenEveryAttemptFaults(t *testing.T) {
+	gen, srv := newStubGenerator(t)
+	srv.SetDefault(llmstub.Step{Fault: llmstub.FaultServerError})
+
+	_, err := gen.GenerateWorkout(models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3), models.NewWorkoutPattern(models.PatternConstant, 2, 3, false))
+	if err == nil || !strings.Contains(err.Error(), "status 500") {
+		t.Fatalf("expected server error after retry, got %v", err)
+	}
+	if requests := srv.Requests(); len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(requests))
+	}
+}
+
+func TestLLMStub_PlanWorkoutFromGoal(t *testing.T) {
+	gen, _ := newStubGenerator(t)
+
+	plan, err := gen.PlanWorkoutFromGoal("ten minutes of slip counters")
+	if err != nil {
+		t.Fatalf("expected stub plan to pass validation, got %v", err)
+	}
+	if plan.Workout.RoundCount() != 3 || plan.Tempo != models.TempoMedium {
+		t.Errorf("unexpected plan: %d rounds, %s tempo", plan.Workout.RoundCount(), plan.Tempo)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -18,10 +19,15 @@ type HTTPClient interface {
 type OpenAIClient struct {
 	apiKey     string
 	baseURL    string
+	model      string
 	httpClient HTTPClient
 }
 
-const defaultOpenAIBaseURL = "https://api.openai.com/v1/chat/completions"
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1/chat/completions"
+	chatCompletionsPath  = "/chat/completions"
+	defaultOpenAIModel   = "gpt-4.1-nano" // Using a cost-effective model
+)
 
 // NewOpenAIClient creates a new OpenAI client with the default HTTP client.
 func NewOpenAIClient(apiKey string) *OpenAIClient {
@@ -38,10 +44,36 @@ func NewOpenAIClientWithHTTPClient(apiKey string, client HTTPClient) *OpenAIClie
 	return &OpenAIClient{
 		apiKey:     apiKey,
 		baseURL:    defaultOpenAIBaseURL,
+		model:      defaultOpenAIModel,
 		httpClient: client,
 	}
 }
 
+// SetBaseURL points the client at an OpenAI-compatible API, such as a local stub server.
+// It accepts either the API root (e.g. "http://127.0.0.1:8089/v1") or the full chat completions URL.
+// An empty URL restores the OpenAI API.
+func (c *OpenAIClient) SetBaseURL(baseURL string) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	switch {
+	case baseURL == "":
+		c.baseURL = defaultOpenAIBaseURL
+	case strings.HasSuffix(baseURL, chatCompletionsPath):
+		c.baseURL = baseURL
+	default:
+		c.baseURL = baseURL + chatCompletionsPath
+	}
+}
+
+// BaseURL returns the chat completions URL requests are sent to
+func (c *OpenAIClient) BaseURL() string {
+	return c.baseURL
+}
+
+// Model returns the model name sent with each request
+func (c *OpenAIClient) Model() string {
+	return c.model
+}
+
 // ChatMessage represents a message in the chat API
 type ChatMessage struct {
 	Role    string `json:"role"`
@@ -54,8 +86,17 @@ type ChatRequest struct {
 	Messages []ChatMessage `json:"messages"`
 }
 
+// ChatUsage represents the token usage block returned by OpenAI API
+type ChatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
 // ChatResponse represents the response from OpenAI API
 type ChatResponse struct {
+	Model   string     `json:"model,omitempty"`
+	Usage   *ChatUsage `json:"usage,omitempty"`
 	Choices []struct {
 		Message struct {
 			Content string `json:"content"`
@@ -68,8 +109,16 @@ type ChatResponse struct {
 
 // GenerateWorkoutRequest sends a request to OpenAI to generate a workout
 func (c *OpenAIClient) GenerateWorkoutRequest(prompt string) (string, error) {
+	content, _, err := c.GenerateWorkoutRequestWithUsage(prompt)
+	return content, err
+}
+
+// GenerateWorkoutRequestWithUsage sends a request to OpenAI and also returns the token usage reported by the API.
+// Usage is returned whenever the response carried a usage block, even if the request otherwise failed.
+func (c *OpenAIClient) GenerateWorkoutRequestWithUsage(prompt string) (string, TokenUsage, error) {
+	usage := TokenUsage{Model: c.model}
 	reqBody := ChatRequest{
-		Model: "gpt-4.1-nano", // Using a cost-effective model
+		Model: c.model,
 		Messages: []ChatMessage{
 			{
 				Role:    "system",
@@ -84,12 +133,12 @@ func (c *OpenAIClient) GenerateWorkoutRequest(prompt string) (string, error) {
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", usage, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", c.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", usage, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -97,39 +146,48 @@ func (c *OpenAIClient) GenerateWorkoutRequest(prompt string) (string, error) {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", usage, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", usage, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var chatResp ChatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
 		// If unmarshaling fails, return raw body for non-200 status codes
 		if resp.StatusCode != http.StatusOK {
-			return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+			return "", usage, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 		}
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		return "", usage, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if chatResp.Model != "" {
+		usage.Model = chatResp.Model
+	}
+	if chatResp.Usage != nil {
+		usage.PromptTokens = chatResp.Usage.PromptTokens
+		usage.CompletionTokens = chatResp.Usage.CompletionTokens
+		usage.TotalTokens = chatResp.Usage.TotalTokens
 	}
 
 	// Check for error in response (even for non-200 status codes)
 	if chatResp.Error != nil {
 		if resp.StatusCode != http.StatusOK {
-			return "", fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, chatResp.Error.Message)
+			return "", usage, fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, chatResp.Error.Message)
 		}
-		return "", fmt.Errorf("OpenAI API error: %s", chatResp.Error.Message)
+		return "", usage, fmt.Errorf("OpenAI API error: %s", chatResp.Error.Message)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", usage, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	if len(chatResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices in response")
+		return "", usage, fmt.Errorf("no choices in response")
 	}
 
-	return chatResp.Choices[0].Message.Content, nil
+	return chatResp.Choices[0].Message.Content, usage, nil
 }
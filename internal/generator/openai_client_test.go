@@ -116,3 +116,70 @@ func TestOpenAIClientGenerateWorkoutRequest_InvalidJSON(t *testing.T) {
 		t.Fatalf("expected json error, got %v", err)
 	}
 }
+
+func TestOpenAIClientGenerateWorkoutRequestWithUsage_ParsesUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockHTTPClient(ctrl)
+	mockClient.EXPECT().
+		Do(gomock.Any()).
+		Return(newHTTPResponse(http.StatusOK, `{"model":"gpt-4.1-nano-2025-04-14","usage":{"prompt_tokens":1200,"completion_tokens":300,"total_tokens":1500},"choices":[{"message":{"content":"{}"}}]}`), nil)
+
+	client := NewOpenAIClientWithHTTPClient("test-key", mockClient)
+	_, usage, err := client.GenerateWorkoutRequestWithUsage("prompt")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if usage.Model != "gpt-4.1-nano-2025-04-14" {
+		t.Errorf("expected model from response, got %s", usage.Model)
+	}
+	if usage.PromptTokens != 1200 || usage.CompletionTokens != 300 || usage.TotalTokens != 1500 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestOpenAIClientGenerateWorkoutRequestWithUsage_NoUsageBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockHTTPClient(ctrl)
+	mockClient.EXPECT().
+		Do(gomock.Any()).
+		Return(newHTTPResponse(http.StatusOK, `{"choices":[{"message":{"content":"{}"}}]}`), nil)
+
+	client := NewOpenAIClientWithHTTPClient("test-key", mockClient)
+	_, usage, err := client.GenerateWorkoutRequestWithUsage("prompt")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if usage.Model != client.Model() {
+		t.Errorf("expected request model %s, got %s", client.Model(), usage.Model)
+	}
+	if usage.TotalTokens != 0 {
+		t.Errorf("expected zero tokens without usage block, got %d", usage.TotalTokens)
+	}
+}
+
+func TestOpenAIClientSetBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{name: "API root", baseURL: "http://127.0.0.1:8089/v1", want: "http://127.0.0.1:8089/v1/chat/completions"},
+		{name: "trailing slash", baseURL: "http://127.0.0.1:8089/v1/", want: "http://127.0.0.1:8089/v1/chat/completions"},
+		{name: "full endpoint", baseURL: "http://127.0.0.1:8089/v1/chat/completions", want: "http://127.0.0.1:8089/v1/chat/completions"},
+		{name: "empty restores default", baseURL: "", want: defaultOpenAIBaseURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewOpenAIClient("test-key")
+			client.SetBaseURL(tt.baseURL)
+			if got := client.BaseURL(); got != tt.want {
+				t.Errorf("BaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package generator
+
+import (
+	_ "embed"
+	"fmt"
+	"heavybagworkout/internal/config"
+	"heavybagworkout/internal/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// defaultWorkoutPromptTemplate is the built-in workout generation prompt
+//
+//go:embed prompts/workout.tmpl
+var defaultWorkoutPromptTemplate string
+
+const (
+	// PromptsDirName is the directory inside the user's data directory that holds prompt templates
+	PromptsDirName = "prompts"
+	// WorkoutPromptFileName is the file name of the user's workout prompt template
+	WorkoutPromptFileName = "workout.tmpl"
+)
+
+// requiredPromptPlaceholders are the fields every workout prompt template must use,
+// since the LLM can't produce a valid workout without them
+var requiredPromptPlaceholders = []string{
+	".MappingDescription",
+	".Config.TotalRounds",
+	".Pattern.MinMoves",
+	".Pattern.MaxMoves",
+}
+
+// defaultCueLanguage is the language coaching cues are asked for in unless the generator has a locale
+const defaultCueLanguage = "English (en)"
+
+// promptTemplateFuncs are the helper functions available to prompt templates
+var promptTemplateFuncs = template.FuncMap{
+	"add":      func(a, b int) int { return a + b },
+	"sub":      func(a, b int) int { return a - b },
+	"contains": strings.Contains,
+}
+
+// PromptData is the data passed to workout prompt templates
+type PromptData struct {
+	Config             models.WorkoutConfig
+	Pattern            models.WorkoutPattern
+	Stance             models.Stance
+	MappingDescription string
+	PreviousError      string // Empty on the first attempt
+	CueLanguage        string // Language of the coaching cues, e.g. "English (en)"
+
+	// Precomputed values so templates don't need arithmetic
+	WorkSeconds  float64
+	RestSeconds  float64
+	IsLinear     bool
+	IsSouthpaw   bool
+	RoundTargets []RoundTarget
+}
+
+// RoundTarget is the target number of moves for one round
+type RoundTarget struct {
+	Number   int
+	Target   int
+	Min      int // Lowest allowed moves (linear pattern: target - 1, clamped to pattern min)
+	Max      int // Highest allowed moves (linear pattern: target + 1, clamped to pattern max)
+	Previous int // Previous round number (0 for round 1)
+}
+
+// NewPromptData builds the template data for a workout generation prompt
+func NewPromptData(config models.WorkoutConfig, pattern models.WorkoutPattern, stance models.Stance, mappingDescription string, previousError string) PromptData {
+	targets := make([]RoundTarget, 0, config.TotalRounds)
+	for i := 1; i <= config.TotalRounds; i++ {
+		moves := pattern.GetMovesPerRound(i, config.TotalRounds)
+		minAllowed, maxAllowed := moves, moves
+		if pattern.Type == models.PatternLinear {
+			minAllowed = moves - 1
+			if minAllowed < pattern.MinMoves {
+				minAllowed = pattern.MinMoves
+			}
+			maxAllowed = moves + 1
+			if maxAllowed > pattern.MaxMoves {
+				maxAllowed = pattern.MaxMoves
+			}
+		}
+		targets = append(targets, RoundTarget{
+			Number:   i,
+			Target:   moves,
+			Min:      minAllowed,
+			Max:      maxAllowed,
+			Previous: i - 1,
+		})
+	}
+
+	return PromptData{
+		Config:             config,
+		Pattern:            pattern,
+		Stance:             stance,
+		MappingDescription: mappingDescription,
+		PreviousError:      previousError,
+		CueLanguage:        defaultCueLanguage,
+		WorkSeconds:        config.WorkDuration.Seconds(),
+		RestSeconds:        config.RestDuration.Seconds(),
+		IsLinear:           pattern.Type == models.PatternLinear,
+		IsSouthpaw:         stance == models.Southpaw,
+		RoundTargets:       targets,
+	}
+}
+
+// PromptTemplate renders workout generation prompts from a Go text/template
+type PromptTemplate struct {
+	name string
+	tmpl *template.Template
+}
+
+// DefaultPromptTemplate returns the built-in workout prompt template
+func DefaultPromptTemplate() *PromptTemplate {
+	pt, err := ParsePromptTemplate("default", defaultWorkoutPromptTemplate)
+	if err != nil {
+		// The built-in template is covered by tests, so this only happens during development
+		panic(fmt.Sprintf("built-in prompt template is invalid: %v", err))
+	}
+	return pt
+}
+
+// DefaultPromptTemplateText returns the source of the built-in template, as a starting point for customization
+func DefaultPromptTemplateText() string {
+	return defaultWorkoutPromptTemplate
+}
+
+// ParsePromptTemplate parses and validates a prompt template.
+// It returns an error if the template doesn't parse, is missing a required placeholder,
+// or fails to render sample data (e.g. a misspelled field name).
+func ParsePromptTemplate(name string, text string) (*PromptTemplate, error) {
+	tmpl, err := template.New(name).Funcs(promptTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt template %s: %w", name, err)
+	}
+
+	used := make(map[string]bool)
+	collectTemplateFields(tmpl.Tree.Root, used)
+	var missing []string
+	for _, placeholder := range requiredPromptPlaceholders {
+		if !used[placeholder] {
+			missing = append(missing, "{{"+placeholder+"}}")
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("prompt template %s is missing required placeholders: %s", name, strings.Join(missing, ", "))
+	}
+
+	pt := &PromptTemplate{name: name, tmpl: tmpl}
+
+	// Render sample data so mistakes surface at load time rather than mid-generation
+	sample := NewPromptData(
+		models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3),
+		models.NewWorkoutPattern(models.PatternLinear, 1, 3, true),
+		models.Orthodox,
+		models.NewMoveMapping().GetMappingDescriptionWithStance(models.Orthodox),
+		"sample error",
+	)
+	if _, err := pt.Render(sample); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+// LoadPromptTemplate loads and validates a prompt template from a file
+func LoadPromptTemplate(path string) (*PromptTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt template: %w", err)
+	}
+	return ParsePromptTemplate(filepath.Base(path), string(data))
+}
+
+// LoadUserPromptTemplate loads the workout prompt template from the user's data directory
+// (<data dir>/prompts/workout.tmpl), falling back to the built-in template if there isn't one
+func LoadUserPromptTemplate() (*PromptTemplate, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, PromptsDirName, WorkoutPromptFileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return DefaultPromptTemplate(), nil
+	}
+	return LoadPromptTemplate(path)
+}
+
+// Name returns the template name (the file name for templates loaded from disk)
+func (pt *PromptTemplate) Name() string {
+	return pt.name
+}
+
+// Render executes the template with the given data
+func (pt *PromptTemplate) Render(data PromptData) (string, error) {
+	var sb strings.Builder
+	if err := pt.tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %s: %w", pt.name, err)
+	}
+	return sb.String(), nil
+}
+
+// collectTemplateFields records every field chain used in a template (e.g. ".Config.TotalRounds")
+func collectTemplateFields(node parse.Node, used map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateFields(child, used)
+		}
+	case *parse.ActionNode:
+		collectTemplateFields(n.Pipe, used)
+	case *parse.IfNode:
+		collectTemplateFields(n.Pipe, used)
+		collectTemplateFields(n.List, used)
+		collectTemplateFields(n.ElseList, used)
+	case *parse.RangeNode:
+		collectTemplateFields(n.Pipe, used)
+		collectTemplateFields(n.List, used)
+		collectTemplateFields(n.ElseList, used)
+	case *parse.WithNode:
+		collectTemplateFields(n.Pipe, used)
+		collectTemplateFields(n.List, used)
+		collectTemplateFields(n.ElseList, used)
+	case *parse.TemplateNode:
+		collectTemplateFields(n.Pipe, used)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateFields(cmd, used)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateFields(arg, used)
+		}
+	case *parse.FieldNode:
+		used["."+strings.Join(n.Ident, ".")] = true
+	case *parse.VariableNode:
+		// $.Config.TotalRounds refers to the root data, same as .Config.TotalRounds
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			used["."+strings.Join(n.Ident[1:], ".")] = true
+		}
+	}
+}
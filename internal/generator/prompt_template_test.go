@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"heavybagworkout/internal/i18n"
+	"heavybagworkout/internal/mocks"
+	"heavybagworkout/internal/models"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// minimalPromptTemplate uses every required placeholder and nothing else
+const minimalPromptTemplate = `Coach style: short and strict.
+{{.MappingDescription}}
+Rounds: {{.Config.TotalRounds}}, moves {{.Pattern.MinMoves}}-{{.Pattern.MaxMoves}}.
+{{if .PreviousError}}Fix: {{.PreviousError}}{{end}}`
+
+func TestDefaultPromptTemplate_Renders(t *testing.T) {
+	lg := NewLLMWorkoutGenerator("")
+	config := models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3)
+	pattern := models.NewWorkoutPattern(models.PatternLinear, 1, 3, false)
+
+	prompt, err := lg.buildWorkoutPromptWithError(config, pattern, models.Southpaw, "round 1: combo has 4 moves, but maximum is 3")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, want := range []string{
+		"PREVIOUS ATTEMPT FAILED",
+		"Never exceed 3 moves in any combo",
+		"CRITICAL: You MUST generate EXACTLY 3 rounds",
+		"  Round 2: Target 2 moves (base range: 1-3, but ACTUAL moves = MAX(1, Round 1's moves))",
+		"a southpaw (left-handed) boxer",
+		"Use ONLY numbers 1-6 for punches",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q", want)
+		}
+	}
+	if strings.Contains(prompt, "{{") {
+		t.Errorf("expected no unrendered template actions in prompt")
+	}
+}
+
+func TestLLMWorkoutGenerator_PromptAsksForCuesInLocale(t *testing.T) {
+	lg := NewLLMWorkoutGenerator("")
+	config := models.NewWorkoutConfig(20*time.Second, 10*time.Second, 3)
+	pattern := models.NewWorkoutPattern(models.PatternConstant, 2, 2, false)
+
+	prompt, err := lg.buildWorkoutPrompt(config, pattern, models.Orthodox)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(prompt, "written in English (en)") {
+		t.Errorf("expected English cues without a locale")
+	}
+
+	spanish, err := i18n.Load("es")
+	if err != nil {
+		t.Fatalf("failed to load es: %v", err)
+	}
+	lg.SetLocale(spanish)
+	prompt, err = lg.buildWorkoutPrompt(config, pattern, models.Orthodox)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(prompt, "written in Español (es)") {
+		t.Errorf("expected the workout prompt to ask for Spanish cues")
+	}
+	if !strings.Contains(lg.buildGoalPrompt("fast hands", ""), "written in Español (es)") {
+		t.Errorf("expected the goal prompt to ask for Spanish cues")
+	}
+}
+
+func TestParsePromptTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{
+			name:    "valid minimal template",
+			text:    minimalPromptTemplate,
+			wantErr: "",
+		},
+		{
+			name:    "root variable counts as placeholder",
+			text:    `{{.MappingDescription}}{{range .RoundTargets}}{{$.Config.TotalRounds}}{{end}}{{.Pattern.MinMoves}}{{.Pattern.MaxMoves}}`,
+			wantErr: "",
+		},
+		{
+			name:    "syntax error",
+			text:    `{{.MappingDescription`,
+			wantErr: "failed to parse prompt template",
+		},
+		{
+			name:    "missing placeholders",
+			text:    `{{.MappingDescription}} {{.Pattern.MinMoves}}`,
+			wantErr: "missing required placeholders: {{.Config.TotalRounds}}, {{.Pattern.MaxMoves}}",
+		},
+		{
+			name:    "misspelled field",
+			text:    minimalPromptTemplate + `{{.Stanse}}`,
+			wantErr: "failed to render prompt template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePromptTemplate("test.tmpl", tt.text)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadUserPromptTemplate(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("HEAVYBAG_DATA_DIR", dataDir)
+
+	// Without a user template, the built-in one is used
+	pt, err := LoadUserPromptTemplate()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pt.Name() != "default" {
+		t.Errorf("expected default template, got %s", pt.Name())
+	}
+
+	promptsDir := filepath.Join(dataDir, PromptsDirName)
+	if err := os.MkdirAll(promptsDir, 0755); err != nil {
+		t.Fatalf("failed to create prompts dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, WorkoutPromptFileName), []byte(minimalPromptTemplate), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	pt, err = LoadUserPromptTemplate()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pt.Name() != WorkoutPromptFileName {
+		t.Errorf("expected user template, got %s", pt.Name())
+	}
+}
+
+func TestLLMWorkoutGenerator_UsesCustomPromptTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTP := mocks.NewMockHTTPClient(ctrl)
+	var capturedPrompt string
+	mockHTTP.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			capturedPrompt = string(body)
+			return newHTTPResponse(http.StatusOK, `{"choices":[{"message":{"content":"{\"rounds\":[{\"round_number\":1,\"combo\":{\"moves\":[1,2]}}]}"}}]}`), nil
+		})
+
+	pt, err := ParsePromptTemplate("custom.tmpl", minimalPromptTemplate)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	gen := NewLLMWorkoutGeneratorWithOpenAIClient(NewOpenAIClientWithHTTPClient("test-key", mockHTTP))
+	gen.SetPromptTemplate(pt)
+
+	config := models.WorkoutConfig{WorkDuration: 20 * time.Second, RestDuration: 10 * time.Second, TotalRounds: 1}
+	pattern := models.NewWorkoutPattern(models.PatternConstant, 1, 3, false)
+	if _, err := gen.GenerateWorkout(config, pattern); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(capturedPrompt, "Coach style: short and strict.") {
+		t.Errorf("expected custom template to be used, got: %s", capturedPrompt)
+	}
+	if strings.Contains(capturedPrompt, "CRITICAL RULE FOR LINEAR PATTERN") {
+		t.Errorf("expected built-in prompt text to be absent")
+	}
+}
@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"heavybagworkout/internal/config"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SpendLogFileName is the name of the spend log file inside the user's data directory
+const SpendLogFileName = "llm_spend.jsonl"
+
+// ErrMonthlyBudgetExceeded is returned when the monthly LLM budget has been used up
+var ErrMonthlyBudgetExceeded = errors.New("monthly LLM budget exceeded")
+
+// SpendEntry represents one generation recorded in the spend log
+type SpendEntry struct {
+	Time             time.Time `json:"time"`
+	Model            string    `json:"model"`
+	Attempts         int       `json:"attempts"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+}
+
+// SpendLog is an append-only JSON lines file recording every LLM generation and its estimated cost
+type SpendLog struct {
+	path string
+}
+
+// NewSpendLog creates a spend log backed by the given file
+func NewSpendLog(path string) *SpendLog {
+	return &SpendLog{path: path}
+}
+
+// DefaultSpendLog creates a spend log in the user's data directory
+func DefaultSpendLog() (*SpendLog, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewSpendLog(filepath.Join(dir, SpendLogFileName)), nil
+}
+
+// Path returns the file backing the spend log
+func (sl *SpendLog) Path() string {
+	return sl.path
+}
+
+// Append records a generation in the spend log
+func (sl *SpendLog) Append(entry SpendEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spend entry: %w", err)
+	}
+
+	f, err := os.OpenFile(sl.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open spend log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write spend log: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every entry in the spend log. A missing file is treated as an empty log.
+func (sl *SpendLog) Entries() ([]SpendEntry, error) {
+	f, err := os.Open(sl.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open spend log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []SpendEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry SpendEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse spend log entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read spend log: %w", err)
+	}
+	return entries, nil
+}
+
+// MonthToDate returns the total estimated spend in the calendar month containing now
+func (sl *SpendLog) MonthToDate(now time.Time) (float64, error) {
+	entries, err := sl.Entries()
+	if err != nil {
+		return 0, err
+	}
+
+	year, month, _ := now.Date()
+	var total float64
+	for _, entry := range entries {
+		entryTime := entry.Time.In(now.Location())
+		if entryTime.Year() == year && entryTime.Month() == month {
+			total += entry.CostUSD
+		}
+	}
+	return total, nil
+}
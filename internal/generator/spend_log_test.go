@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSpendLog_AppendAndMonthToDate(t *testing.T) {
+	log := NewSpendLog(filepath.Join(t.TempDir(), SpendLogFileName))
+
+	now := time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+	entries := []SpendEntry{
+		{Time: time.Date(2025, time.February, 28, 23, 0, 0, 0, time.UTC), Model: "gpt-4.1-nano", CostUSD: 1.00},
+		{Time: time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC), Model: "gpt-4.1-nano", CostUSD: 0.25},
+		{Time: time.Date(2025, time.March, 14, 8, 0, 0, 0, time.UTC), Model: "gpt-4.1-nano", CostUSD: 0.50},
+	}
+	for _, entry := range entries {
+		if err := log.Append(entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	got, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(got) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(got))
+	}
+
+	spent, err := log.MonthToDate(now)
+	if err != nil {
+		t.Fatalf("MonthToDate() error = %v", err)
+	}
+	if math.Abs(spent-0.75) > 1e-9 {
+		t.Errorf("expected month-to-date spend 0.75, got %v", spent)
+	}
+}
+
+func TestSpendLog_MissingFileIsEmpty(t *testing.T) {
+	log := NewSpendLog(filepath.Join(t.TempDir(), "missing.jsonl"))
+
+	spent, err := log.MonthToDate(time.Now())
+	if err != nil {
+		t.Fatalf("MonthToDate() error = %v", err)
+	}
+	if spent != 0 {
+		t.Errorf("expected no spend for missing log, got %v", spent)
+	}
+}
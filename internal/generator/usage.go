@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"fmt"
+	"heavybagworkout/internal/config"
+	"strings"
+)
+
+// TokenUsage represents the tokens consumed by a single LLM request
+type TokenUsage struct {
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// ModelPrice represents the price of a model in USD per million tokens
+type ModelPrice struct {
+	InputPerMillionUSD  float64
+	OutputPerMillionUSD float64
+}
+
+// PriceTable maps model names to their prices
+type PriceTable map[string]ModelPrice
+
+// DefaultPriceTable returns the built-in prices for the models the app is likely to use.
+// Prices change over time, so they can be overridden from config (generator.pricing).
+func DefaultPriceTable() PriceTable {
+	return PriceTable{
+		"gpt-4.1-nano": {InputPerMillionUSD: 0.10, OutputPerMillionUSD: 0.40},
+		"gpt-4.1-mini": {InputPerMillionUSD: 0.40, OutputPerMillionUSD: 1.60},
+		"gpt-4o-mini":  {InputPerMillionUSD: 0.15, OutputPerMillionUSD: 0.60},
+		"gpt-4o":       {InputPerMillionUSD: 2.50, OutputPerMillionUSD: 10.00},
+	}
+}
+
+// PriceTableFromConfig returns the default price table with any config overrides applied
+func PriceTableFromConfig(pricing map[string]config.ModelPricing) PriceTable {
+	table := DefaultPriceTable()
+	for model, price := range pricing {
+		table[model] = ModelPrice{
+			InputPerMillionUSD:  price.InputPerMillionUSD,
+			OutputPerMillionUSD: price.OutputPerMillionUSD,
+		}
+	}
+	return table
+}
+
+// Lookup returns the price for a model. The API reports dated snapshot names
+// (e.g. "gpt-4.1-nano-2025-04-14"), so the longest table entry that prefixes the model name is used.
+func (pt PriceTable) Lookup(model string) (ModelPrice, bool) {
+	if price, ok := pt[model]; ok {
+		return price, true
+	}
+	bestName := ""
+	for name := range pt {
+		if strings.HasPrefix(model, name+"-") && len(name) > len(bestName) {
+			bestName = name
+		}
+	}
+	if bestName == "" {
+		return ModelPrice{}, false
+	}
+	return pt[bestName], true
+}
+
+// EstimateCost returns the estimated cost in USD of a request, and false if the model has no known price
+func (pt PriceTable) EstimateCost(usage TokenUsage) (float64, bool) {
+	price, ok := pt.Lookup(usage.Model)
+	if !ok {
+		return 0, false
+	}
+	cost := float64(usage.PromptTokens)*price.InputPerMillionUSD/1_000_000 +
+		float64(usage.CompletionTokens)*price.OutputPerMillionUSD/1_000_000
+	return cost, true
+}
+
+// AttemptUsage represents the usage of one generation attempt (the first try or a retry)
+type AttemptUsage struct {
+	TokenUsage
+	CostUSD float64
+	Priced  bool   // false if the model was missing from the price table
+	Error   string // empty if the attempt succeeded
+}
+
+// GenerationUsage represents the usage of a whole generation, including retries
+type GenerationUsage struct {
+	Attempts []AttemptUsage
+}
+
+// TotalTokens returns the summed token counts across all attempts
+func (gu GenerationUsage) TotalTokens() TokenUsage {
+	var total TokenUsage
+	for _, attempt := range gu.Attempts {
+		if total.Model == "" {
+			total.Model = attempt.Model
+		}
+		total.PromptTokens += attempt.PromptTokens
+		total.CompletionTokens += attempt.CompletionTokens
+		total.TotalTokens += attempt.TotalTokens
+	}
+	return total
+}
+
+// TotalCostUSD returns the summed estimated cost across all priced attempts
+func (gu GenerationUsage) TotalCostUSD() float64 {
+	var total float64
+	for _, attempt := range gu.Attempts {
+		total += attempt.CostUSD
+	}
+	return total
+}
+
+// FullyPriced returns true if every attempt had a known price
+func (gu GenerationUsage) FullyPriced() bool {
+	for _, attempt := range gu.Attempts {
+		if !attempt.Priced {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary returns a one-line, human readable description of the usage
+// (e.g. "LLM usage: 1234 tokens (1000 in / 234 out), 2 attempts, est. cost $0.0003")
+func (gu GenerationUsage) Summary() string {
+	if len(gu.Attempts) == 0 {
+		return "LLM usage: no requests made"
+	}
+	total := gu.TotalTokens()
+	attempts := "1 attempt"
+	if len(gu.Attempts) > 1 {
+		attempts = fmt.Sprintf("%d attempts", len(gu.Attempts))
+	}
+	cost := fmt.Sprintf("est. cost $%.4f", gu.TotalCostUSD())
+	if !gu.FullyPriced() {
+		cost = fmt.Sprintf("cost unknown for model %s", total.Model)
+	}
+	return fmt.Sprintf("LLM usage: %d tokens (%d in / %d out), %s, %s",
+		total.TotalTokens, total.PromptTokens, total.CompletionTokens, attempts, cost)
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"heavybagworkout/internal/config"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPriceTable_Lookup(t *testing.T) {
+	table := DefaultPriceTable()
+
+	tests := []struct {
+		name      string
+		model     string
+		wantFound bool
+		wantInput float64
+	}{
+		{name: "exact match", model: "gpt-4.1-nano", wantFound: true, wantInput: 0.10},
+		{name: "dated snapshot", model: "gpt-4.1-nano-2025-04-14", wantFound: true, wantInput: 0.10},
+		{name: "longest prefix wins", model: "gpt-4o-mini-2024-07-18", wantFound: true, wantInput: 0.15},
+		{name: "unknown model", model: "some-other-model", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, ok := table.Lookup(tt.model)
+			if ok != tt.wantFound {
+				t.Fatalf("Lookup(%s) found = %v, want %v", tt.model, ok, tt.wantFound)
+			}
+			if ok && price.InputPerMillionUSD != tt.wantInput {
+				t.Errorf("Lookup(%s) input price = %v, want %v", tt.model, price.InputPerMillionUSD, tt.wantInput)
+			}
+		})
+	}
+}
+
+func TestPriceTable_EstimateCost(t *testing.T) {
+	table := PriceTable{"test-model": {InputPerMillionUSD: 1.0, OutputPerMillionUSD: 4.0}}
+
+	cost, ok := table.EstimateCost(TokenUsage{Model: "test-model", PromptTokens: 500_000, CompletionTokens: 250_000})
+	if !ok {
+		t.Fatalf("expected model to be priced")
+	}
+	if math.Abs(cost-1.5) > 1e-9 {
+		t.Errorf("expected cost 1.5, got %v", cost)
+	}
+
+	if _, ok := table.EstimateCost(TokenUsage{Model: "unknown"}); ok {
+		t.Errorf("expected unknown model to be unpriced")
+	}
+}
+
+func TestPriceTableFromConfig_OverridesDefaults(t *testing.T) {
+	table := PriceTableFromConfig(map[string]config.ModelPricing{
+		"gpt-4.1-nano": {InputPerMillionUSD: 1, OutputPerMillionUSD: 2},
+		"local-model":  {InputPerMillionUSD: 0, OutputPerMillionUSD: 0},
+	})
+
+	if price := table["gpt-4.1-nano"]; price.InputPerMillionUSD != 1 || price.OutputPerMillionUSD != 2 {
+		t.Errorf("expected override for gpt-4.1-nano, got %+v", price)
+	}
+	if _, ok := table["local-model"]; !ok {
+		t.Errorf("expected new model from config to be added")
+	}
+	if _, ok := table["gpt-4o"]; !ok {
+		t.Errorf("expected default entries to be kept")
+	}
+}
+
+func TestGenerationUsage_Summary(t *testing.T) {
+	usage := GenerationUsage{Attempts: []AttemptUsage{
+		{TokenUsage: TokenUsage{Model: "gpt-4.1-nano", PromptTokens: 1000, CompletionTokens: 200, TotalTokens: 1200}, CostUSD: 0.00018, Priced: true, Error: "bad json"},
+		{TokenUsage: TokenUsage{Model: "gpt-4.1-nano", PromptTokens: 1100, CompletionTokens: 250, TotalTokens: 1350}, CostUSD: 0.00021, Priced: true},
+	}}
+
+	summary := usage.Summary()
+	for _, want := range []string{"2550 tokens", "2100 in / 450 out", "2 attempts", "est. cost $0.0004"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("expected summary to contain %q, got %q", want, summary)
+		}
+	}
+
+	usage.Attempts[1].Priced = false
+	if summary := usage.Summary(); !strings.Contains(summary, "cost unknown") {
+		t.Errorf("expected unpriced summary to say cost unknown, got %q", summary)
+	}
+}
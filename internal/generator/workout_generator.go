@@ -65,6 +65,7 @@ func (wg *WorkoutGenerator) GenerateWorkout(config models.WorkoutConfig, pattern
 
 		combo := wg.distributeCombosAcrossWorkPeriod(comboGen, roundNumber, config, pattern, previousMoveCount)
 		round := models.NewWorkoutRound(roundNumber, combo, config.WorkDuration, config.RestDuration)
+		round.Cue = CoachingCueForCombo(combo, roundNumber)
 		rounds = append(rounds, round)
 	}
 
@@ -1,5 +1,7 @@
 package generator
 
+import "heavybagworkout/internal/config"
+
 // WorkoutResponseJSON represents the JSON structure returned by OpenAI
 type WorkoutResponseJSON struct {
 	Rounds []RoundResponseJSON `json:"rounds"`
@@ -9,6 +11,7 @@ type WorkoutResponseJSON struct {
 type RoundResponseJSON struct {
 	RoundNumber int       `json:"round_number"`
 	Combo       ComboJSON `json:"combo"`
+	Cue         string    `json:"cue,omitempty"` // Optional short coaching cue for the round
 }
 
 // ComboJSON represents a combo in the JSON response
@@ -16,3 +19,13 @@ type RoundResponseJSON struct {
 type ComboJSON struct {
 	Moves []int `json:"moves"`
 }
+
+// WorkoutPlanResponseJSON represents a full workout plan proposed by OpenAI from a natural-language goal
+type WorkoutPlanResponseJSON struct {
+	Workout config.WorkoutConfig `json:"workout"`
+	Pattern config.PatternConfig `json:"pattern"`
+	Tempo   string               `json:"tempo"`
+	Stance  string               `json:"stance"`
+	Notes   string               `json:"notes,omitempty"`
+	Rounds  []RoundResponseJSON  `json:"rounds"`
+}
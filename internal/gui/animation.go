@@ -180,11 +180,7 @@ func (cs *CharacterSprite) createPunchAnimation(moveName string, state Animation
 		frameDuration = cs.timePerMove
 	} else {
 		// Fallback: use tempo-based calculation
-		tempoDuration := cs.tempo.Duration()
-		if cs.tempo == models.TempoSuperfast {
-			tempoDuration = 1 * time.Second // Override to 1 second for superfast
-		}
-		frameDuration = tempoDuration / 2 // Complete punch in half the tempo interval
+		frameDuration = cs.tempo.Duration() / 2 // Complete punch in half the tempo interval
 	}
 
 	// Try to load sprite image based on stance
@@ -230,11 +226,7 @@ func (cs *CharacterSprite) createDefensiveAnimation(moveName string, state Anima
 		frameDuration = cs.timePerMove
 	} else {
 		// Fallback: use tempo-based calculation
-		tempoDuration := cs.tempo.Duration()
-		if cs.tempo == models.TempoSuperfast {
-			tempoDuration = 1 * time.Second
-		}
-		frameDuration = tempoDuration / 2 // Complete defensive move in half the tempo interval
+		frameDuration = cs.tempo.Duration() / 2 // Complete defensive move in half the tempo interval
 	}
 
 	// Try to load sprite image based on stance
@@ -1,215 +1,107 @@
 package gui
 
 import (
-	"heavybagworkout/internal/models"
-	"heavybagworkout/internal/types"
+	"heavybagworkout/internal/timer"
 	"time"
-)
-
-// startAnimationSequence starts the timer-based animation sequence for a combo
-// This is called when a beep plays during work period
-func (a *App) startAnimationSequence() {
-	// Check if we're still in the workout period
-	// If not, switch to rest period
-	if a.currentPeriod == types.PeriodWork {
-		elapsed := time.Since(a.workoutStartTime)
-		if elapsed >= a.workoutPeriodDuration {
-			// Workout period has ended, switch to rest
-			a.currentPeriod = types.PeriodRest
-			a.startRestPeriodAnimation()
-			return
-		}
-	}
-
-	// Only start animation sequence during work period
-	if a.currentPeriod != types.PeriodWork {
-		return
-	}
-
-	if a.currentCombo.IsEmpty() || len(a.currentCombo.Moves) == 0 {
-		return
-	}
-
-	// Stop any existing animation timer
-	a.stopAnimationSequence()
-
-	// Get time per move based on tempo
-	timePerMove := a.getTimePerMove()
-
-	// Get tempo interval (time between beeps)
-	tempoInterval := a.selectedTempo.Duration()
-	if a.selectedTempo == models.TempoSuperfast {
-		tempoInterval = 1 * time.Second
-	}
 
-	// Calculate total combo time
-	totalComboTime := time.Duration(len(a.currentCombo.Moves)) * timePerMove
+	"gioui.org/layout"
+	"gioui.org/op"
+)
 
-	// Calculate idle duration (remainder until next beep)
-	idleDuration := tempoInterval - totalComboTime
-	if idleDuration < 0 {
-		idleDuration = 0 // Safety check
-	}
+// beatBufferSize is how many events the animation can fall behind by before the workout waits for it
+const beatBufferSize = 16
 
-	// Set up animation sequence goroutine
-	a.animationTimerStop = make(chan struct{})
-	a.currentMoveIndex = 0
+// goIndicatorDuration is how long the "go!" indicator shows for each move
+const goIndicatorDuration = 500 * time.Millisecond
 
-	// Play beep at the start of the combo sequence
-	if a.audioHandler != nil {
-		a.audioHandler.PlayBeep()
+// followBeats plays the tempo click on each of the workout timer's beats and queues the beats for the UI goroutine
+// to animate, until the subscription is closed. Run it on its own goroutine.
+func (a *App) followBeats(sub *timer.Subscription, audioHandler timer.AudioCueHandler) {
+	for event := range sub.Events() {
+		switch event.Type {
+		case timer.EventBeat, timer.EventMove, timer.EventComboDone:
+			if event.Type == timer.EventBeat && audioHandler != nil {
+				timer.PlayTempoBeat(audioHandler)
+			}
+			a.queueBeat(event)
+		}
 	}
-
-	// Start with first move
-	a.startNextMove(timePerMove, tempoInterval, idleDuration)
 }
 
-// startNextMove starts the timer for the current move in the combo
-func (a *App) startNextMove(timePerMove, tempoInterval, idleDuration time.Duration) {
-	if a.currentPeriod != types.PeriodWork {
-		return
-	}
-
-	if a.currentMoveIndex >= len(a.currentCombo.Moves) {
-		// All moves completed, start idle animation
-		a.startIdleAnimation(idleDuration, tempoInterval)
-		return
+// queueBeat queues a beat for animateBeats and asks for a frame to animate it
+func (a *App) queueBeat(event timer.Event) {
+	a.beatsMu.Lock()
+	a.pendingBeats = append(a.pendingBeats, event)
+	a.beatsMu.Unlock()
+	if a.window != nil {
+		a.window.Invalidate()
 	}
+}
 
-	// Get the current move
-	move := a.currentCombo.Moves[a.currentMoveIndex]
-	animationState := a.getAnimationStateForMove(move)
-
-	// Set the animation
-	if a.characterSprite != nil {
-		a.characterSprite.SetAnimation(animationState)
-	}
+// animateBeats animates the beats queued since the last frame and hides the "go!" indicator once its time is up.
+// Layout calls it on the UI goroutine, which owns the animation state.
+func (a *App) animateBeats(gtx layout.Context) {
+	a.beatsMu.Lock()
+	beats := a.pendingBeats
+	a.pendingBeats = nil
+	a.beatsMu.Unlock()
 
-	// Show "go!" indicator
-	a.showGo = true
-	if a.window != nil {
-		a.window.Invalidate()
+	for _, event := range beats {
+		a.onBeat(event)
 	}
 
-	// Hide "go!" after 500ms
-	go func() {
-		time.Sleep(500 * time.Millisecond)
-		if a.currentPeriod == types.PeriodWork {
+	if a.showGo {
+		left := a.goUntil.Sub(a.clock.Now())
+		if left <= 0 {
 			a.showGo = false
-			if a.window != nil {
-				a.window.Invalidate()
-			}
+		} else {
+			gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(left)})
 		}
-	}()
-
-	// Create timer for this move
-	a.animationTimer = time.AfterFunc(timePerMove, func() {
-		select {
-		case <-a.animationTimerStop:
-			return // Sequence was stopped
-		default:
-			// Move to next move
-			a.currentMoveIndex++
-			a.startNextMove(timePerMove, tempoInterval, idleDuration)
-		}
-	})
+	}
 }
 
-// startIdleAnimation starts the idle animation timer after combo completes
-func (a *App) startIdleAnimation(idleDuration, tempoInterval time.Duration) {
-	if a.currentPeriod != types.PeriodWork {
+// onBeat animates each move of the combo, then idles until the next beat
+func (a *App) onBeat(event timer.Event) {
+	a.currentMoveIndex = event.MoveIndex
+	if event.Type == timer.EventComboDone || event.MoveIndex >= len(event.Combo.Moves) {
+		// All moves completed, idle until the next beat
+		if a.characterSprite != nil {
+			a.characterSprite.SetAnimation(AnimationStateIdle)
+		}
 		return
 	}
 
-	// Set to idle animation
+	move := event.Combo.Moves[event.MoveIndex]
 	if a.characterSprite != nil {
-		a.characterSprite.SetAnimation(AnimationStateIdle)
-	}
-
-	if a.window != nil {
-		a.window.Invalidate()
+		a.characterSprite.SetAnimation(a.getAnimationStateForMove(move))
 	}
 
-	// If there's idle time, create timer for it
-	if idleDuration > 0 {
-		a.animationTimer = time.AfterFunc(idleDuration, func() {
-			select {
-			case <-a.animationTimerStop:
-				return // Sequence was stopped
-			default:
-				// Idle completed, play beep and start next combo
-				// Beep plays at the start of the next combo sequence
-				a.startAnimationSequence()
-			}
-		})
-	} else {
-		// No idle time, immediately start next combo (beep will play at start)
-		a.startAnimationSequence()
-	}
+	// Show "go!" indicator for a moment
+	a.showGo = true
+	a.goUntil = a.clock.Now().Add(goIndicatorDuration)
 }
 
 // startRestPeriodAnimation starts the rest period animation
 func (a *App) startRestPeriodAnimation() {
-	// Stop any existing animation sequence
-	a.stopAnimationSequence()
+	a.showGo = false
 
 	// Set to idle animation (non-looping)
 	if a.characterSprite != nil {
 		a.characterSprite.SetAnimation(AnimationStateIdle)
 	}
 
-	// Get rest duration from current round
-	if a.currentRound > 0 && a.currentRound <= len(a.workout.Rounds) {
-		round := a.workout.Rounds[a.currentRound-1]
-		restDuration := round.RestDuration
-
-		// Create timer for rest period
-		a.animationTimerStop = make(chan struct{})
-		a.animationTimer = time.AfterFunc(restDuration, func() {
-			select {
-			case <-a.animationTimerStop:
-				return // Rest period was stopped
-			default:
-				// Rest period completed - timer will call OnPeriodStart for next work period
-				// Animation will be updated by OnPeriodStart
-			}
-		})
-	}
-
 	if a.window != nil {
 		a.window.Invalidate()
 	}
 }
 
-// stopAnimationSequence stops the current animation sequence
-func (a *App) stopAnimationSequence() {
-	if a.animationTimer != nil {
-		a.animationTimer.Stop()
-		a.animationTimer = nil
-	}
-	if a.animationTimerStop != nil {
-		// Signal stop without closing (channel will be recreated on next start)
-		select {
-		case a.animationTimerStop <- struct{}{}:
-		default:
-			// Channel already has a value or is closed, ignore
-		}
-		a.animationTimerStop = nil
-	}
-}
-
-// getTimePerMove returns the time per move based on tempo
-func (a *App) getTimePerMove() time.Duration {
-	switch a.selectedTempo {
-	case models.TempoSlow:
-		return 400 * time.Millisecond
-	case models.TempoMedium:
-		return 400 * time.Millisecond
-	case models.TempoFast:
-		return 400 * time.Millisecond
-	case models.TempoSuperfast:
-		return 400 * time.Millisecond
-	default:
-		return 400 * time.Millisecond
+// stopFollowingBeats ends the current workout's beat subscription and drops the beats not animated yet
+func (a *App) stopFollowingBeats() {
+	if a.beats != nil {
+		a.beats.Unsubscribe()
+		a.beats = nil
 	}
+	a.beatsMu.Lock()
+	a.pendingBeats = nil
+	a.beatsMu.Unlock()
 }
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"heavybagworkout/internal/config"
 	"heavybagworkout/internal/generator"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
 	"heavybagworkout/internal/timer"
 	"heavybagworkout/internal/types"
 	"image"
@@ -12,7 +14,10 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"gioui.org/layout"
 	"gioui.org/op/clip"
@@ -23,6 +28,12 @@ import (
 	"gioui.org/widget/material"
 )
 
+// progressTickInterval is how often the workout timer sends fine updates for the progress bar
+const progressTickInterval = 100 * time.Millisecond
+
+// addTimeStep is how much time the add-time button adds to the current period
+const addTimeStep = 30 * time.Second
+
 // App represents the main GUI application
 type App struct {
 	theme *material.Theme
@@ -57,12 +68,53 @@ type App struct {
 	tempoOptions      []widget.Clickable
 	selectedTempo     models.Tempo
 
+	// Rest mode dropdown
+	restModeDropdownOpen bool
+	restModeButton       widget.Clickable
+	restModeOptions      []widget.Clickable
+	selectedRestMode     models.RestMode
+
+	// Language dropdown
+	languageDropdownOpen bool
+	languageButton       widget.Clickable
+	languageOptions      []widget.Clickable
+	selectedLanguage     string
+
+	// Callout style dropdown
+	calloutStyleDropdownOpen bool
+	calloutStyleButton       widget.Clickable
+	calloutStyleOptions      []widget.Clickable
+	selectedCalloutStyle     models.CalloutStyle
+
+	// Cue profile dropdown
+	cueProfileDropdownOpen bool
+	cueProfileButton       widget.Clickable
+	cueProfileOptions      []widget.Clickable
+	selectedCueProfile     string
+	cueProfiles            []config.CueProfileConfig // User-defined profiles (carried over from loaded config)
+
 	// LLM generation checkbox
 	useLLM widget.Bool
 
 	// OpenAI API key field
 	openAIAPIKeyEditor widget.Editor
 
+	// Natural-language goal for LLM-planned workouts
+	goalEditor         widget.Editor
+	planFromGoalButton widget.Clickable
+
+	// LLM pricing overrides, monthly budget, prompt template path and API base URL (carried over from loaded config, not editable in the form)
+	llmPricing          map[string]config.ModelPricing
+	llmMonthlyBudgetUSD float64
+	llmPromptTemplate   string
+	llmBaseURL          string
+
+	// Background music, text-to-speech settings and sample pack directory (carried over from loaded config, not
+	// editable in the form)
+	music      config.MusicConfig
+	tts        config.TTSConfig
+	samplePack string
+
 	// Preset dropdown
 	presetDropdownOpen bool
 	presetButton       widget.Clickable
@@ -94,23 +146,36 @@ type App struct {
 	// Timer state (will be updated by timer callbacks)
 	currentPeriod types.PeriodType // Current period (Work/Rest)
 	remainingTime time.Duration    // Remaining time in current period
+	fineRemaining time.Duration    // Remaining time in current period from fine updates, for the progress bar
+	elapsedTime   time.Duration    // Time rested so far in an open-ended (auto rest) period
+	openPeriod    bool             // The current period lasts until Ready, so the timer counts up
 
 	// Current combo state (will be updated by timer callbacks)
 	currentCombo models.Combo // Current combo for the active round
+	currentCue   string       // Coaching cue for the active round (may be empty)
 	showGo       bool         // Show "go!" indicator when combo should be performed (at tempo intervals during work period)
 
-	// Timer-based animation sequence state
-	animationTimer        *time.Timer   // Current animation timer (for move or idle)
-	animationTimerStop    chan struct{} // Channel to stop the animation sequence goroutine
-	workoutStartTime      time.Time     // When the current workout period started (for period duration check)
-	workoutPeriodDuration time.Duration // Duration of the current workout period
-	currentMoveIndex      int           // Current move index in the combo (0 = first move)
+	// Beat-driven animation state
+	beats            *timer.Subscription // The workout timer's tempo beats, nil outside a workout
+	beatsMu          sync.Mutex          // Guards pendingBeats
+	pendingBeats     []timer.Event       // Beats received from the workout timer, animated by Layout on the UI goroutine
+	currentMoveIndex int                 // Current move index in the combo (0 = first move)
+	goUntil          time.Time           // When the "go!" indicator hides
 
 	// Workout control state
 	isPaused       bool             // Whether the workout is paused
 	pauseResumeBtn widget.Clickable // Pause/Resume button
 	stopBtn        widget.Clickable // Stop/Quit button
 
+	// Session controls
+	skipRestBtn     widget.Clickable // Skip the rest of the rest period
+	repeatRoundBtn  widget.Clickable // Restart the current round
+	addTimeBtn      widget.Clickable // Add 30 seconds to the current period
+	jumpRoundBtn    widget.Clickable // Jump to the round in jumpRoundEditor
+	readyBtn        widget.Clickable // End an auto rest period
+	doneBtn         widget.Clickable // End a work period early, banking the time left as rest
+	jumpRoundEditor widget.Editor    // Round number to jump to
+
 	// Completion screen controls
 	completionDoneBtn widget.Clickable // Button to return to form from completion screen
 
@@ -118,12 +183,22 @@ type App struct {
 	confirmWorkoutBtn widget.Clickable // Button to confirm and start workout
 	backToFormBtn     widget.Clickable // Button to go back to form from preview
 
+	// Checkpoints of unfinished workouts
+	checkpoints          *timer.CheckpointStore // nil disables checkpointing
+	sessions             *timer.SessionLog
+	pendingCheckpoint    *timer.Checkpoint // Unfinished workout offered for resuming on the form
+	resumeCheckpointBtn  widget.Clickable  // Resume the unfinished workout
+	logCheckpointBtn     widget.Clickable  // Log the unfinished workout as partially completed
+	discardCheckpointBtn widget.Clickable  // Discard the unfinished workout
+
 	// Generated workout (stored after generation, used by timer)
 	workout models.Workout
 
 	// Workout timer (Task 58)
 	workoutTimer *timer.WorkoutTimer
-	audioHandler timer.AudioCueHandler // Audio handler for tempo-based beeps
+	audioHandler timer.AudioCueHandler // Audio handler of the workout in progress
+	mixer        *synth.Mixer          // Plays the workout's music and cues, nil without music
+	clock        timer.Clock           // Drives the workout timer and the animation sequence
 
 	// Window reference for invalidating frames (needed for timer updates)
 	window interface {
@@ -141,11 +216,16 @@ type App struct {
 // NewApp creates a new GUI application instance
 func NewApp() *App {
 	app := &App{
-		theme:           material.NewTheme(),
-		selectedPattern: models.PatternLinear,
-		selectedStance:  models.Orthodox,
-		selectedTempo:   models.TempoSlow,
-		currentPeriod:   types.PeriodWork,
+		theme:            material.NewTheme(),
+		selectedPattern:  models.PatternLinear,
+		selectedStance:   models.Orthodox,
+		selectedTempo:    models.TempoSlow,
+		selectedRestMode: models.RestFixed,
+		selectedLanguage: i18n.DefaultLanguage,
+
+		selectedCalloutStyle: models.CalloutFull,
+		currentPeriod:        types.PeriodWork,
+		clock:                timer.RealClock(),
 	}
 
 	// Initialize editors with single-line mode
@@ -159,6 +239,7 @@ func NewApp() *App {
 	app.minMovesEditor.Submit = true
 	app.maxMovesEditor.SingleLine = true
 	app.maxMovesEditor.Submit = true
+	app.jumpRoundEditor.SingleLine = true
 	app.openAIAPIKeyEditor.SingleLine = true
 	app.openAIAPIKeyEditor.Submit = true
 	app.openAIAPIKeyEditor.Mask = '*' // Mask the API key input
@@ -181,6 +262,15 @@ func NewApp() *App {
 	// Initialize tempo options clickables
 	app.tempoOptions = make([]widget.Clickable, 4)
 
+	// Initialize rest mode options clickables
+	app.restModeOptions = make([]widget.Clickable, len(models.AllRestModes()))
+
+	// Initialize language options clickables
+	app.languageOptions = make([]widget.Clickable, len(i18n.Languages()))
+
+	// Initialize callout style options clickables
+	app.calloutStyleOptions = make([]widget.Clickable, len(models.AllCalloutStyles()))
+
 	// Initialize preset options clickables (3 presets + 1 for "Custom")
 	app.presetOptions = make([]widget.Clickable, 4)
 
@@ -194,6 +284,38 @@ func NewApp() *App {
 	return app
 }
 
+// SetCheckpointStore checkpoints workouts as they run and offers to resume the one left in the store, if any.
+// Workouts given up on are recorded in the session log.
+func (a *App) SetCheckpointStore(store *timer.CheckpointStore, sessions *timer.SessionLog) {
+	a.checkpoints = store
+	a.sessions = sessions
+	a.loadPendingCheckpoint()
+}
+
+// loadPendingCheckpoint loads the unfinished workout to offer on the form
+func (a *App) loadPendingCheckpoint() {
+	a.pendingCheckpoint = nil
+	if a.checkpoints == nil {
+		return
+	}
+	checkpoint, err := a.checkpoints.Load()
+	if err != nil {
+		a.statusMessage = fmt.Sprintf("Discarded unreadable workout checkpoint: %v", err)
+		a.statusError = true
+		a.checkpoints.Clear()
+		return
+	}
+	a.pendingCheckpoint = checkpoint
+}
+
+// Close stops the workout in progress, keeping its checkpoint so it can be resumed on the next launch
+func (a *App) Close() {
+	if a.workoutTimer != nil {
+		a.workoutTimer.Stop()
+	}
+	a.stopMusic()
+}
+
 // SetWindow sets the window reference for invalidating frames
 func (a *App) SetWindow(window interface{ Invalidate() }) {
 	a.window = window
@@ -206,6 +328,8 @@ func (a *App) Layout(gtx layout.Context) layout.Dimensions {
 	// 2. Timer callbacks call window.Invalidate() when state changes
 	// This ensures the display updates smoothly without excessive invalidations
 
+	a.animateBeats(gtx)
+
 	// Choose layout based on current state
 	if a.showCompletion {
 		return a.layoutCompletionScreen(gtx)
@@ -257,6 +381,9 @@ func (a *App) layoutForm(gtx layout.Context) layout.Dimensions {
 					return layout.Spacer{Height: unit.Dp(20)}.Layout(gtx)
 				}),
 
+				// Unfinished workout, if any
+				layout.Rigid(a.layoutResumePanel),
+
 				// Preset dropdown
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return a.layoutPresetDropdown(gtx)
@@ -357,6 +484,46 @@ func (a *App) layoutForm(gtx layout.Context) layout.Dimensions {
 					return layout.Spacer{Height: unit.Dp(10)}.Layout(gtx)
 				}),
 
+				// Rest mode dropdown
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.layoutRestModeDropdown(gtx)
+				}),
+
+				// Spacing
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Spacer{Height: unit.Dp(10)}.Layout(gtx)
+				}),
+
+				// Language dropdown
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.layoutLanguageDropdown(gtx)
+				}),
+
+				// Spacing
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Spacer{Height: unit.Dp(10)}.Layout(gtx)
+				}),
+
+				// Callout style dropdown
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.layoutCalloutStyleDropdown(gtx)
+				}),
+
+				// Spacing
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Spacer{Height: unit.Dp(10)}.Layout(gtx)
+				}),
+
+				// Cue profile dropdown
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.layoutCueProfileDropdown(gtx)
+				}),
+
+				// Spacing
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return layout.Spacer{Height: unit.Dp(10)}.Layout(gtx)
+				}),
+
 				// Use LLM generation checkbox
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return a.layoutCheckbox(gtx, "Use LLM generation", &a.useLLM, "useLLM")
@@ -380,6 +547,24 @@ func (a *App) layoutForm(gtx layout.Context) layout.Dimensions {
 						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 							return a.layoutFormFieldWithValidation(gtx, "OpenAI API Key (optional)", &a.openAIAPIKeyEditor, "openAIAPIKey")
 						}),
+						// Spacing
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return layout.Spacer{Height: unit.Dp(10)}.Layout(gtx)
+						}),
+						// Workout goal field
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							return a.layoutFormFieldWithHelp(gtx, "Workout Goal (optional)", &a.goalEditor, "goal")
+						}),
+						// Plan from Goal button
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if a.planFromGoalButton.Clicked(gtx) {
+								a.handlePlanFromGoal()
+							}
+							return layout.Inset{Top: unit.Dp(5)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+								btn := material.Button(a.theme, &a.planFromGoalButton, "Plan from Goal")
+								return btn.Layout(gtx)
+							})
+						}),
 					)
 				}),
 
@@ -442,27 +627,121 @@ func (a *App) layoutForm(gtx layout.Context) layout.Dimensions {
 				}),
 
 				// Status message
-				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					if a.statusMessage != "" {
-						return layout.Inset{
-							Top: unit.Dp(10),
-						}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-							statusColor := color.NRGBA{R: 0, G: 150, B: 0, A: 255} // Green for success
-							if a.statusError {
-								statusColor = color.NRGBA{R: 200, G: 0, B: 0, A: 255} // Red for error
-							}
-							lbl := material.Body2(a.theme, a.statusMessage)
-							lbl.Color = statusColor
-							return lbl.Layout(gtx)
-						})
-					}
-					return layout.Dimensions{}
-				}),
+				layout.Rigid(a.layoutStatusMessage),
 			)
 		})
 	})
 }
 
+// layoutResumePanel offers to resume, log or discard the unfinished workout
+func (a *App) layoutResumePanel(gtx layout.Context) layout.Dimensions {
+	if a.resumeCheckpointBtn.Clicked(gtx) {
+		a.handleResumeCheckpoint()
+	}
+	if a.logCheckpointBtn.Clicked(gtx) {
+		a.handleLogCheckpoint()
+	}
+	if a.discardCheckpointBtn.Clicked(gtx) {
+		a.handleDiscardCheckpoint()
+	}
+	if a.pendingCheckpoint == nil {
+		return layout.Dimensions{}
+	}
+
+	checkpoint := a.pendingCheckpoint
+	return layout.Inset{Bottom: unit.Dp(20)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				msg := fmt.Sprintf("Unfinished workout from %s: %s", checkpoint.SavedAt.Local().Format("Mon Jan 2 15:04"), checkpoint.Summary())
+				return material.Body1(a.theme, msg).Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Spacer{Height: unit.Dp(8)}.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceEnd}.Layout(gtx,
+					layout.Rigid(material.Button(a.theme, &a.resumeCheckpointBtn, "Resume").Layout),
+					layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+					layout.Rigid(material.Button(a.theme, &a.logCheckpointBtn, "Log as Partial").Layout),
+					layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+					layout.Rigid(material.Button(a.theme, &a.discardCheckpointBtn, "Discard").Layout),
+				)
+			}),
+		)
+	})
+}
+
+// handleResumeCheckpoint starts the unfinished workout from where it was left
+func (a *App) handleResumeCheckpoint() {
+	checkpoint := a.pendingCheckpoint
+	if checkpoint == nil {
+		return
+	}
+	a.pendingCheckpoint = nil
+	a.workout = checkpoint.Workout
+	a.selectedStance = checkpoint.Stance
+	a.selectedTempo = tempoForDuration(checkpoint.Tempo)
+	a.startWorkout(checkpoint)
+}
+
+// handleLogCheckpoint records the unfinished workout as partially completed and discards it
+func (a *App) handleLogCheckpoint() {
+	checkpoint := a.pendingCheckpoint
+	if checkpoint == nil {
+		return
+	}
+	if a.sessions != nil {
+		if err := a.sessions.Append(checkpoint.PartialSession(time.Now())); err != nil {
+			a.statusMessage = fmt.Sprintf("Failed to log workout: %v", err)
+			a.statusError = true
+			return
+		}
+	}
+	a.handleDiscardCheckpoint()
+	a.statusMessage = fmt.Sprintf("Logged %d of %d rounds as partially completed", checkpoint.RoundsCompleted(), len(checkpoint.Workout.Rounds))
+	a.statusError = false
+}
+
+// handleDiscardCheckpoint discards the unfinished workout
+func (a *App) handleDiscardCheckpoint() {
+	a.pendingCheckpoint = nil
+	if a.checkpoints == nil {
+		return
+	}
+	if err := a.checkpoints.Clear(); err != nil {
+		a.statusMessage = err.Error()
+		a.statusError = true
+	}
+}
+
+// tempoForDuration returns the tempo with the given beat interval, or TempoSlow if none matches
+func tempoForDuration(d time.Duration) models.Tempo {
+	for _, tempo := range []models.Tempo{models.TempoSlow, models.TempoMedium, models.TempoFast, models.TempoSuperfast} {
+		if tempo.Duration() == d {
+			return tempo
+		}
+	}
+	return models.TempoSlow
+}
+
+// layoutStatusMessage renders the status message line (green for success, red for error)
+func (a *App) layoutStatusMessage(gtx layout.Context) layout.Dimensions {
+	if a.statusMessage == "" {
+		return layout.Dimensions{}
+	}
+	return layout.Inset{
+		Top: unit.Dp(10),
+	}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		statusColor := color.NRGBA{R: 0, G: 150, B: 0, A: 255} // Green for success
+		if a.statusError {
+			statusColor = color.NRGBA{R: 200, G: 0, B: 0, A: 255} // Red for error
+		}
+		lbl := material.Body2(a.theme, a.statusMessage)
+		lbl.Color = statusColor
+		return lbl.Layout(gtx)
+	})
+}
+
 // layoutWorkoutDisplay handles the workout display screen layout
 // This layout structure is ready to be populated with workout data in subsequent tasks
 // Task 56: Add visual feedback for work vs rest periods (different background/colors)
@@ -500,7 +779,7 @@ func (a *App) layoutWorkoutDisplay(gtx layout.Context) layout.Dimensions {
 			}.Layout(gtx,
 				// Title section
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					title := material.H5(a.theme, "Workout in Progress")
+					title := material.H5(a.theme, a.locale().Text("display.in_progress", nil))
 					title.Alignment = text.Middle
 					return title.Layout(gtx)
 				}),
@@ -565,6 +844,11 @@ func (a *App) layoutWorkoutDisplay(gtx layout.Context) layout.Dimensions {
 					return a.layoutComboMoves(gtx)
 				}),
 
+				// Coaching cue for the round (shown during work and rest)
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.layoutCoachingCue(gtx)
+				}),
+
 				// Spacing
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return layout.Spacer{Height: unit.Dp(40)}.Layout(gtx)
@@ -574,6 +858,12 @@ func (a *App) layoutWorkoutDisplay(gtx layout.Context) layout.Dimensions {
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return a.layoutWorkoutControls(gtx)
 				}),
+
+				// Skip, repeat, add time and jump controls
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.layoutSessionControls(gtx)
+				}),
+				layout.Rigid(a.layoutStatusMessage),
 			)
 		})
 	})
@@ -601,7 +891,7 @@ func (a *App) layoutCompletionScreen(gtx layout.Context) layout.Dimensions {
 		}.Layout(gtx,
 			// Completion title
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				title := material.H3(a.theme, "Workout Complete!")
+				title := material.H3(a.theme, a.locale().Text("display.workout_complete", nil))
 				title.Alignment = text.Middle
 				title.Color = color.NRGBA{R: 76, G: 175, B: 80, A: 255} // Green color
 				return title.Layout(gtx)
@@ -616,9 +906,9 @@ func (a *App) layoutCompletionScreen(gtx layout.Context) layout.Dimensions {
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				var message string
 				if a.totalRounds > 0 {
-					message = fmt.Sprintf("Congratulations! You completed all %d rounds.", a.totalRounds)
+					message = a.locale().Plural("display.congratulations", a.totalRounds, nil)
 				} else {
-					message = "Congratulations! You completed the workout."
+					message = a.locale().Text("display.congratulations_workout", nil)
 				}
 				label := material.Body1(a.theme, message)
 				label.Alignment = text.Middle
@@ -633,7 +923,7 @@ func (a *App) layoutCompletionScreen(gtx layout.Context) layout.Dimensions {
 
 			// Done button
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				btn := material.Button(a.theme, &a.completionDoneBtn, "Return to Form")
+				btn := material.Button(a.theme, &a.completionDoneBtn, a.locale().Text("display.return_to_form", nil))
 				btn.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 255}     // White text
 				btn.Background = color.NRGBA{R: 33, G: 150, B: 243, A: 255} // Blue
 				btn.CornerRadius = unit.Dp(4)
@@ -675,7 +965,7 @@ func (a *App) layoutWorkoutPreview(gtx layout.Context) layout.Dimensions {
 			}.Layout(gtx,
 				// Title
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					title := material.H5(a.theme, "Workout Preview")
+					title := material.H5(a.theme, a.locale().Text("display.preview", nil))
 					title.Alignment = text.Middle
 					return title.Layout(gtx)
 				}),
@@ -719,7 +1009,7 @@ func (a *App) layoutWorkoutPreview(gtx layout.Context) layout.Dimensions {
 								Right: unit.Dp(10),
 							}
 							return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								btn := material.Button(a.theme, &a.backToFormBtn, "Back to Form")
+								btn := material.Button(a.theme, &a.backToFormBtn, a.locale().Text("display.back_to_form", nil))
 								btn.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
 								btn.Background = color.NRGBA{R: 158, G: 158, B: 158, A: 255} // Gray
 								btn.CornerRadius = unit.Dp(4)
@@ -739,7 +1029,7 @@ func (a *App) layoutWorkoutPreview(gtx layout.Context) layout.Dimensions {
 								Right: unit.Dp(10),
 							}
 							return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-								btn := material.Button(a.theme, &a.confirmWorkoutBtn, "Confirm and Start")
+								btn := material.Button(a.theme, &a.confirmWorkoutBtn, a.locale().Text("display.confirm_start", nil))
 								btn.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
 								btn.Background = color.NRGBA{R: 76, G: 175, B: 80, A: 255} // Green
 								btn.CornerRadius = unit.Dp(4)
@@ -748,6 +1038,9 @@ func (a *App) layoutWorkoutPreview(gtx layout.Context) layout.Dimensions {
 						}),
 					)
 				}),
+
+				// Status message (e.g. LLM token usage and estimated cost)
+				layout.Rigid(a.layoutStatusMessage),
 			)
 		})
 	})
@@ -769,10 +1062,11 @@ func (a *App) layoutWorkoutSummary(gtx layout.Context) layout.Dimensions {
 			minutes := int(totalDuration.Minutes())
 			seconds := int(totalDuration.Seconds()) % 60
 
-			summaryText = fmt.Sprintf("Total Rounds: %d | Work: %ds | Rest: %ds | Total Time: %dm %ds",
-				len(a.workout.Rounds), workSec, restSec, minutes, seconds)
+			summaryText = a.locale().Text("display.summary", i18n.Vars{
+				"rounds": len(a.workout.Rounds), "work": workSec, "rest": restSec, "minutes": minutes, "seconds": seconds,
+			})
 		} else {
-			summaryText = "No workout data"
+			summaryText = a.locale().Text("display.no_workout", nil)
 		}
 
 		label := material.Body1(a.theme, summaryText)
@@ -810,7 +1104,7 @@ func (a *App) layoutWorkoutRoundsList(gtx layout.Context) layout.Dimensions {
 			}.Layout(gtx,
 				// Round header
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-					roundHeader := material.H6(a.theme, fmt.Sprintf("Round %d:", round.RoundNumber))
+					roundHeader := material.H6(a.theme, a.locale().Text("display.round", i18n.Vars{"round": round.RoundNumber})+":")
 					roundHeader.Color = color.NRGBA{R: 33, G: 150, B: 243, A: 255} // Blue
 					return roundHeader.Layout(gtx)
 				}),
@@ -819,7 +1113,7 @@ func (a *App) layoutWorkoutRoundsList(gtx layout.Context) layout.Dimensions {
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					comboText := a.formatComboWithStance(round.Combo, a.selectedStance)
 					if comboText == "" {
-						comboText = "No moves"
+						comboText = a.locale().Text("display.no_moves", nil)
 					}
 					comboLabel := material.Body2(a.theme, "  "+comboText)
 					comboLabel.Color = color.NRGBA{R: 60, G: 60, B: 60, A: 255}
@@ -830,6 +1124,20 @@ func (a *App) layoutWorkoutRoundsList(gtx layout.Context) layout.Dimensions {
 					return inset.Layout(gtx, comboLabel.Layout)
 				}),
 
+				// Coaching cue (if any)
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					if round.Cue == "" {
+						return layout.Dimensions{}
+					}
+					cueLabel := material.Caption(a.theme, "  "+capitalize(a.locale().Text("display.coach", nil))+": "+a.locale().Cue(round.Cue))
+					cueLabel.Color = color.NRGBA{R: 120, G: 120, B: 120, A: 255}
+					inset := layout.Inset{
+						Left: unit.Dp(20),
+						Top:  unit.Dp(2),
+					}
+					return inset.Layout(gtx, cueLabel.Layout)
+				}),
+
 				// Spacing between rounds
 				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 					return layout.Spacer{Height: unit.Dp(12)}.Layout(gtx)
@@ -841,6 +1149,11 @@ func (a *App) layoutWorkoutRoundsList(gtx layout.Context) layout.Dimensions {
 
 // handleConfirmWorkout confirms the workout and starts it
 func (a *App) handleConfirmWorkout() {
+	a.startWorkout(nil)
+}
+
+// startWorkout shows the workout display and starts the workout, from the checkpoint if one is given
+func (a *App) startWorkout(resume *timer.Checkpoint) {
 	// PRIORITY: Switch to workout display FIRST to ensure UI appears before audio starts
 	a.showWorkoutPreview = false
 	a.showWorkoutDisplay = true
@@ -854,33 +1167,66 @@ func (a *App) handleConfirmWorkout() {
 	// Create workout timer
 	a.workoutTimer = timer.NewWorkoutTimer(a.workout)
 	a.workoutTimer.SetStance(a.selectedStance)
+	a.workoutTimer.SetClock(a.clock)
+	a.workoutTimer.SetFineTickInterval(progressTickInterval)
+	a.workoutTimer.SetTempo(a.selectedTempo.Duration())
+	a.workoutTimer.SetAudioPreRoll(timer.DefaultAudioPreRoll)
+	a.workoutTimer.SetRestMode(a.selectedRestMode)
+	if resume != nil {
+		a.workoutTimer.SetTempo(resume.Tempo)
+	}
+	if a.checkpoints != nil {
+		a.workoutTimer.SetCheckpointStore(a.checkpoints)
+	}
 
 	// Update character sprite stance and tempo (Tasks 32-34, 54)
 	if a.characterSprite != nil {
 		a.characterSprite.SetStance(a.selectedStance)
 		a.characterSprite.SetTempo(a.selectedTempo)
+		a.characterSprite.SetTimePerMove(timer.DefaultMoveInterval)
 	}
 
 	// Set display handler (App implements TimerDisplayHandler)
 	a.workoutTimer.SetDisplayHandler(a)
 
-	// Set audio handler (default audio cues)
-	audioHandler := timer.NewDefaultAudioCueHandler(true)
-	a.audioHandler = audioHandler // Store for tempo ticker
+	// Set audio handler (default audio cues, or the sample pack's callouts)
+	speakers, audioHandler := a.newAudioHandler()
+	if tts := a.ttsEngine(); tts != nil {
+		speakers.SetTTSEngine(tts)
+	}
+	speakers.SetLocale(a.locale())
+	speakers.SetCalloutStyle(a.selectedCalloutStyle, a.selectedTempo.Duration())
+	a.workoutTimer.SetLocale(a.locale())
+	a.workoutTimer.SetCalloutStyle(a.selectedCalloutStyle)
+	profile := a.cueProfile()
+	a.workoutTimer.SetCueProfile(&profile)
+	a.startMusic(speakers)
+	a.audioHandler = audioHandler // Store to stop the cues with the workout
 	a.workoutTimer.SetAudioHandler(audioHandler)
 
+	// Animate the combo on the tempo beats
+	a.stopFollowingBeats()
+	a.beats = a.workoutTimer.Events().SubscribeBlocking(beatBufferSize)
+	go a.followBeats(a.beats, audioHandler)
+
 	// Set workout completion callback
 	a.workoutTimer.OnWorkoutComplete(func() {
 		a.handleWorkoutComplete()
 	})
 
 	// Start the timer in a goroutine with a small delay to allow UI to render first
+	workoutTimer := a.workoutTimer
+	start := workoutTimer.Start
+	if resume != nil {
+		checkpoint := *resume
+		start = func() error { return workoutTimer.StartFrom(checkpoint) }
+	}
 	go func() {
 		// Small delay to ensure window has time to redraw
 		time.Sleep(50 * time.Millisecond)
 
 		// Start the timer (this will trigger audio callbacks)
-		if err := a.workoutTimer.Start(); err != nil {
+		if err := start(); err != nil {
 			// Handle error - we need to update UI from main thread
 			// For now, we'll just log it since we're in a goroutine
 			// In a production app, you'd want to use a channel or similar to communicate back
@@ -889,6 +1235,64 @@ func (a *App) handleConfirmWorkout() {
 	}()
 }
 
+// newAudioHandler creates the workout's audio handler, which plays callouts from the sample pack if there is one,
+// and the default handler under it that plays everything else
+func (a *App) newAudioHandler() (*timer.DefaultAudioCueHandler, timer.AudioCueHandler) {
+	if a.samplePack != "" {
+		pack, err := timer.LoadSamplePack(a.samplePack)
+		if err == nil {
+			fileHandler := timer.NewFileAudioCueHandler(true, pack)
+			return fileHandler.DefaultAudioCueHandler, fileHandler
+		}
+		fmt.Fprintf(os.Stderr, "Warning: failed to load sample pack: %v, callouts will be spoken\n", err)
+	}
+	handler := timer.NewDefaultAudioCueHandler(true)
+	return handler, handler
+}
+
+// ttsEngine returns the configured text-to-speech engine for the selected language, nil if it isn't available
+func (a *App) ttsEngine() timer.TTSEngine {
+	settings := timer.TTSSettings{
+		Language:     a.locale().Language,
+		Voice:        a.tts.Voice,
+		Rate:         a.tts.Rate,
+		Volume:       a.tts.Volume,
+		SpeakCommand: a.tts.SpeakCommand,
+		FileCommand:  a.tts.FileCommand,
+	}
+	tts, _, err := timer.SelectTTSEngine(a.tts.Engine, settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using the default speech engine\n", err)
+		return nil
+	}
+	return tts
+}
+
+// startMusic plays the configured music under the workout's cues, if there is any
+func (a *App) startMusic(audioHandler *timer.DefaultAudioCueHandler) {
+	a.stopMusic()
+	if a.music.Folder == "" {
+		return
+	}
+	mixer, _, err := synth.StartMusic(a.music.Folder)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, playing without music\n", err)
+		return
+	}
+	mixer.SetDuckLevel(a.music.GetDuckLevel())
+	rest, _ := timer.ParseMusicRest(a.music.Rest) // Checked with the config
+	audioHandler.SetMusic(mixer, a.music.GetVolume(), rest)
+	a.mixer = mixer
+}
+
+// stopMusic stops the workout's music
+func (a *App) stopMusic() {
+	if a.mixer != nil {
+		a.mixer.Close()
+		a.mixer = nil
+	}
+}
+
 // handleBackToForm goes back to the form screen
 func (a *App) handleBackToForm() {
 	a.showWorkoutPreview = false
@@ -897,33 +1301,31 @@ func (a *App) handleBackToForm() {
 	// but allow them to go back and adjust settings
 }
 
-// formatComboWithStance formats a combo with stance-specific punch names
+// formatComboWithStance formats a combo with stance-specific punch names in the selected language and callout style
 func (a *App) formatComboWithStance(combo models.Combo, stance models.Stance) string {
-	if combo.IsEmpty() {
-		return ""
+	names := a.locale().StyledMoveNames(combo, stance, a.selectedCalloutStyle)
+	for i, name := range names {
+		names[i] = capitalize(name)
 	}
+	return strings.Join(names, ", ")
+}
 
-	result := ""
-	for i, move := range combo.Moves {
-		if i > 0 {
-			result += ", "
-		}
-
-		if move.IsPunch() && move.Punch != nil {
-			// Use stance-specific name for punches
-			punchName := move.Punch.NameForStance(stance)
-			// Capitalize first letter
-			if len(punchName) > 0 {
-				punchName = strings.ToUpper(string(punchName[0])) + punchName[1:]
-			}
-			result += punchName
-		} else if move.IsDefensive() && move.Defensive != nil {
-			// For defensive moves, show the full name
-			result += move.String()
-		}
+// capitalize returns text with its first letter upper case
+func capitalize(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	if size == 0 {
+		return text
 	}
+	return string(unicode.ToUpper(first)) + text[size:]
+}
 
-	return result
+// locale returns the locale of the selected language
+func (a *App) locale() *i18n.Locale {
+	locale, err := i18n.Load(a.selectedLanguage)
+	if err != nil {
+		return i18n.Default()
+	}
+	return locale
 }
 
 // layoutRoundNumber displays the current round number prominently (Task 23)
@@ -931,13 +1333,13 @@ func (a *App) layoutRoundNumber(gtx layout.Context) layout.Dimensions {
 	var roundText string
 	if a.totalRounds > 0 && a.currentRound > 0 {
 		// Display "Round X of Y" format
-		roundText = fmt.Sprintf("Round %d of %d", a.currentRound, a.totalRounds)
+		roundText = capitalize(a.locale().Text("display.round_of", i18n.Vars{"round": a.currentRound, "total": a.totalRounds}))
 	} else if a.totalRounds > 0 {
 		// Workout ready but not started yet
-		roundText = fmt.Sprintf("Ready - %d rounds", a.totalRounds)
+		roundText = a.locale().Plural("display.ready_rounds", a.totalRounds, nil)
 	} else {
 		// No workout data yet
-		roundText = "Round -"
+		roundText = a.locale().Text("display.round", i18n.Vars{"round": "-"})
 	}
 
 	// Use H2 for prominent display
@@ -947,6 +1349,30 @@ func (a *App) layoutRoundNumber(gtx layout.Context) layout.Dimensions {
 	return label.Layout(gtx)
 }
 
+// roundProgress returns the fraction of the workout completed, including the elapsed part of the current round
+func (a *App) roundProgress() float64 {
+	if a.totalRounds <= 0 {
+		return 0
+	}
+	if a.currentRound > a.totalRounds {
+		return 1
+	}
+
+	completed := float64(a.currentRound - 1)
+	if a.currentRound > 0 && a.currentRound <= len(a.workout.Rounds) {
+		round := a.workout.Rounds[a.currentRound-1]
+		elapsed := round.WorkDuration - a.fineRemaining
+		if a.currentPeriod == types.PeriodRest {
+			elapsed = round.WorkDuration + round.RestDuration - a.fineRemaining
+		}
+		if total := round.WorkDuration + round.RestDuration; total > 0 && elapsed > 0 {
+			completed += min(float64(elapsed)/float64(total), 1)
+		}
+	}
+
+	return max(0, min(completed/float64(a.totalRounds), 1))
+}
+
 // layoutWorkoutProgress displays workout progress with progress bar (Task 24)
 func (a *App) layoutWorkoutProgress(gtx layout.Context) layout.Dimensions {
 	// Calculate rounds completed (current round - 1, since we're currently in a round)
@@ -959,16 +1385,7 @@ func (a *App) layoutWorkoutProgress(gtx layout.Context) layout.Dimensions {
 	}
 
 	// Calculate progress percentage
-	var progress float64
-	if a.totalRounds > 0 {
-		progress = float64(roundsCompleted) / float64(a.totalRounds)
-		if progress > 1.0 {
-			progress = 1.0
-		}
-		if progress < 0.0 {
-			progress = 0.0
-		}
-	}
+	progress := a.roundProgress()
 
 	// Layout progress bar and text in a column
 	return layout.Flex{
@@ -1025,9 +1442,9 @@ func (a *App) layoutWorkoutProgress(gtx layout.Context) layout.Dimensions {
 			var progressText string
 			if a.totalRounds > 0 {
 				progressPercent := int(progress * 100)
-				progressText = fmt.Sprintf("%d%% (%d/%d rounds completed)", progressPercent, roundsCompleted, a.totalRounds)
+				progressText = fmt.Sprintf("%d%% (%s)", progressPercent, a.locale().Plural("display.rounds_completed", a.totalRounds, i18n.Vars{"completed": roundsCompleted}))
 			} else {
-				progressText = "Progress: - / -"
+				progressText = a.locale().Text("display.progress", nil) + ": - / -"
 			}
 
 			label := material.Body1(a.theme, progressText)
@@ -1045,9 +1462,11 @@ func (a *App) layoutWorkoutProgress(gtx layout.Context) layout.Dimensions {
 
 // layoutCountdownTimer displays the countdown timer in MM:SS format (Task 25 placeholder)
 func (a *App) layoutCountdownTimer(gtx layout.Context) layout.Dimensions {
-	// Format remaining time as MM:SS. If no data, show "--:--"
+	// Format remaining time as MM:SS, or the time rested in an auto rest period. If no data, show "--:--"
 	timerText := "--:--"
-	if a.remainingTime > 0 {
+	if a.openPeriod {
+		timerText = formatDurationMMSS(a.elapsedTime)
+	} else if a.remainingTime > 0 {
 		timerText = formatDurationMMSS(a.remainingTime)
 	}
 
@@ -1059,12 +1478,10 @@ func (a *App) layoutCountdownTimer(gtx layout.Context) layout.Dimensions {
 
 // layoutPeriodIndicator displays the current period (Work/Rest) (Task 26 placeholder)
 func (a *App) layoutPeriodIndicator(gtx layout.Context) layout.Dimensions {
-	periodLabel := "Period: -"
+	periodLabel := a.locale().Text("display.period", i18n.Vars{"period": "-"})
 	switch a.currentPeriod {
-	case types.PeriodWork:
-		periodLabel = "Period: Work"
-	case types.PeriodRest:
-		periodLabel = "Period: Rest"
+	case types.PeriodWork, types.PeriodRest:
+		periodLabel = a.locale().Text("display.period", i18n.Vars{"period": capitalize(a.locale().PeriodName(a.currentPeriod))})
 	}
 
 	label := material.H5(a.theme, periodLabel)
@@ -1099,7 +1516,7 @@ func (a *App) layoutComboMoves(gtx layout.Context) layout.Dimensions {
 	return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		if a.currentCombo.IsEmpty() {
 			// No combo data yet
-			label := material.Body1(a.theme, "Combo moves will appear here")
+			label := material.Body1(a.theme, a.locale().Text("display.combo_placeholder", nil))
 			label.Alignment = text.Middle
 			label.Color = color.NRGBA{R: 128, G: 128, B: 128, A: 255}
 			return label.Layout(gtx)
@@ -1113,7 +1530,7 @@ func (a *App) layoutComboMoves(gtx layout.Context) layout.Dimensions {
 		}.Layout(gtx,
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
 				// Title
-				title := material.H6(a.theme, "Current Combo:")
+				title := material.H6(a.theme, a.locale().Text("display.current_combo", nil))
 				title.Alignment = text.Middle
 				title.Color = color.NRGBA{R: 60, G: 60, B: 60, A: 255}
 				return title.Layout(gtx)
@@ -1165,10 +1582,28 @@ func (a *App) layoutComboMoves(gtx layout.Context) layout.Dimensions {
 	})
 }
 
+// layoutCoachingCue displays the coaching cue for the current round
+func (a *App) layoutCoachingCue(gtx layout.Context) layout.Dimensions {
+	if a.currentCue == "" {
+		return layout.Dimensions{}
+	}
+	inset := layout.Inset{
+		Left:   unit.Dp(40),
+		Right:  unit.Dp(40),
+		Bottom: unit.Dp(10),
+	}
+	return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		label := material.Body1(a.theme, capitalize(a.locale().Text("display.coach", nil))+": "+a.currentCue)
+		label.Alignment = text.Middle
+		label.Color = color.NRGBA{R: 255, G: 152, B: 0, A: 255} // Orange
+		return label.Layout(gtx)
+	})
+}
+
 // formatComboForDisplay formats a combo for display in the GUI with stance-specific names
 func (a *App) formatComboForDisplay(combo models.Combo) string {
 	if combo.IsEmpty() {
-		return "No moves"
+		return a.locale().Text("display.no_moves", nil)
 	}
 
 	// Use stance-specific formatting
@@ -1227,10 +1662,10 @@ func (a *App) layoutWorkoutControls(gtx layout.Context) layout.Dimensions {
 				var btnText string
 				var btnColor color.NRGBA
 				if a.isPaused {
-					btnText = "Resume"
+					btnText = a.locale().Text("display.resume", nil)
 					btnColor = color.NRGBA{R: 33, G: 150, B: 243, A: 255} // Blue for resume
 				} else {
-					btnText = "Pause"
+					btnText = a.locale().Text("display.pause", nil)
 					btnColor = color.NRGBA{R: 255, G: 152, B: 0, A: 255} // Orange for pause
 				}
 
@@ -1256,7 +1691,7 @@ func (a *App) layoutWorkoutControls(gtx layout.Context) layout.Dimensions {
 				Right:  unit.Dp(20),
 			}
 			return inset.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-				btn := material.Button(a.theme, &a.stopBtn, "Stop")
+				btn := material.Button(a.theme, &a.stopBtn, a.locale().Text("display.stop", nil))
 				btn.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 255}    // White text
 				btn.Background = color.NRGBA{R: 244, G: 67, B: 54, A: 255} // Red for stop
 				btn.CornerRadius = unit.Dp(4)
@@ -1266,6 +1701,97 @@ func (a *App) layoutWorkoutControls(gtx layout.Context) layout.Dimensions {
 	)
 }
 
+// layoutSessionControls displays the skip rest, repeat round, add time and jump to round controls
+func (a *App) layoutSessionControls(gtx layout.Context) layout.Dimensions {
+	// Handle button clicks
+	if a.skipRestBtn.Clicked(gtx) {
+		a.handleSessionControl("skipping rest", (*timer.WorkoutTimer).SkipRest)
+	}
+	if a.repeatRoundBtn.Clicked(gtx) {
+		a.handleSessionControl("repeating round", (*timer.WorkoutTimer).RepeatRound)
+	}
+	if a.addTimeBtn.Clicked(gtx) {
+		a.handleSessionControl("adding time", func(wt *timer.WorkoutTimer) error {
+			return wt.AddTime(addTimeStep)
+		})
+	}
+	if a.jumpRoundBtn.Clicked(gtx) {
+		a.handleJumpToRound()
+	}
+	if a.readyBtn.Clicked(gtx) {
+		a.handleSessionControl("ending rest", (*timer.WorkoutTimer).Ready)
+	}
+	if a.doneBtn.Clicked(gtx) {
+		a.handleSessionControl("finishing round", (*timer.WorkoutTimer).Done)
+	}
+
+	button := func(clickable *widget.Clickable, label string) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(6), Right: unit.Dp(6)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				btn := material.Button(a.theme, clickable, label)
+				btn.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 255}     // White text
+				btn.Background = color.NRGBA{R: 96, G: 125, B: 139, A: 255} // Blue grey for session controls
+				btn.CornerRadius = unit.Dp(4)
+				return btn.Layout(gtx)
+			})
+		})
+	}
+
+	// Ready and Done only apply to their rest modes
+	var restControl []layout.FlexChild
+	switch a.selectedRestMode {
+	case models.RestAuto:
+		restControl = append(restControl, button(&a.readyBtn, a.locale().Text("display.ready", nil)))
+	case models.RestEarned:
+		restControl = append(restControl, button(&a.doneBtn, a.locale().Text("display.done", nil)))
+	}
+
+	return layout.Flex{
+		Axis:      layout.Horizontal,
+		Spacing:   layout.SpaceAround,
+		Alignment: layout.Middle,
+	}.Layout(gtx, append(restControl,
+		button(&a.skipRestBtn, a.locale().Text("display.skip_rest", nil)),
+		button(&a.repeatRoundBtn, a.locale().Text("display.repeat_round", nil)),
+		button(&a.addTimeBtn, fmt.Sprintf("+%ds", int(addTimeStep.Seconds()))),
+		// Round number for the jump button
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Left: unit.Dp(6), Right: unit.Dp(6)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				gtx.Constraints.Min.X = gtx.Dp(unit.Dp(50))
+				gtx.Constraints.Max.X = gtx.Dp(unit.Dp(50))
+				editor := material.Editor(a.theme, &a.jumpRoundEditor, a.locale().Text("display.round_hint", nil))
+				return editor.Layout(gtx)
+			})
+		}),
+		button(&a.jumpRoundBtn, a.locale().Text("display.jump", nil)),
+	)...)
+}
+
+// handleSessionControl runs a workout timer control, showing any error in the status line
+func (a *App) handleSessionControl(action string, control func(*timer.WorkoutTimer) error) {
+	if a.workoutTimer == nil {
+		return
+	}
+	if err := control(a.workoutTimer); err != nil {
+		a.setStatusMessage(fmt.Sprintf("Error %s: %v", action, err), true)
+		return
+	}
+	a.statusMessage = ""
+}
+
+// handleJumpToRound jumps to the round entered in the jump editor
+func (a *App) handleJumpToRound() {
+	round, err := strconv.Atoi(strings.TrimSpace(a.jumpRoundEditor.Text()))
+	if err != nil {
+		a.setStatusMessage(a.locale().Text("display.jump_round_hint", nil), true)
+		return
+	}
+	a.handleSessionControl("jumping to round", func(wt *timer.WorkoutTimer) error {
+		return wt.JumpToRound(round)
+	})
+	a.jumpRoundEditor.SetText("")
+}
+
 // handlePauseResume toggles the pause/resume state (Task 28, Task 58)
 func (a *App) handlePauseResume() {
 	if a.workoutTimer == nil {
@@ -1300,6 +1826,7 @@ func (a *App) handleStop() {
 		a.workoutTimer = nil
 	}
 	a.resetWorkoutState()
+	a.loadPendingCheckpoint() // The stopped workout can be resumed
 }
 
 // resetWorkoutState resets all workout-related state
@@ -1309,9 +1836,7 @@ func (a *App) resetWorkoutState() {
 		a.workoutTimer.Stop()
 		a.workoutTimer = nil
 	}
-
-	// Stop animation sequence
-	a.stopAnimationSequence()
+	a.stopFollowingBeats()
 
 	a.showWorkoutDisplay = false
 	a.showCompletion = false
@@ -1322,11 +1847,16 @@ func (a *App) resetWorkoutState() {
 	a.totalRounds = 0
 	a.currentPeriod = types.PeriodWork
 	a.remainingTime = 0
+	a.fineRemaining = 0
+	a.elapsedTime = 0
+	a.openPeriod = false
 	a.currentCombo = models.Combo{} // Reset combo
+	a.currentCue = ""               // Reset coaching cue
 	a.workout = models.Workout{}    // Reset generated workout
 	a.showGo = false                // Reset "go!" indicator
-	a.stopAnimationSequence()       // Stop any running animation timers
-	a.audioHandler = nil            // Clear audio handler
+	a.currentMoveIndex = 0
+	a.audioHandler = nil // Clear audio handler
+	a.stopMusic()
 
 	// Reset character animation to idle (Tasks 32-34)
 	if a.characterSprite != nil {
@@ -1625,11 +2155,21 @@ func (a *App) getFieldHelpText(fieldName string) string {
 	case "stance":
 		return "Boxing stance: Orthodox (right-handed) or Southpaw (left-handed)"
 	case "tempo":
-		return "Tempo determines the speed of combo intervals: Slow (5s), Medium (4s), Fast (3s), Superfast (1s)"
+		return "Tempo determines the speed of combo intervals: Slow (5s), Medium (4s), Fast (3s), Superfast (2s)"
+	case "language":
+		return "Language of the callouts and of the move names, rounds and periods shown during the workout"
+	case "calloutStyle":
+		return "How combos are called out and shown: Numbers (1-2-3), Short Names (jab, hook), Full Names (left hook) or Coach (left hook to the body). Callouts too long for the tempo are shortened."
+	case "cueProfile":
+		return "Sounds that mark each round: Spoken says \"work\" and \"rest\" and beeps the last 3 seconds of rest, Gym rings one bell to start a round, three to end it and a clapper 10 seconds before the end"
+	case "restMode":
+		return "Fixed rests for the set duration, Auto rests until you press Ready (10s-2m), Earned adds the time left when you press Done to the round's rest"
 	case "includeDefensive":
 		return "Include defensive moves (slips, rolls, pull back, duck) in generated combos"
 	case "useLLM":
 		return "Use AI-powered workout generation (requires OpenAI API key)"
+	case "goal":
+		return "Describe the workout you want (e.g. \"12 minutes, counters off the slip, southpaw, finish hard\") and the AI plans timing, pattern, tempo, stance and combos"
 	case "preset":
 		return "Quick-start with a preset workout configuration"
 	default:
@@ -2051,26 +2591,369 @@ func (a *App) layoutTempoDropdown(gtx layout.Context) layout.Dimensions {
 	)
 }
 
-// layoutPresetDropdown creates a dropdown selector for workout presets
-func (a *App) layoutPresetDropdown(gtx layout.Context) layout.Dimensions {
-	// Check if preset button was clicked
-	if a.presetButton.Clicked(gtx) {
-		a.presetDropdownOpen = !a.presetDropdownOpen
+// layoutRestModeDropdown creates a dropdown selector for the rest mode
+func (a *App) layoutRestModeDropdown(gtx layout.Context) layout.Dimensions {
+	if a.restModeButton.Clicked(gtx) {
+		a.restModeDropdownOpen = !a.restModeDropdownOpen
 	}
-
-	// Available presets
-	presets := models.AvailablePresets()
-	presetLabels := []string{"Beta Style", "Endurance", "Power"}
-	allPresets := []struct {
-		preset *models.WorkoutPreset
-		label  string
-		index  int
-	}{
-		{nil, "Custom", 3}, // "Custom" option (nil preset)
+	modes := models.AllRestModes()
+	for i := range modes {
+		if a.restModeOptions[i].Clicked(gtx) {
+			a.selectedRestMode = modes[i]
+			a.restModeDropdownOpen = false
+		}
 	}
-	for i, preset := range presets {
-		p := preset
-		allPresets = append(allPresets, struct {
+
+	return layout.Flex{
+		Axis:      layout.Vertical,
+		Spacing:   layout.SpaceStart,
+		Alignment: layout.Start,
+	}.Layout(gtx,
+		// Label
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body1(a.theme, "Rest Mode")
+			lbl.Alignment = text.Start
+			return lbl.Layout(gtx)
+		}),
+		// Help text
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:  unit.Dp(2),
+				Left: unit.Dp(4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				helpLabel := material.Caption(a.theme, a.getFieldHelpText("restMode"))
+				helpLabel.Color = color.NRGBA{R: 120, G: 120, B: 120, A: 255} // Gray color for help text
+				return helpLabel.Layout(gtx)
+			})
+		}),
+
+		// Dropdown button
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			btn := material.Button(a.theme, &a.restModeButton, a.selectedRestMode.DisplayName())
+			return btn.Layout(gtx)
+		}),
+
+		// Dropdown options (shown when open)
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !a.restModeDropdownOpen {
+				return layout.Dimensions{}
+			}
+
+			return layout.Inset{
+				Top:   unit.Dp(5),
+				Left:  unit.Dp(10),
+				Right: unit.Dp(10),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				var options []layout.FlexChild
+				for i, mode := range modes {
+					if mode == a.selectedRestMode {
+						continue // Skip the selected rest mode
+					}
+					if len(options) > 0 {
+						options = append(options, layout.Rigid(layout.Spacer{Height: unit.Dp(5)}.Layout))
+					}
+					label, index := mode.DisplayName(), i
+					options = append(options, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return material.Button(a.theme, &a.restModeOptions[index], label).Layout(gtx)
+					}))
+				}
+
+				return layout.Flex{
+					Axis:      layout.Vertical,
+					Spacing:   layout.SpaceStart,
+					Alignment: layout.Start,
+				}.Layout(gtx, options...)
+			})
+		}),
+	)
+}
+
+// layoutLanguageDropdown creates a dropdown selector for the language
+func (a *App) layoutLanguageDropdown(gtx layout.Context) layout.Dimensions {
+	if a.languageButton.Clicked(gtx) {
+		a.languageDropdownOpen = !a.languageDropdownOpen
+	}
+	languages := i18n.Languages()
+	for i := range languages {
+		if a.languageOptions[i].Clicked(gtx) {
+			a.selectedLanguage = languages[i]
+			a.languageDropdownOpen = false
+		}
+	}
+
+	return layout.Flex{
+		Axis:      layout.Vertical,
+		Spacing:   layout.SpaceStart,
+		Alignment: layout.Start,
+	}.Layout(gtx,
+		// Label
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body1(a.theme, "Language")
+			lbl.Alignment = text.Start
+			return lbl.Layout(gtx)
+		}),
+		// Help text
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:  unit.Dp(2),
+				Left: unit.Dp(4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				helpLabel := material.Caption(a.theme, a.getFieldHelpText("language"))
+				helpLabel.Color = color.NRGBA{R: 120, G: 120, B: 120, A: 255} // Gray color for help text
+				return helpLabel.Layout(gtx)
+			})
+		}),
+
+		// Dropdown button
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			btn := material.Button(a.theme, &a.languageButton, a.locale().Name)
+			return btn.Layout(gtx)
+		}),
+
+		// Dropdown options (shown when open)
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !a.languageDropdownOpen {
+				return layout.Dimensions{}
+			}
+
+			return layout.Inset{
+				Top:   unit.Dp(5),
+				Left:  unit.Dp(10),
+				Right: unit.Dp(10),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				var options []layout.FlexChild
+				for i, language := range languages {
+					if language == a.selectedLanguage {
+						continue // Skip the selected language
+					}
+					if len(options) > 0 {
+						options = append(options, layout.Rigid(layout.Spacer{Height: unit.Dp(5)}.Layout))
+					}
+					locale, err := i18n.Load(language)
+					if err != nil {
+						continue
+					}
+					label, index := locale.Name, i
+					options = append(options, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return material.Button(a.theme, &a.languageOptions[index], label).Layout(gtx)
+					}))
+				}
+
+				return layout.Flex{
+					Axis:      layout.Vertical,
+					Spacing:   layout.SpaceStart,
+					Alignment: layout.Start,
+				}.Layout(gtx, options...)
+			})
+		}),
+	)
+}
+
+// cueProfileNames returns the names of the cue profiles to choose from: the user-defined ones, then the built-in
+// ones they don't replace
+func (a *App) cueProfileNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, profile := range a.cueProfiles {
+		names = append(names, profile.Name)
+		seen[strings.ToLower(profile.Name)] = true
+	}
+	for _, profile := range models.BuiltInCueProfiles() {
+		if !seen[profile.Name] {
+			names = append(names, profile.Name)
+		}
+	}
+	return names
+}
+
+// cueProfile returns the selected cue profile, or the spoken profile if it can't be found
+func (a *App) cueProfile() models.CueProfile {
+	audio := config.AudioConfig{CueProfile: a.selectedCueProfile, CueProfiles: a.cueProfiles}
+	profile, err := audio.GetCueProfile()
+	if err != nil {
+		return models.SpokenCueProfile()
+	}
+	return profile
+}
+
+// layoutCueProfileDropdown creates a dropdown selector for the cue profile
+func (a *App) layoutCueProfileDropdown(gtx layout.Context) layout.Dimensions {
+	if a.cueProfileButton.Clicked(gtx) {
+		a.cueProfileDropdownOpen = !a.cueProfileDropdownOpen
+	}
+	names := a.cueProfileNames()
+	if len(a.cueProfileOptions) != len(names) {
+		a.cueProfileOptions = make([]widget.Clickable, len(names))
+	}
+	for i := range names {
+		if a.cueProfileOptions[i].Clicked(gtx) {
+			a.selectedCueProfile = names[i]
+			a.cueProfileDropdownOpen = false
+		}
+	}
+	selected := a.cueProfile().Name
+
+	return layout.Flex{
+		Axis:      layout.Vertical,
+		Spacing:   layout.SpaceStart,
+		Alignment: layout.Start,
+	}.Layout(gtx,
+		// Label
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body1(a.theme, "Cue Profile")
+			lbl.Alignment = text.Start
+			return lbl.Layout(gtx)
+		}),
+		// Help text
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:  unit.Dp(2),
+				Left: unit.Dp(4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				helpLabel := material.Caption(a.theme, a.getFieldHelpText("cueProfile"))
+				helpLabel.Color = color.NRGBA{R: 120, G: 120, B: 120, A: 255} // Gray color for help text
+				return helpLabel.Layout(gtx)
+			})
+		}),
+
+		// Dropdown button
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			btn := material.Button(a.theme, &a.cueProfileButton, selected)
+			return btn.Layout(gtx)
+		}),
+
+		// Dropdown options (shown when open)
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !a.cueProfileDropdownOpen {
+				return layout.Dimensions{}
+			}
+
+			return layout.Inset{
+				Top:   unit.Dp(5),
+				Left:  unit.Dp(10),
+				Right: unit.Dp(10),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				var options []layout.FlexChild
+				for i, name := range names {
+					if strings.EqualFold(name, selected) {
+						continue // Skip the selected cue profile
+					}
+					if len(options) > 0 {
+						options = append(options, layout.Rigid(layout.Spacer{Height: unit.Dp(5)}.Layout))
+					}
+					label, index := name, i
+					options = append(options, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return material.Button(a.theme, &a.cueProfileOptions[index], label).Layout(gtx)
+					}))
+				}
+
+				return layout.Flex{
+					Axis:      layout.Vertical,
+					Spacing:   layout.SpaceStart,
+					Alignment: layout.Start,
+				}.Layout(gtx, options...)
+			})
+		}),
+	)
+}
+
+// layoutCalloutStyleDropdown creates a dropdown selector for the callout style
+func (a *App) layoutCalloutStyleDropdown(gtx layout.Context) layout.Dimensions {
+	if a.calloutStyleButton.Clicked(gtx) {
+		a.calloutStyleDropdownOpen = !a.calloutStyleDropdownOpen
+	}
+	styles := models.AllCalloutStyles()
+	for i := range styles {
+		if a.calloutStyleOptions[i].Clicked(gtx) {
+			a.selectedCalloutStyle = styles[i]
+			a.calloutStyleDropdownOpen = false
+		}
+	}
+
+	return layout.Flex{
+		Axis:      layout.Vertical,
+		Spacing:   layout.SpaceStart,
+		Alignment: layout.Start,
+	}.Layout(gtx,
+		// Label
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			lbl := material.Body1(a.theme, "Callout Style")
+			lbl.Alignment = text.Start
+			return lbl.Layout(gtx)
+		}),
+		// Help text
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{
+				Top:  unit.Dp(2),
+				Left: unit.Dp(4),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				helpLabel := material.Caption(a.theme, a.getFieldHelpText("calloutStyle"))
+				helpLabel.Color = color.NRGBA{R: 120, G: 120, B: 120, A: 255} // Gray color for help text
+				return helpLabel.Layout(gtx)
+			})
+		}),
+
+		// Dropdown button
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			btn := material.Button(a.theme, &a.calloutStyleButton, a.selectedCalloutStyle.DisplayName())
+			return btn.Layout(gtx)
+		}),
+
+		// Dropdown options (shown when open)
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !a.calloutStyleDropdownOpen {
+				return layout.Dimensions{}
+			}
+
+			return layout.Inset{
+				Top:   unit.Dp(5),
+				Left:  unit.Dp(10),
+				Right: unit.Dp(10),
+			}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				var options []layout.FlexChild
+				for i, style := range styles {
+					if style == a.selectedCalloutStyle {
+						continue // Skip the selected callout style
+					}
+					if len(options) > 0 {
+						options = append(options, layout.Rigid(layout.Spacer{Height: unit.Dp(5)}.Layout))
+					}
+					label, index := style.DisplayName(), i
+					options = append(options, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						return material.Button(a.theme, &a.calloutStyleOptions[index], label).Layout(gtx)
+					}))
+				}
+
+				return layout.Flex{
+					Axis:      layout.Vertical,
+					Spacing:   layout.SpaceStart,
+					Alignment: layout.Start,
+				}.Layout(gtx, options...)
+			})
+		}),
+	)
+}
+
+// layoutPresetDropdown creates a dropdown selector for workout presets
+func (a *App) layoutPresetDropdown(gtx layout.Context) layout.Dimensions {
+	// Check if preset button was clicked
+	if a.presetButton.Clicked(gtx) {
+		a.presetDropdownOpen = !a.presetDropdownOpen
+	}
+
+	// Available presets
+	presets := models.AvailablePresets()
+	presetLabels := []string{"Beta Style", "Endurance", "Power"}
+	allPresets := []struct {
+		preset *models.WorkoutPreset
+		label  string
+		index  int
+	}{
+		{nil, "Custom", 3}, // "Custom" option (nil preset)
+	}
+	for i, preset := range presets {
+		p := preset
+		allPresets = append(allPresets, struct {
 			preset *models.WorkoutPreset
 			label  string
 			index  int
@@ -2384,6 +3267,7 @@ func (a *App) handleStartWorkout() {
 
 	// Generate workout
 	var workout models.Workout
+	usageSummary := "" // LLM token usage and cost, shown on the preview screen
 	if a.useLLM.Value {
 		// LLM generation path
 		apiKey := strings.TrimSpace(a.openAIAPIKeyEditor.Text())
@@ -2391,7 +3275,7 @@ func (a *App) handleStartWorkout() {
 			// Try environment variable
 			apiKey = a.getOpenAIAPIKeyFromEnv()
 		}
-		if apiKey == "" {
+		if apiKey == "" && a.requiresAPIKey() {
 			a.setStatusMessage("OpenAI API key required for LLM generation", true)
 			return
 		}
@@ -2399,13 +3283,23 @@ func (a *App) handleStartWorkout() {
 		a.setStatusMessage("Generating workout with LLM...", false)
 		// Note: In a real GUI, you'd want to show this in a non-blocking way
 		// For now, we'll generate synchronously (could be improved with goroutines)
-		llmGenerator := generator.NewLLMWorkoutGenerator(apiKey)
-		var genErr error
+		llmGenerator, genErr := generator.NewLLMWorkoutGeneratorFromConfig(apiKey, a.createConfigFromForm().Generator)
+		if genErr != nil {
+			a.setStatusMessage(fmt.Sprintf("Error setting up LLM generator: %v", genErr), true)
+			return
+		}
+		llmGenerator.SetLocale(a.locale())
 		workout, genErr = llmGenerator.GenerateWorkoutWithStance(workoutConfig, workoutPattern, a.selectedStance)
+		usage := llmGenerator.LastUsage()
 		if genErr != nil {
-			a.setStatusMessage(fmt.Sprintf("Error generating workout with LLM: %v", genErr), true)
+			if len(usage.Attempts) > 0 {
+				a.setStatusMessage(fmt.Sprintf("Error generating workout with LLM: %v (%s)", genErr, usage.Summary()), true)
+			} else {
+				a.setStatusMessage(fmt.Sprintf("Error generating workout with LLM: %v", genErr), true)
+			}
 			return
 		}
+		usageSummary = usage.Summary()
 	} else {
 		// In-house generation path
 		a.setStatusMessage("Generating workout...", false)
@@ -2430,8 +3324,73 @@ func (a *App) handleStartWorkout() {
 	// Switch to workout preview screen for confirmation
 	a.showWorkoutPreview = true
 	a.showWorkoutDisplay = false
-	// Clear status messages when switching screens
+	// Clear status messages when switching screens, keeping the LLM usage line if there is one
 	a.statusMessage = ""
+	if usageSummary != "" {
+		a.setStatusMessage(usageSummary, false)
+	}
+}
+
+// handlePlanFromGoal asks the LLM to plan a whole workout from the goal text,
+// fills the form with the planned settings and shows the plan on the preview screen
+func (a *App) handlePlanFromGoal() {
+	goal := strings.TrimSpace(a.goalEditor.Text())
+	if goal == "" {
+		a.setStatusMessage("Enter a workout goal to plan from", true)
+		return
+	}
+
+	apiKey := strings.TrimSpace(a.openAIAPIKeyEditor.Text())
+	if apiKey == "" {
+		apiKey = a.getOpenAIAPIKeyFromEnv()
+	}
+	if apiKey == "" && a.requiresAPIKey() {
+		a.setStatusMessage("OpenAI API key required for goal-based planning", true)
+		return
+	}
+
+	a.setStatusMessage("Planning workout from goal with LLM...", false)
+	llmGenerator, err := generator.NewLLMWorkoutGeneratorFromConfig(apiKey, a.createConfigFromForm().Generator)
+	if err != nil {
+		a.setStatusMessage(fmt.Sprintf("Error setting up LLM generator: %v", err), true)
+		return
+	}
+	llmGenerator.SetLocale(a.locale())
+	plan, err := llmGenerator.PlanWorkoutFromGoal(goal)
+	usage := llmGenerator.LastUsage()
+	if err != nil {
+		if len(usage.Attempts) > 0 {
+			a.setStatusMessage(fmt.Sprintf("Error planning workout from goal: %v (%s)", err, usage.Summary()), true)
+		} else {
+			a.setStatusMessage(fmt.Sprintf("Error planning workout from goal: %v", err), true)
+		}
+		return
+	}
+
+	// Fill the form with the plan so Back to Form shows (and Save Config keeps) what the LLM chose.
+	// Tempo is set first because populateFromConfig clamps max moves to the selected tempo's limit.
+	a.selectedTempo = plan.Tempo
+	planConfig := plan.Config
+	planConfig.Generator.Pricing = a.llmPricing
+	planConfig.Generator.MonthlyBudgetUSD = a.llmMonthlyBudgetUSD
+	planConfig.Generator.PromptTemplate = a.llmPromptTemplate
+	planConfig.Generator.BaseURL = a.llmBaseURL
+	a.populateFromConfig(&planConfig)
+
+	// Store the planned workout and switch to the preview screen for confirmation
+	a.workout = plan.Workout
+	a.totalRounds = plan.Workout.RoundCount()
+	a.currentRound = 0
+	a.currentPeriod = types.PeriodWork
+	a.remainingTime = plan.Workout.Config.WorkDuration
+	a.showWorkoutPreview = true
+	a.showWorkoutDisplay = false
+
+	status := usage.Summary()
+	if plan.Notes != "" {
+		status = fmt.Sprintf("%s — %s", plan.Notes, status)
+	}
+	a.setStatusMessage(status, false)
 }
 
 // getOpenAIAPIKeyFromEnv retrieves OpenAI API key from environment variable
@@ -2439,11 +3398,42 @@ func (a *App) getOpenAIAPIKeyFromEnv() string {
 	return os.Getenv("OPENAI_API_KEY")
 }
 
+// requiresAPIKey returns false when the LLM endpoint is a custom base URL (e.g. a local stub) that doesn't need a key
+func (a *App) requiresAPIKey() bool {
+	generatorConfig := config.GeneratorConfig{BaseURL: a.llmBaseURL}
+	return generatorConfig.RequiresAPIKey()
+}
+
 // TimerDisplayHandler implementation
 // These methods are called by the workout timer to update the GUI
 // OnTimerUpdate is called on each timer tick to update the remaining time
 func (a *App) OnTimerUpdate(remaining time.Duration, periodType types.PeriodType, roundNumber int) {
 	a.remainingTime = remaining
+	a.openPeriod = false
+	a.currentPeriod = periodType
+	a.currentRound = roundNumber
+	// Invalidate window to trigger redraw
+	if a.window != nil {
+		a.window.Invalidate()
+	}
+}
+
+// OnElapsedUpdate is called on each tick of an auto rest period, which counts up until the boxer is ready
+func (a *App) OnElapsedUpdate(elapsed time.Duration, periodType types.PeriodType, roundNumber int) {
+	a.elapsedTime = elapsed
+	a.openPeriod = true
+	a.currentPeriod = periodType
+	a.currentRound = roundNumber
+	// Invalidate window to trigger redraw
+	if a.window != nil {
+		a.window.Invalidate()
+	}
+}
+
+// OnTimerFineUpdate is called several times a second to keep the progress bar moving smoothly.
+// It leaves remainingTime to OnTimerUpdate so the countdown only changes on whole seconds.
+func (a *App) OnTimerFineUpdate(remaining time.Duration, periodType types.PeriodType, roundNumber int) {
+	a.fineRemaining = remaining
 	a.currentPeriod = periodType
 	a.currentRound = roundNumber
 	// Invalidate window to trigger redraw
@@ -2457,31 +3447,24 @@ func (a *App) OnPeriodStart(periodType types.PeriodType, roundNumber int, durati
 	a.currentPeriod = periodType
 	a.currentRound = roundNumber
 	a.remainingTime = duration
+	a.fineRemaining = duration
+	a.openPeriod = false
 
 	// Update current combo for the round
 	if roundNumber > 0 && roundNumber <= len(a.workout.Rounds) {
 		round := a.workout.Rounds[roundNumber-1]
 		a.currentCombo = round.Combo
+		a.currentCue = a.locale().Cue(round.Cue)
 	}
 
 	if periodType == types.PeriodWork {
-		// Store workout period start time and duration for period check
-		a.workoutStartTime = time.Now()
-		a.workoutPeriodDuration = duration
-
-		// Set to idle initially, wait for first beep
+		// Set to idle initially; the first beat starts the combo as the period starts
+		a.currentMoveIndex = 0
 		if a.characterSprite != nil {
 			a.characterSprite.SetAnimation(AnimationStateIdle)
 		}
-
-		// Start animation sequence (beep will play at start of sequence)
-		a.startAnimationSequence()
 	} else {
-		// Rest period - stop any work period animations
-		a.stopAnimationSequence()
-		a.showGo = false
-
-		// Start rest period animation
+		// Rest period - the beats stop with the work period
 		a.startRestPeriodAnimation()
 	}
 
@@ -2493,9 +3476,8 @@ func (a *App) OnPeriodStart(periodType types.PeriodType, roundNumber int, durati
 
 // OnPeriodEnd is called when a period ends
 func (a *App) OnPeriodEnd(periodType types.PeriodType, roundNumber int) {
-	// Stop animation sequence when work period ends
+	// Hide "go!" when work period ends
 	if periodType == types.PeriodWork {
-		a.stopAnimationSequence()
 		a.showGo = false
 	}
 	// Period end - timer will call OnPeriodStart for next period
@@ -2609,6 +3591,17 @@ func (a *App) populateFromConfig(cfg *config.AppConfig) {
 		a.selectedPattern = models.PatternConstant
 	}
 
+	// Set language and callout style
+	a.selectedLanguage = cfg.GetLanguage()
+	if style := models.ParseCalloutStyle(cfg.Audio.CalloutStyle); style != models.CalloutUnknown {
+		a.selectedCalloutStyle = style
+	}
+	a.cueProfiles = cfg.Audio.CueProfiles
+	a.selectedCueProfile = ""
+	if profile, err := cfg.Audio.GetCueProfile(); err == nil {
+		a.selectedCueProfile = profile.Name
+	}
+
 	// Set stance
 	switch cfg.GetStance() {
 	case "orthodox":
@@ -2619,6 +3612,13 @@ func (a *App) populateFromConfig(cfg *config.AppConfig) {
 
 	// Generator config
 	a.useLLM.Value = cfg.Generator.UseLLM
+	a.llmPricing = cfg.Generator.Pricing
+	a.llmMonthlyBudgetUSD = cfg.Generator.MonthlyBudgetUSD
+	a.llmPromptTemplate = cfg.Generator.PromptTemplate
+	a.llmBaseURL = cfg.Generator.BaseURL
+	a.music = cfg.Audio.Music
+	a.tts = cfg.Audio.TTS
+	a.samplePack = cfg.Audio.SamplePack
 	if cfg.OpenAIAPIKey != "" {
 		a.openAIAPIKeyEditor.SetText(cfg.OpenAIAPIKey)
 	}
@@ -2654,10 +3654,23 @@ func (a *App) createConfigFromForm() *config.AppConfig {
 			IncludeDefensive: a.includeDefensive.Value,
 		},
 		Generator: config.GeneratorConfig{
-			UseLLM:   a.useLLM.Value,
-			LLMModel: "gpt-4o-mini", // Default model
+			UseLLM:           a.useLLM.Value,
+			LLMModel:         "gpt-4o-mini", // Default model
+			Pricing:          a.llmPricing,
+			MonthlyBudgetUSD: a.llmMonthlyBudgetUSD,
+			PromptTemplate:   a.llmPromptTemplate,
+			BaseURL:          a.llmBaseURL,
+		},
+		Audio: config.AudioConfig{
+			TTS:          a.tts,
+			SamplePack:   a.samplePack,
+			CalloutStyle: a.selectedCalloutStyle.String(),
+			Music:        a.music,
+			CueProfile:   a.selectedCueProfile,
+			CueProfiles:  a.cueProfiles,
 		},
 		Stance:       stance,
+		Language:     a.selectedLanguage,
 		OpenAIAPIKey: strings.TrimSpace(a.openAIAPIKeyEditor.Text()),
 	}
 }
@@ -1,10 +1,15 @@
 package gui
 
 import (
+	"heavybagworkout/internal/config"
 	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/timer"
+	"heavybagworkout/internal/types"
 	"strings"
 	"testing"
 	"time"
+
+	"gioui.org/layout"
 )
 
 // TestFormValidation_WorkDuration tests validation for work duration field
@@ -756,3 +761,268 @@ func (m *mockWindow) Invalidate() {
 // without actual GUI rendering. These would typically be manual tests or integration tests
 // that require a running GUI application. The code structure supports responsive layouts
 // through the use of layout.Flex and layout constraints, which is the standard Gio approach.
+
+// TestConfigRoundTrip_LLMPricingAndBudget tests that pricing overrides, the monthly budget and prompt template survive load/save
+func TestConfigRoundTrip_LLMPricingAndBudget(t *testing.T) {
+	app := NewApp()
+
+	cfg := config.LoadDefault()
+	cfg.Generator.UseLLM = true
+	cfg.Generator.Pricing = map[string]config.ModelPricing{
+		"gpt-4.1-nano": {InputPerMillionUSD: 0.2, OutputPerMillionUSD: 0.8},
+	}
+	cfg.Generator.MonthlyBudgetUSD = 3.5
+	cfg.Generator.PromptTemplate = "prompts/strict-coach.tmpl"
+	app.populateFromConfig(cfg)
+
+	saved := app.createConfigFromForm()
+	if saved.Generator.MonthlyBudgetUSD != 3.5 {
+		t.Errorf("expected monthly budget 3.5, got %v", saved.Generator.MonthlyBudgetUSD)
+	}
+	if price, ok := saved.Generator.Pricing["gpt-4.1-nano"]; !ok || price.OutputPerMillionUSD != 0.8 {
+		t.Errorf("expected pricing override to be kept, got %+v", saved.Generator.Pricing)
+	}
+	if saved.Generator.PromptTemplate != "prompts/strict-coach.tmpl" {
+		t.Errorf("expected prompt template path to be kept, got %q", saved.Generator.PromptTemplate)
+	}
+}
+
+// TestConfigRoundTrip_AudioSettings tests that the audio settings the form doesn't edit survive load/save
+func TestConfigRoundTrip_AudioSettings(t *testing.T) {
+	app := NewApp()
+
+	cfg := config.LoadDefault()
+	cfg.Audio.TTS = config.TTSConfig{Engine: "espeak-ng", Voice: "en-gb", Rate: 160, Volume: 0.8}
+	cfg.Audio.SamplePack = "packs/coach"
+	app.populateFromConfig(cfg)
+
+	saved := app.createConfigFromForm()
+	if saved.Audio.TTS != cfg.Audio.TTS {
+		t.Errorf("expected text-to-speech settings %+v to be kept, got %+v", cfg.Audio.TTS, saved.Audio.TTS)
+	}
+	if saved.Audio.SamplePack != "packs/coach" {
+		t.Errorf("expected sample pack packs/coach to be kept, got %q", saved.Audio.SamplePack)
+	}
+}
+
+// TestPlanFromGoal_RequiresGoal tests that planning from an empty goal is rejected before calling the LLM
+func TestPlanFromGoal_RequiresGoal(t *testing.T) {
+	app := NewApp()
+	app.useLLM.Value = true
+	app.goalEditor.SetText("   ")
+
+	app.handlePlanFromGoal()
+
+	if !app.statusError || !strings.Contains(app.statusMessage, "workout goal") {
+		t.Errorf("expected goal error status, got %q (error=%v)", app.statusMessage, app.statusError)
+	}
+	if app.showWorkoutPreview {
+		t.Error("should not switch to preview without a plan")
+	}
+}
+
+func TestOnPeriodStart_SetsCoachingCue(t *testing.T) {
+	app := NewApp()
+	combo := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab)})
+	cuedRound := models.NewWorkoutRound(1, combo, 2*time.Second, 1*time.Second)
+	cuedRound.Cue = "Snap the jab back"
+	app.workout = models.NewWorkout(
+		models.NewWorkoutConfig(2*time.Second, 1*time.Second, 2),
+		[]models.WorkoutRound{
+			cuedRound,
+			models.NewWorkoutRound(2, combo, 2*time.Second, 1*time.Second),
+		},
+	)
+
+	// The cue stays up through the round's rest period
+	app.OnPeriodStart(types.PeriodRest, 1, 1*time.Second)
+	if app.currentCue != "Snap the jab back" {
+		t.Errorf("expected cue for round 1, got %q", app.currentCue)
+	}
+
+	app.OnPeriodStart(types.PeriodRest, 2, 1*time.Second)
+	if app.currentCue != "" {
+		t.Errorf("expected no cue for round 2, got %q", app.currentCue)
+	}
+}
+
+func TestRoundProgress_FineUpdates(t *testing.T) {
+	app := NewApp()
+	rounds := []models.WorkoutRound{
+		models.NewWorkoutRound(1, models.Combo{}, 3*time.Second, 1*time.Second),
+		models.NewWorkoutRound(2, models.Combo{}, 3*time.Second, 1*time.Second),
+	}
+	app.workout = models.NewWorkout(models.NewWorkoutConfig(3*time.Second, 1*time.Second, 2), rounds)
+	app.totalRounds = 2
+
+	tests := []struct {
+		name      string
+		round     int
+		period    types.PeriodType
+		remaining time.Duration
+		want      float64
+	}{
+		{name: "start of workout", round: 1, period: types.PeriodWork, remaining: 3 * time.Second, want: 0},
+		{name: "mid work", round: 1, period: types.PeriodWork, remaining: 1 * time.Second, want: 0.25},
+		{name: "mid rest", round: 1, period: types.PeriodRest, remaining: 500 * time.Millisecond, want: 0.4375},
+		{name: "second round", round: 2, period: types.PeriodWork, remaining: 2900 * time.Millisecond, want: 0.5125},
+		{name: "end of workout", round: 2, period: types.PeriodRest, remaining: 0, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app.OnTimerFineUpdate(tt.remaining, tt.period, tt.round)
+			if got := app.roundProgress(); got != tt.want {
+				t.Errorf("expected progress %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAnimationSequence_FakeClock(t *testing.T) {
+	app := NewApp()
+	clock := timer.NewFakeClock(time.Unix(0, 0))
+	app.clock = clock
+	combo := models.NewCombo([]models.Move{
+		models.NewPunchMove(models.Jab),
+		models.NewPunchMove(models.Cross),
+		models.NewPunchMove(models.LeadHook),
+	})
+	app.workout = models.NewWorkout(
+		models.NewWorkoutConfig(10*time.Second, 5*time.Second, 1),
+		[]models.WorkoutRound{models.NewWorkoutRound(1, combo, 10*time.Second, 5*time.Second)},
+	)
+	workoutTimer := timer.NewWorkoutTimer(app.workout)
+	workoutTimer.SetClock(clock)
+	workoutTimer.SetTempo(models.TempoSlow.Duration()) // 5 seconds between combos
+	sub := workoutTimer.Events().SubscribeBlocking(64)
+	defer sub.Unsubscribe()
+
+	// Stands in for followBeats and the frame loop, so the app's state is only touched by the test goroutine
+	nextEvent := func(kinds ...timer.EventType) timer.Event {
+		t.Helper()
+		for {
+			select {
+			case event := <-sub.Events():
+				for _, kind := range kinds {
+					if event.Type == kind {
+						return event
+					}
+				}
+				if event.Type == timer.EventPeriodEnded {
+					t.Fatalf("expected %v before the work period ended", kinds)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for %v", kinds)
+			}
+		}
+	}
+	beat := func() {
+		t.Helper()
+		app.queueBeat(nextEvent(timer.EventBeat, timer.EventMove, timer.EventComboDone))
+		app.animateBeats(layout.Context{})
+	}
+
+	if err := workoutTimer.Start(); err != nil {
+		t.Fatalf("failed to start workout: %v", err)
+	}
+	defer workoutTimer.Stop()
+	app.currentPeriod = types.PeriodWork
+
+	// The first move plays as the work period starts
+	beat()
+	if app.currentMoveIndex != 0 || !app.showGo {
+		t.Fatalf("expected first move with go shown, got index %d (go %v)", app.currentMoveIndex, app.showGo)
+	}
+
+	// Each move lasts 400ms
+	clock.BlockUntil(2)
+	clock.Advance(400 * time.Millisecond)
+	beat()
+	if app.currentMoveIndex != 1 {
+		t.Errorf("expected second move after 400ms, got index %d", app.currentMoveIndex)
+	}
+	clock.Advance(800 * time.Millisecond)
+	beat()
+	beat()
+	if app.currentMoveIndex != 3 {
+		t.Errorf("expected combo to finish after 1.2s, got index %d", app.currentMoveIndex)
+	}
+	if !app.showGo {
+		t.Error("expected go to show for 500ms after the last move")
+	}
+
+	// The next combo starts on the next beat, go having hidden in between
+	clock.Advance(3800 * time.Millisecond)
+	app.animateBeats(layout.Context{})
+	if app.showGo {
+		t.Error("expected go to hide 500ms after the last move")
+	}
+	beat()
+	if app.currentMoveIndex != 0 {
+		t.Errorf("expected the combo to restart after 5s, got index %d", app.currentMoveIndex)
+	}
+
+	// Once the work period has elapsed the beats stop
+	clock.Advance(5 * time.Second)
+	for i := 0; i < 3; i++ {
+		beat() // The rest of the second combo
+	}
+	nextEvent(timer.EventPeriodEnded)
+}
+
+func TestSessionControls_ReportErrors(t *testing.T) {
+	app := NewApp()
+	app.workout = models.NewWorkout(
+		models.NewWorkoutConfig(10*time.Second, 10*time.Second, 2),
+		[]models.WorkoutRound{
+			models.NewWorkoutRound(1, models.Combo{}, 10*time.Second, 10*time.Second),
+			models.NewWorkoutRound(2, models.Combo{}, 10*time.Second, 10*time.Second),
+		},
+	)
+	app.workoutTimer = timer.NewWorkoutTimer(app.workout)
+	app.workoutTimer.SetAudioHandler(timer.NewNoOpAudioCueHandler())
+	if err := app.workoutTimer.Start(); err != nil {
+		t.Fatalf("failed to start workout: %v", err)
+	}
+	defer app.workoutTimer.Stop()
+
+	app.jumpRoundEditor.SetText("abc")
+	app.handleJumpToRound()
+	if !app.statusError {
+		t.Error("expected an error for a non-numeric round")
+	}
+
+	app.jumpRoundEditor.SetText("5")
+	app.handleJumpToRound()
+	if !app.statusError || !strings.Contains(app.statusMessage, "out of range") {
+		t.Errorf("expected an out of range error, got %q", app.statusMessage)
+	}
+
+	app.handleSessionControl("skipping rest", (*timer.WorkoutTimer).SkipRest)
+	if !strings.Contains(app.statusMessage, "not in a rest period") {
+		t.Errorf("expected a rest period error, got %q", app.statusMessage)
+	}
+
+	app.jumpRoundEditor.SetText("2")
+	app.handleJumpToRound()
+	if app.statusMessage != "" {
+		t.Errorf("expected the status to clear after a successful jump, got %q", app.statusMessage)
+	}
+}
+
+func TestAutoRest_CountsUpUntilReady(t *testing.T) {
+	app := NewApp()
+
+	app.OnPeriodStart(types.PeriodRest, 1, 2*time.Minute)
+	app.OnElapsedUpdate(75*time.Second, types.PeriodRest, 1)
+	if !app.openPeriod || app.elapsedTime != 75*time.Second {
+		t.Errorf("expected an open rest period with 75s rested, got open %v with %v", app.openPeriod, app.elapsedTime)
+	}
+
+	// Ready before the minimum rest turns the period into a countdown
+	app.OnTimerUpdate(4*time.Second, types.PeriodRest, 1)
+	if app.openPeriod {
+		t.Error("expected a countdown after a timer update")
+	}
+}
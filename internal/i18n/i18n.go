@@ -0,0 +1,332 @@
+// Package i18n localizes the workout's callouts and the strings shown while it runs: move names, round callouts,
+// period names and counts. Each language is a locale file in locales/, embedded in the binary.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// DefaultLanguage is the language used when none is set, and for messages a locale is missing
+const DefaultLanguage = "en"
+
+// cuePrefix starts the message keys of the coaching cues
+const cuePrefix = "cue."
+
+// Vars fills in a message's {placeholders}
+type Vars map[string]any
+
+// pluralRules pick a plural form ("one" or "other") for a count, by the name locale files give them
+var pluralRules = map[string]func(n int) string{
+	// Only 1 is singular, as in English and Spanish
+	"one": func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	// 0 and 1 are singular, as in Portuguese and French
+	"zero_one": func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+}
+
+// Locale holds the messages of one language. A nil Locale is English.
+type Locale struct {
+	Language string // Language code, e.g. "es"
+	Name     string // Name of the language in the language itself, e.g. "Español"
+
+	plural   func(n int) string
+	messages map[string]message
+}
+
+// message is a message's plural forms; a message without plurals has just "other"
+type message map[string]string
+
+// UnmarshalJSON reads a message as a string, or as an object of plural forms
+func (m *message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = message{"other": text}
+		return nil
+	}
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("a message must be a string or an object of plural forms")
+	}
+	if forms["other"] == "" {
+		return fmt.Errorf("plural forms need an \"other\" form")
+	}
+	*m = forms
+	return nil
+}
+
+// localeFile is the layout of a locale file
+type localeFile struct {
+	Language   string             `json:"language"`
+	Name       string             `json:"name"`
+	PluralRule string             `json:"plural_rule"` // A key of pluralRules
+	Messages   map[string]message `json:"messages"`
+}
+
+var (
+	localesOnce sync.Once
+	locales     map[string]*Locale
+	localesErr  error
+)
+
+// loadLocales parses every embedded locale file
+func loadLocales() (map[string]*Locale, error) {
+	localesOnce.Do(func() {
+		locales = make(map[string]*Locale)
+		paths, _ := localeFiles.ReadDir("locales")
+		for _, entry := range paths {
+			locale, err := parseLocale(path.Join("locales", entry.Name()))
+			if err != nil {
+				localesErr = fmt.Errorf("failed to load locale %s: %w", entry.Name(), err)
+				return
+			}
+			locales[locale.Language] = locale
+		}
+	})
+	return locales, localesErr
+}
+
+// parseLocale parses one embedded locale file
+func parseLocale(name string) (*Locale, error) {
+	data, err := localeFiles.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var file localeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.Language == "" {
+		return nil, fmt.Errorf("no language code")
+	}
+	plural, ok := pluralRules[file.PluralRule]
+	if !ok {
+		return nil, fmt.Errorf("unknown plural rule %q", file.PluralRule)
+	}
+	return &Locale{Language: file.Language, Name: file.Name, plural: plural, messages: file.Messages}, nil
+}
+
+// Languages returns the codes of the available languages, sorted
+func Languages() []string {
+	all, _ := loadLocales()
+	languages := make([]string, 0, len(all))
+	for language := range all {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Load returns the locale for a language code, or English for ""
+func Load(language string) (*Locale, error) {
+	all, err := loadLocales()
+	if err != nil {
+		return nil, err
+	}
+	if language == "" {
+		language = DefaultLanguage
+	}
+	locale, ok := all[strings.ToLower(language)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q (available: %s)", language, strings.Join(Languages(), ", "))
+	}
+	return locale, nil
+}
+
+// Default returns the English locale
+func Default() *Locale {
+	locale, err := Load(DefaultLanguage)
+	if err != nil {
+		panic(err) // The embedded English locale is checked by the tests
+	}
+	return locale
+}
+
+// or returns the locale, or English if it is nil
+func (l *Locale) or() *Locale {
+	if l == nil {
+		return Default()
+	}
+	return l
+}
+
+// Text returns a message with its placeholders filled in
+func (l *Locale) Text(key string, vars Vars) string {
+	return l.or().format(key, "other", vars)
+}
+
+// Plural returns the form of a message for count, with {count} and the other placeholders filled in
+func (l *Locale) Plural(key string, count int, vars Vars) string {
+	l = l.or()
+	filled := Vars{"count": count}
+	for name, value := range vars {
+		filled[name] = value
+	}
+	return l.format(key, l.plural(count), filled)
+}
+
+// format fills in a form of a message. Messages the locale is missing come from English, and unknown messages
+// are returned as their key so they stand out.
+func (l *Locale) format(key, form string, vars Vars) string {
+	msg, ok := l.messages[key]
+	if !ok && l.Language != DefaultLanguage {
+		english := Default()
+		msg, ok = english.messages[key]
+		form = english.plural(countOf(vars))
+	}
+	if !ok {
+		return key
+	}
+	text, ok := msg[form]
+	if !ok {
+		text = msg["other"]
+	}
+	for name, value := range vars {
+		text = strings.ReplaceAll(text, "{"+name+"}", fmt.Sprint(value))
+	}
+	return text
+}
+
+// countOf returns the count a plural message was asked for, 0 if none
+func countOf(vars Vars) int {
+	count, _ := vars["count"].(int)
+	return count
+}
+
+// PeriodName returns the name of a period, e.g. "work"
+func (l *Locale) PeriodName(period types.PeriodType) string {
+	if period == types.PeriodRest {
+		return l.Text("period.rest", nil)
+	}
+	return l.Text("period.work", nil)
+}
+
+// WorkoutComplete returns the callout for the end of the workout
+func (l *Locale) WorkoutComplete() string {
+	return l.Text("workout_complete", nil)
+}
+
+// RoundCallout returns the callout for a round, e.g. "round 1 of 8"
+func (l *Locale) RoundCallout(roundNumber, totalRounds int) string {
+	return l.Text("callout.round", Vars{"round": roundNumber, "total": totalRounds})
+}
+
+// LastRound returns the callout before the last round
+func (l *Locale) LastRound() string {
+	return l.Text("callout.last_round", nil)
+}
+
+// Halfway returns the callout halfway through a round
+func (l *Locale) Halfway() string {
+	return l.Text("callout.halfway", nil)
+}
+
+// SecondsLeft returns the warning callout for the seconds left in a round, e.g. "10 seconds"
+func (l *Locale) SecondsLeft(seconds int) string {
+	return l.Plural("callout.seconds_left", seconds, nil)
+}
+
+// Cue returns a round's coaching cue in the locale's language. Cues from the generator's fallback bank are message
+// keys ("cue.…"); cues written by the LLM are already in the workout's language and are returned as they are.
+func (l *Locale) Cue(cue string) string {
+	if !strings.HasPrefix(cue, cuePrefix) {
+		return cue
+	}
+	return l.Text(cue, nil)
+}
+
+// MoveName returns a move's name; punches are named by the hand that throws them in the stance, e.g. "left hook"
+func (l *Locale) MoveName(move models.Move, stance models.Stance) string {
+	return l.StyledMoveName(move, stance, models.CalloutFull)
+}
+
+// StyledMoveName returns a move's name in a callout style: its number (defensive moves have none, so they get
+// their short name), its name without the hand, its full name, or its full name with a coaching hint
+func (l *Locale) StyledMoveName(move models.Move, stance models.Stance, style models.CalloutStyle) string {
+	var name string
+	switch {
+	case move.IsPunch() && move.Punch != nil:
+		if style == models.CalloutNumeric {
+			return strconv.Itoa(move.Punch.Number())
+		}
+		name = move.Punch.NameForStance(stance)
+	case move.IsDefensive() && move.Defensive != nil:
+		name = move.Defensive.String()
+	default:
+		return ""
+	}
+	switch style {
+	case models.CalloutNumeric, models.CalloutShort:
+		return l.Text(messageKey("short", withoutHand(name)), nil)
+	case models.CalloutCoach:
+		return l.Text(messageKey("coach", name), nil)
+	default:
+		return l.Text(messageKey("move", name), nil)
+	}
+}
+
+// MoveNames returns the names of a combo's moves
+func (l *Locale) MoveNames(combo models.Combo, stance models.Stance) []string {
+	return l.StyledMoveNames(combo, stance, models.CalloutFull)
+}
+
+// StyledMoveNames returns the names of a combo's moves in a callout style
+func (l *Locale) StyledMoveNames(combo models.Combo, stance models.Stance, style models.CalloutStyle) []string {
+	var names []string
+	for _, move := range combo.Moves {
+		if name := l.StyledMoveName(move, stance, style); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ComboCallout returns the callout for a combo, e.g. "jab, cross, then left hook"
+func (l *Locale) ComboCallout(combo models.Combo, stance models.Stance) string {
+	return l.StyledComboCallout(combo, stance, models.CalloutFull)
+}
+
+// StyledComboCallout returns the callout for a combo in a callout style. Numbers and short names are called in
+// one breath, e.g. "1 2 3"; full names and coach style are listed, e.g. "jab, cross, then left hook".
+func (l *Locale) StyledComboCallout(combo models.Combo, stance models.Stance, style models.CalloutStyle) string {
+	names := l.StyledMoveNames(combo, stance, style)
+	if style == models.CalloutNumeric || style == models.CalloutShort || len(names) <= 1 {
+		return strings.Join(names, " ")
+	}
+	moves := strings.Join(names[:len(names)-1], l.Text("combo.separator", nil))
+	return l.Text("combo.last", Vars{"moves": moves, "last": names[len(names)-1]})
+}
+
+// messageKey returns the message key of a move's English name, e.g. "move.left_hook" for "Left Hook"
+func messageKey(prefix, name string) string {
+	return prefix + "." + strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+// withoutHand returns a move's name without the hand or side, e.g. "hook" for "left hook"
+func withoutHand(name string) string {
+	name = strings.ToLower(name)
+	for _, hand := range []string{"left ", "right "} {
+		name = strings.TrimPrefix(name, hand)
+	}
+	return name
+}
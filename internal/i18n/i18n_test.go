@@ -0,0 +1,202 @@
+package i18n
+
+import (
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// placeholders returns a message form's placeholders, sorted
+func placeholders(text string) []string {
+	found := placeholderPattern.FindAllString(text, -1)
+	sort.Strings(found)
+	return found
+}
+
+func TestLocales_MatchEnglish(t *testing.T) {
+	if got, want := Languages(), []string{"en", "es", "pt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected languages %v, got %v", want, got)
+	}
+	english := Default()
+	for _, language := range Languages() {
+		locale, err := Load(language)
+		if err != nil {
+			t.Fatalf("Load(%q) failed: %v", language, err)
+		}
+		for key, englishMsg := range english.messages {
+			msg, ok := locale.messages[key]
+			if !ok {
+				t.Errorf("%s: missing %s", language, key)
+				continue
+			}
+			for form := range englishMsg {
+				if _, ok := msg[form]; !ok {
+					t.Errorf("%s: %s has no %q form", language, key, form)
+				}
+			}
+			for form, text := range msg {
+				if got, want := placeholders(text), placeholders(englishMsg["other"]); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: %s %q form has placeholders %v, English has %v", language, key, form, got, want)
+				}
+			}
+		}
+		for key := range locale.messages {
+			if _, ok := english.messages[key]; !ok {
+				t.Errorf("%s: unknown message %s", language, key)
+			}
+		}
+	}
+}
+
+func TestLocale_Callouts(t *testing.T) {
+	combo := models.NewCombo([]models.Move{
+		models.NewPunchMove(models.Jab),
+		models.NewPunchMove(models.Cross),
+		models.NewDefensiveMove(models.LeftSlip),
+		models.NewPunchMove(models.LeadHook),
+	})
+	tests := []struct {
+		language  string
+		stance    models.Stance
+		wantCombo string
+		wantRound string
+		wantRest  string
+		wantLast  string
+	}{
+		{"en", models.Orthodox, "jab, cross, left slip, then left hook", "round 3 of 8", "rest", "last round"},
+		{"en", models.Southpaw, "jab, cross, left slip, then right hook", "round 3 of 8", "rest", "last round"},
+		{"es", models.Orthodox, "jab, directo, esquiva a la izquierda y luego gancho de izquierda", "asalto 3 de 8", "descanso", "último asalto"},
+		{"pt", models.Southpaw, "jab, direto, esquiva para a esquerda e depois gancho de direita", "assalto 3 de 8", "descanso", "último assalto"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+"/"+tt.stance.String(), func(t *testing.T) {
+			locale, err := Load(tt.language)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if got := locale.ComboCallout(combo, tt.stance); got != tt.wantCombo {
+				t.Errorf("expected combo callout %q, got %q", tt.wantCombo, got)
+			}
+			if got := locale.RoundCallout(3, 8); got != tt.wantRound {
+				t.Errorf("expected round callout %q, got %q", tt.wantRound, got)
+			}
+			if got := locale.PeriodName(types.PeriodRest); got != tt.wantRest {
+				t.Errorf("expected rest period %q, got %q", tt.wantRest, got)
+			}
+			if got := locale.LastRound(); got != tt.wantLast {
+				t.Errorf("expected last round callout %q, got %q", tt.wantLast, got)
+			}
+		})
+	}
+
+	// Two moves read "jab, then cross", as they always have
+	var english *Locale
+	two := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab), models.NewPunchMove(models.Cross)})
+	if got := english.ComboCallout(two, models.Orthodox); got != "jab, then cross" {
+		t.Errorf("expected a nil locale to speak English, got %q", got)
+	}
+}
+
+func TestLocale_CalloutStyles(t *testing.T) {
+	combo := models.NewCombo([]models.Move{
+		models.NewPunchMove(models.Jab),
+		models.NewPunchMove(models.Cross),
+		models.NewDefensiveMove(models.RightSlip),
+		models.NewPunchMove(models.LeadHook),
+	})
+	spanish, _ := Load("es")
+	tests := []struct {
+		locale *Locale
+		style  models.CalloutStyle
+		stance models.Stance
+		want   string
+	}{
+		{nil, models.CalloutNumeric, models.Orthodox, "1 2 slip 3"},
+		{nil, models.CalloutNumeric, models.Southpaw, "1 2 slip 3"}, // Numbers don't depend on the stance
+		{nil, models.CalloutShort, models.Southpaw, "jab cross slip hook"},
+		{nil, models.CalloutFull, models.Southpaw, "jab, cross, right slip, then right hook"},
+		{nil, models.CalloutCoach, models.Orthodox, "jab to the head, cross to the head, slip right off the line, then left hook to the body"},
+		{spanish, models.CalloutShort, models.Orthodox, "jab directo esquiva gancho"},
+		{spanish, models.CalloutCoach, models.Orthodox, "jab a la cabeza, directo a la cabeza, esquiva a la derecha fuera de la línea y luego gancho de izquierda al cuerpo"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.StyledComboCallout(combo, tt.stance, tt.style); got != tt.want {
+			t.Errorf("%s %s: expected %q, got %q", tt.style, tt.stance, tt.want, got)
+		}
+	}
+}
+
+func TestLocale_Plural(t *testing.T) {
+	english, _ := Load("en")
+	portuguese, _ := Load("pt")
+	tests := []struct {
+		locale *Locale
+		count  int
+		want   string
+	}{
+		{english, 0, "0 punches"},
+		{english, 1, "1 punch"},
+		{english, 2, "2 punches"},
+		{portuguese, 0, "0 golpe"}, // Portuguese counts 0 as singular
+		{portuguese, 1, "1 golpe"},
+		{portuguese, 2, "2 golpes"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.Plural("display.punches", tt.count, nil); got != tt.want {
+			t.Errorf("%s %d: expected %q, got %q", tt.locale.Language, tt.count, tt.want, got)
+		}
+	}
+
+	spanish, _ := Load("es")
+	if got := spanish.Plural("display.rounds_completed", 8, Vars{"completed": 3}); got != "3/8 asaltos completados" {
+		t.Errorf("expected the completed rounds filled in, got %q", got)
+	}
+	if got := spanish.Plural("display.seconds_rested", 1, nil); !strings.HasPrefix(got, "1 segundo de descanso") {
+		t.Errorf("expected the singular form for 1 second rested, got %q", got)
+	}
+}
+
+func TestLocale_Cue(t *testing.T) {
+	portuguese, _ := Load("pt")
+	tests := []struct {
+		name   string
+		locale *Locale
+		cue    string
+		want   string
+	}{
+		{name: "bank cue", locale: portuguese, cue: "cue.exhale", want: "Expire a cada golpe"},
+		{name: "bank cue in English", locale: nil, cue: "cue.exhale", want: "Exhale on every punch"},
+		{name: "LLM cue", locale: portuguese, cue: "Mantenha a guarda alta", want: "Mantenha a guarda alta"},
+		{name: "no cue", locale: portuguese, cue: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.Cue(tt.cue); got != tt.want {
+				t.Errorf("Cue(%q) = %q, want %q", tt.cue, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocale_Fallbacks(t *testing.T) {
+	spanish, _ := Load("ES")
+	delete(spanish.messages, "workout_complete")
+	defer func() { spanish.messages["workout_complete"] = message{"other": "entrenamiento completo"} }()
+
+	if got := spanish.WorkoutComplete(); got != "workout complete" {
+		t.Errorf("expected a missing message to fall back to English, got %q", got)
+	}
+	if got := spanish.Text("no.such.message", nil); got != "no.such.message" {
+		t.Errorf("expected an unknown message to show its key, got %q", got)
+	}
+	if _, err := Load("fr"); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
@@ -0,0 +1,116 @@
+package llmstub
+
+import (
+	"encoding/json"
+	"heavybagworkout/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Patterns for the settings the built-in workout prompt template states.
+// Custom templates that drop these lines get the defaults below.
+var (
+	totalRoundsRe      = regexp.MustCompile(`Total Rounds: (\d+)`)
+	minMovesRe         = regexp.MustCompile(`Minimum moves per combo: (\d+)`)
+	maxMovesRe         = regexp.MustCompile(`Maximum moves per combo: (\d+)`)
+	patternRe          = regexp.MustCompile(`Combo Pattern: (\w+)`)
+	includeDefensiveRe = regexp.MustCompile(`Include defensive moves: (true|false)`)
+)
+
+const (
+	defaultRounds   = 3
+	defaultMinMoves = 1
+	defaultMaxMoves = 3
+)
+
+// goalPlanJSON is the plan returned for goal-based planning prompts
+const goalPlanJSON = `{
+  "workout": {"work_duration_seconds": 60, "rest_duration_seconds": 30, "total_rounds": 3},
+  "pattern": {"type": "linear", "min_moves": 2, "max_moves": 3, "include_defensive": true},
+  "tempo": "medium",
+  "stance": "orthodox",
+  "notes": "Stub plan: three rounds building from two to three moves.",
+  "rounds": [
+    {"round_number": 1, "combo": {"moves": [1, 2]}, "cue": "Snap the jab back"},
+    {"round_number": 2, "combo": {"moves": [7, 1, 2]}, "cue": "Slip small and keep your eyes up"},
+    {"round_number": 3, "combo": {"moves": [1, 2, 3]}, "cue": "Exhale on every punch"}
+  ]
+}`
+
+type stubRound struct {
+	RoundNumber int `json:"round_number"`
+	Combo       struct {
+		Moves []int `json:"moves"`
+	} `json:"combo"`
+}
+
+// RespondToPrompt returns the rule-based response for a prompt: a fixed plan for goal-based planning prompts,
+// otherwise a workout that satisfies the rounds, move limits and pattern stated in the prompt.
+// With extraRound set the workout has one round more than requested, to exercise round count validation.
+func RespondToPrompt(prompt string, extraRound bool) string {
+	if strings.Contains(prompt, "GOAL:") {
+		return goalPlanJSON
+	}
+
+	totalRounds := intFromPrompt(prompt, totalRoundsRe, defaultRounds)
+	minMoves := intFromPrompt(prompt, minMovesRe, defaultMinMoves)
+	maxMoves := intFromPrompt(prompt, maxMovesRe, defaultMaxMoves)
+	if maxMoves < minMoves {
+		maxMoves = minMoves
+	}
+	patternType := models.PatternConstant
+	if m := patternRe.FindStringSubmatch(prompt); m != nil {
+		patternType = models.WorkoutPatternType(m[1])
+	}
+	includeDefensive := false
+	if m := includeDefensiveRe.FindStringSubmatch(prompt); m != nil {
+		includeDefensive = m[1] == "true"
+	}
+	pattern := models.NewWorkoutPattern(patternType, minMoves, maxMoves, includeDefensive)
+
+	roundCount := totalRounds
+	if extraRound {
+		roundCount++
+	}
+	rounds := make([]stubRound, 0, roundCount)
+	for i := 1; i <= roundCount; i++ {
+		moveCount := pattern.GetMovesPerRound(i, totalRounds)
+		if moveCount < minMoves {
+			moveCount = minMoves
+		}
+		if moveCount > maxMoves {
+			moveCount = maxMoves
+		}
+		rounds = append(rounds, stubRound{RoundNumber: i})
+		rounds[i-1].Combo.Moves = comboMoves(i, moveCount, includeDefensive)
+	}
+
+	data, _ := json.Marshal(map[string][]stubRound{"rounds": rounds})
+	return string(data)
+}
+
+// comboMoves builds a deterministic combo: punches cycling through 1-6, opening with a defensive move every third round
+func comboMoves(roundNumber, moveCount int, includeDefensive bool) []int {
+	moves := make([]int, 0, moveCount)
+	for j := 0; j < moveCount; j++ {
+		moves = append(moves, (roundNumber+j-1)%6+1)
+	}
+	if includeDefensive && moveCount >= 2 && roundNumber%3 == 0 {
+		moves[0] = 7 + (roundNumber/3-1)%6
+	}
+	return moves
+}
+
+// intFromPrompt returns the first number captured by re, or def if the prompt doesn't contain it
+func intFromPrompt(prompt string, re *regexp.Regexp, def int) int {
+	m := re.FindStringSubmatch(prompt)
+	if m == nil {
+		return def
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,292 @@
+// Package llmstub provides a local OpenAI-compatible chat completions server for offline testing.
+// It serves scripted or rule-based workout JSON, can inject faults (bad JSON, wrong round counts,
+// rate limits, slow responses) and records every request it receives.
+package llmstub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChatCompletionsPath is the endpoint served by the stub, relative to the base URL
+const ChatCompletionsPath = "/v1/chat/completions"
+
+// Fault is a failure the stub can inject in place of a normal response
+type Fault string
+
+const (
+	FaultNone            Fault = ""
+	FaultBadJSON         Fault = "bad_json"          // 200 with message content that isn't JSON
+	FaultWrongRoundCount Fault = "wrong_round_count" // 200 with a workout that has one round too many
+	FaultRateLimit       Fault = "rate_limit"        // 429 with an OpenAI-style error body
+	FaultServerError     Fault = "server_error"      // 500 with an OpenAI-style error body
+)
+
+// AllFaults returns every fault the stub can inject
+func AllFaults() []Fault {
+	return []Fault{FaultBadJSON, FaultWrongRoundCount, FaultRateLimit, FaultServerError}
+}
+
+// ParseFault parses a fault name (e.g. "rate_limit"). An empty string is FaultNone.
+func ParseFault(s string) (Fault, error) {
+	if s == "" {
+		return FaultNone, nil
+	}
+	for _, fault := range AllFaults() {
+		if string(fault) == s {
+			return fault, nil
+		}
+	}
+	return FaultNone, fmt.Errorf("unknown fault %q (expected one of: bad_json, wrong_round_count, rate_limit, server_error)", s)
+}
+
+// Step describes one response from the stub
+type Step struct {
+	Content string `json:"content,omitempty"`  // Assistant message content (empty = rule-based workout JSON)
+	Fault   Fault  `json:"fault,omitempty"`    // Fault to inject instead of a normal response
+	DelayMS int    `json:"delay_ms,omitempty"` // Delay before responding, in milliseconds
+}
+
+// Delay returns the step's delay as a duration
+func (s Step) Delay() time.Duration {
+	return time.Duration(s.DelayMS) * time.Millisecond
+}
+
+// RecordedRequest is a request received by the stub
+type RecordedRequest struct {
+	Time          time.Time `json:"time"`
+	Authorization string    `json:"authorization,omitempty"` // Redacted to the scheme and the key's last 4 characters
+	Model         string    `json:"model"`
+	System        string    `json:"system,omitempty"` // System message content
+	Prompt        string    `json:"prompt"`           // Last user message content
+	Step          Step      `json:"step"`             // The response the stub chose
+}
+
+// chatRequest is the subset of the OpenAI chat completions request the stub reads
+type chatRequest struct {
+	Model    string `json:"model"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+}
+
+// Stub is an http.Handler that answers OpenAI chat completions requests.
+// Scripted steps are used first, in order; once the script runs out every request gets the default step.
+type Stub struct {
+	mu          sync.Mutex
+	script      []Step
+	defaultStep Step
+	requests    []RecordedRequest
+	onRequest   func(RecordedRequest)
+	now         func() time.Time
+}
+
+// NewStub creates a stub that answers every request with rule-based workout JSON
+func NewStub() *Stub {
+	return &Stub{now: time.Now}
+}
+
+// Enqueue appends steps to the script
+func (s *Stub) Enqueue(steps ...Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.script = append(s.script, steps...)
+}
+
+// SetDefault sets the step used once the script is exhausted
+func (s *Stub) SetDefault(step Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultStep = step
+}
+
+// OnRequest sets a callback invoked for every recorded request (e.g. to write a request log)
+func (s *Stub) OnRequest(callback func(RecordedRequest)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRequest = callback
+}
+
+// Requests returns every request received so far
+func (s *Stub) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]RecordedRequest, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// ServeHTTP implements http.Handler
+func (s *Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != ChatCompletionsPath && r.URL.Path != "/chat/completions" {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("unknown endpoint %s", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "invalid_request_error", "only POST is supported")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "failed to read request body")
+		return
+	}
+	var req chatRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+
+	recorded := RecordedRequest{
+		Authorization: redactAuthorization(r.Header.Get("Authorization")),
+		Model:         req.Model,
+	}
+	for _, msg := range req.Messages {
+		switch msg.Role {
+		case "system":
+			recorded.System = msg.Content
+		case "user":
+			recorded.Prompt = msg.Content
+		}
+	}
+	step := s.record(&recorded)
+
+	if delay := step.Delay(); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return // Client gave up (e.g. its timeout fired)
+		}
+	}
+
+	switch step.Fault {
+	case FaultRateLimit:
+		writeError(w, http.StatusTooManyRequests, "rate_limit_exceeded", "Rate limit reached for requests")
+		return
+	case FaultServerError:
+		writeError(w, http.StatusInternalServerError, "server_error", "The server had an error while processing your request")
+		return
+	}
+
+	content := step.Content
+	switch {
+	case step.Fault == FaultBadJSON:
+		content = "Sure! Here is your workout: {rounds: [oops"
+	case content == "":
+		content = RespondToPrompt(recorded.Prompt, step.Fault == FaultWrongRoundCount)
+	}
+	writeCompletion(w, req.Model, recorded.Prompt, content)
+}
+
+// record stores a request and returns the step to answer it with
+func (s *Stub) record(req *RecordedRequest) Step {
+	s.mu.Lock()
+	step := s.defaultStep
+	if len(s.script) > 0 {
+		step = s.script[0]
+		s.script = s.script[1:]
+	}
+	req.Time = s.now()
+	req.Step = step
+	s.requests = append(s.requests, *req)
+	callback := s.onRequest
+	s.mu.Unlock()
+
+	if callback != nil {
+		callback(*req)
+	}
+	return step
+}
+
+// writeCompletion writes a successful chat completions response with an estimated usage block
+func writeCompletion(w http.ResponseWriter, model string, prompt string, content string) {
+	if model == "" {
+		model = "stub"
+	}
+	// Roughly 4 characters per token, close enough for exercising usage tracking
+	promptTokens := len(prompt) / 4
+	completionTokens := len(content) / 4
+	resp := map[string]interface{}{
+		"object": "chat.completion",
+		"model":  model,
+		"choices": []map[string]interface{}{
+			{
+				"index":         0,
+				"message":       map[string]string{"role": "assistant", "content": content},
+				"finish_reason": "stop",
+			},
+		},
+		"usage": map[string]int{
+			"prompt_tokens":     promptTokens,
+			"completion_tokens": completionTokens,
+			"total_tokens":      promptTokens + completionTokens,
+		},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// redactAuthorization keeps an Authorization header's scheme and the last 4 characters of its key, e.g.
+// "Bearer …abcd", so a real key never ends up in a recording
+func redactAuthorization(header string) string {
+	if header == "" {
+		return ""
+	}
+	scheme, key, found := strings.Cut(header, " ")
+	if !found {
+		scheme, key = "", scheme
+	}
+	redacted := "…"
+	if len(key) > 8 {
+		redacted += key[len(key)-4:]
+	}
+	if scheme == "" {
+		return redacted
+	}
+	return scheme + " " + redacted
+}
+
+// writeError writes an OpenAI-style error response
+func writeError(w http.ResponseWriter, status int, errType string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{
+			"message": message,
+			"type":    errType,
+		},
+	})
+}
+
+// Server is a stub running on a local httptest server
+type Server struct {
+	*Stub
+	server *httptest.Server
+}
+
+// NewServer starts a stub on a random local port. Call Close when done.
+func NewServer() *Server {
+	stub := NewStub()
+	return &Server{
+		Stub:   stub,
+		server: httptest.NewServer(stub),
+	}
+}
+
+// BaseURL returns the OpenAI-compatible base URL of the server (e.g. "http://127.0.0.1:54321/v1")
+func (s *Server) BaseURL() string {
+	return s.server.URL + "/v1"
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
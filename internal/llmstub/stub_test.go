@@ -0,0 +1,174 @@
+package llmstub
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postPrompt sends a chat completions request with the given prompt to the server
+func postPrompt(t *testing.T, srv *Server, prompt string) (int, map[string]interface{}) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"model": "gpt-4.1-nano",
+		"messages": []map[string]string{
+			{"role": "system", "content": "system prompt"},
+			{"role": "user", "content": prompt},
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPost, srv.BaseURL()+"/chat/completions", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("response is not JSON: %v (%s)", err, data)
+	}
+	return resp.StatusCode, decoded
+}
+
+// messageContent extracts the assistant message from a chat completions response
+func messageContent(t *testing.T, resp map[string]interface{}) string {
+	t.Helper()
+	choices, ok := resp["choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		t.Fatalf("response has no choices: %v", resp)
+	}
+	message := choices[0].(map[string]interface{})["message"].(map[string]interface{})
+	return message["content"].(string)
+}
+
+func TestRespondToPrompt_FollowsPromptSettings(t *testing.T) {
+	prompt := "Total Rounds: 4\nCombo Pattern: linear\n- Minimum moves per combo: 2 (total)\n- Maximum moves per combo: 4 (total)\n- Include defensive moves: true\n"
+
+	var resp struct {
+		Rounds []stubRound `json:"rounds"`
+	}
+	if err := json.Unmarshal([]byte(RespondToPrompt(prompt, false)), &resp); err != nil {
+		t.Fatalf("rule-based response is not valid JSON: %v", err)
+	}
+	if len(resp.Rounds) != 4 {
+		t.Fatalf("expected 4 rounds, got %d", len(resp.Rounds))
+	}
+	previous := 0
+	for _, round := range resp.Rounds {
+		count := len(round.Combo.Moves)
+		if count < 2 || count > 4 {
+			t.Errorf("round %d: %d moves, want 2-4", round.RoundNumber, count)
+		}
+		if count < previous {
+			t.Errorf("round %d: linear pattern decreased from %d to %d moves", round.RoundNumber, previous, count)
+		}
+		previous = count
+	}
+
+	if err := json.Unmarshal([]byte(RespondToPrompt(prompt, true)), &resp); err != nil {
+		t.Fatalf("rule-based response is not valid JSON: %v", err)
+	}
+	if len(resp.Rounds) != 5 {
+		t.Errorf("expected an extra round, got %d rounds", len(resp.Rounds))
+	}
+}
+
+func TestServer_ScriptedStepsThenDefault(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Enqueue(
+		Step{Content: `{"rounds":[]}`},
+		Step{Fault: FaultBadJSON},
+		Step{Fault: FaultRateLimit},
+	)
+
+	status, resp := postPrompt(t, srv, "Total Rounds: 2")
+	if status != http.StatusOK || messageContent(t, resp) != `{"rounds":[]}` {
+		t.Errorf("expected scripted content, got %d %v", status, resp)
+	}
+
+	status, resp = postPrompt(t, srv, "Total Rounds: 2")
+	if status != http.StatusOK || json.Valid([]byte(messageContent(t, resp))) {
+		t.Errorf("expected bad JSON content, got %d %v", status, resp)
+	}
+
+	status, resp = postPrompt(t, srv, "Total Rounds: 2")
+	if status != http.StatusTooManyRequests || resp["error"] == nil {
+		t.Errorf("expected 429 with error body, got %d %v", status, resp)
+	}
+
+	// Script exhausted: rule-based workout with a usage block
+	status, resp = postPrompt(t, srv, "Total Rounds: 2")
+	if status != http.StatusOK || !strings.Contains(messageContent(t, resp), `"round_number":2`) {
+		t.Errorf("expected rule-based workout, got %d %v", status, resp)
+	}
+	if resp["usage"] == nil {
+		t.Error("expected usage block in response")
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 recorded requests, got %d", len(requests))
+	}
+	if requests[0].Prompt != "Total Rounds: 2" || requests[0].System != "system prompt" || requests[0].Authorization != "Bearer …" {
+		t.Errorf("unexpected recorded request: %+v", requests[0])
+	}
+	if requests[2].Step.Fault != FaultRateLimit {
+		t.Errorf("expected recorded fault %q, got %q", FaultRateLimit, requests[2].Step.Fault)
+	}
+}
+
+func TestServer_SlowResponse(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.SetDefault(Step{DelayMS: 200})
+
+	start := time.Now()
+	status, _ := postPrompt(t, srv, "Total Rounds: 1")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected response to be delayed by 200ms, took %v", elapsed)
+	}
+}
+
+func TestRedactAuthorization(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"Bearer sk-proj-1234567890abcd", "Bearer …abcd"},
+		{"Bearer short", "Bearer …"},
+		{"sk-proj-1234567890abcd", "…abcd"},
+	}
+
+	for _, tt := range tests {
+		if got := redactAuthorization(tt.header); got != tt.want {
+			t.Errorf("redactAuthorization(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestParseFault(t *testing.T) {
+	for _, fault := range AllFaults() {
+		got, err := ParseFault(string(fault))
+		if err != nil || got != fault {
+			t.Errorf("ParseFault(%q) = %q, %v", fault, got, err)
+		}
+	}
+	if got, err := ParseFault(""); err != nil || got != FaultNone {
+		t.Errorf("ParseFault(\"\") = %q, %v", got, err)
+	}
+	if _, err := ParseFault("timeout"); err == nil {
+		t.Error("expected error for unknown fault")
+	}
+}
@@ -47,6 +47,18 @@ func (mr *MockAudioCueHandlerMockRecorder) PlayBeep() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlayBeep", reflect.TypeOf((*MockAudioCueHandler)(nil).PlayBeep))
 }
 
+// PlayCoachingCue mocks base method.
+func (m *MockAudioCueHandler) PlayCoachingCue(cue string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PlayCoachingCue", cue)
+}
+
+// PlayCoachingCue indicates an expected call of PlayCoachingCue.
+func (mr *MockAudioCueHandlerMockRecorder) PlayCoachingCue(cue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlayCoachingCue", reflect.TypeOf((*MockAudioCueHandler)(nil).PlayCoachingCue), cue)
+}
+
 // PlayComboCallout mocks base method.
 func (m *MockAudioCueHandler) PlayComboCallout(combo models.Combo, stance models.Stance) {
 	m.ctrl.T.Helper()
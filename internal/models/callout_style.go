@@ -0,0 +1,81 @@
+package models
+
+import "strings"
+
+// CalloutStyle determines how the moves of a combo are called out and shown
+type CalloutStyle int
+
+const (
+	CalloutFull    CalloutStyle = iota // Stance-aware names, e.g. "jab, cross, then left hook"
+	CalloutNumeric                     // Punch numbers, e.g. "1 2 3"
+	CalloutShort                       // Names without the hand, e.g. "jab cross hook"
+	CalloutCoach                       // Full names with a hint for each move, e.g. "left hook to the body"
+	CalloutUnknown                     // Invalid/unknown callout style
+)
+
+// String returns the string representation of the callout style
+func (s CalloutStyle) String() string {
+	switch s {
+	case CalloutFull:
+		return "full"
+	case CalloutNumeric:
+		return "numeric"
+	case CalloutShort:
+		return "short"
+	case CalloutCoach:
+		return "coach"
+	default:
+		return "unknown"
+	}
+}
+
+// DisplayName returns the display name for the callout style
+func (s CalloutStyle) DisplayName() string {
+	switch s {
+	case CalloutFull:
+		return "Full Names"
+	case CalloutNumeric:
+		return "Numbers (1-2-3)"
+	case CalloutShort:
+		return "Short Names"
+	case CalloutCoach:
+		return "Coach"
+	default:
+		return "Unknown"
+	}
+}
+
+// Shorter returns the next shorter callout style: coach, then full names, then short names, then numbers
+func (s CalloutStyle) Shorter() CalloutStyle {
+	switch s {
+	case CalloutCoach:
+		return CalloutFull
+	case CalloutFull:
+		return CalloutShort
+	default:
+		return CalloutNumeric
+	}
+}
+
+// ParseCalloutStyle parses a callout style string and returns the corresponding CalloutStyle value
+// Returns CalloutUnknown if the string is invalid
+// Empty string defaults to CalloutFull
+func ParseCalloutStyle(s string) CalloutStyle {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "full", "":
+		return CalloutFull
+	case "numeric", "numbers":
+		return CalloutNumeric
+	case "short":
+		return CalloutShort
+	case "coach":
+		return CalloutCoach
+	default:
+		return CalloutUnknown
+	}
+}
+
+// AllCalloutStyles returns a slice of all available callout styles, from the shortest
+func AllCalloutStyles() []CalloutStyle {
+	return []CalloutStyle{CalloutNumeric, CalloutShort, CalloutFull, CalloutCoach}
+}
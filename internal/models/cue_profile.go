@@ -0,0 +1,145 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sounds a cue profile can play
+const (
+	SoundNone    = "none"    // Nothing is played
+	SoundVoice   = "voice"   // The cue is spoken
+	SoundBeep    = "beep"    // The countdown pip
+	SoundBell    = "bell"    // The round bell
+	SoundClapper = "clapper" // The wooden clack of a gym timer's warning
+)
+
+// CueSound is what a cue profile plays for a cue: a sound, struck a number of times
+type CueSound struct {
+	Sound string // One of the Sound constants
+	Times int    // How many times the sound is struck, at least 1; spoken cues are said once
+}
+
+// Voice is the sound of a spoken cue
+var Voice = CueSound{Sound: SoundVoice, Times: 1}
+
+// ParseCueSound parses a sound such as "bell", "bell x3", "clapper", "beep", "voice" or "none".
+// Empty string is silent, like "none".
+func ParseCueSound(s string) (CueSound, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return CueSound{Sound: SoundNone}, nil
+	}
+	sound := CueSound{Sound: fields[0], Times: 1}
+	switch sound.Sound {
+	case SoundNone, SoundVoice, SoundBeep, SoundBell, SoundClapper:
+	default:
+		return CueSound{}, fmt.Errorf("sound must be one of: bell, clapper, beep, voice, none, got %q", fields[0])
+	}
+	if len(fields) > 2 {
+		return CueSound{}, fmt.Errorf("sound must be a sound with an optional count, e.g. \"bell x3\", got %q", s)
+	}
+	if len(fields) == 2 {
+		times, err := strconv.Atoi(strings.TrimPrefix(fields[1], "x"))
+		if err != nil || !strings.HasPrefix(fields[1], "x") || times < 1 {
+			return CueSound{}, fmt.Errorf("sound count must be x1 or more, e.g. \"bell x3\", got %q", fields[1])
+		}
+		if sound.Sound == SoundNone || sound.Sound == SoundVoice {
+			return CueSound{}, fmt.Errorf("%s can't be repeated, got %q", sound.Sound, s)
+		}
+		sound.Times = times
+	}
+	return sound, nil
+}
+
+// String returns the sound as ParseCueSound reads it, e.g. "bell x3"
+func (s CueSound) String() string {
+	if s.IsSilent() {
+		return SoundNone
+	}
+	if s.Times > 1 {
+		return fmt.Sprintf("%s x%d", s.Sound, s.Times)
+	}
+	return s.Sound
+}
+
+// IsSilent reports whether nothing is played
+func (s CueSound) IsSilent() bool {
+	return s.Sound == "" || s.Sound == SoundNone
+}
+
+// IsVoice reports whether the cue is spoken
+func (s CueSound) IsVoice() bool {
+	return s.Sound == SoundVoice
+}
+
+// CueProfile determines which sounds mark the moments of a round
+type CueProfile struct {
+	Name          string
+	RoundStart    CueSound      // When a work period starts; voice says "work"
+	RoundEnd      CueSound      // When a work period ends; voice says "rest"
+	Warning       CueSound      // WarningBefore the end of a work period; voice says how many seconds are left
+	WarningBefore time.Duration // How long before the end of a work period the warning plays
+	Halfway       CueSound      // Halfway through a work period; voice says "halfway"
+	LastRound     CueSound      // Before the last round is called out; voice says "last round"
+	Countdown     CueSound      // Each of the last 3 seconds of a rest period; voice counts down
+}
+
+// Names of the built-in cue profiles
+const (
+	CueProfileSpoken = "spoken"
+	CueProfileGym    = "gym"
+)
+
+// SpokenCueProfile returns the default profile: "work" and "rest" are spoken and rest periods end with countdown
+// beeps
+func SpokenCueProfile() CueProfile {
+	return CueProfile{
+		Name:       CueProfileSpoken,
+		RoundStart: Voice,
+		RoundEnd:   Voice,
+		Warning:    CueSound{Sound: SoundNone},
+		Halfway:    CueSound{Sound: SoundNone},
+		LastRound:  CueSound{Sound: SoundNone},
+		Countdown:  CueSound{Sound: SoundBeep, Times: 1},
+	}
+}
+
+// GymCueProfile returns the profile of a boxing gym's round timer: one bell starts a round, three bells end it, and
+// a clapper warns 10 seconds before the end. The last round is announced.
+func GymCueProfile() CueProfile {
+	return CueProfile{
+		Name:          CueProfileGym,
+		RoundStart:    CueSound{Sound: SoundBell, Times: 1},
+		RoundEnd:      CueSound{Sound: SoundBell, Times: 3},
+		Warning:       CueSound{Sound: SoundClapper, Times: 1},
+		WarningBefore: 10 * time.Second,
+		Halfway:       CueSound{Sound: SoundNone},
+		LastRound:     Voice,
+		Countdown:     CueSound{Sound: SoundNone},
+	}
+}
+
+// BuiltInCueProfiles returns the cue profiles that come with the app, the default first
+func BuiltInCueProfiles() []CueProfile {
+	return []CueProfile{SpokenCueProfile(), GymCueProfile()}
+}
+
+// FindCueProfile returns the named profile from custom, or the built-in profile of that name. Custom profiles can
+// replace built-in ones. Empty string is the spoken profile.
+func FindCueProfile(name string, custom []CueProfile) (CueProfile, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = CueProfileSpoken
+	}
+	var names []string
+	for _, profile := range append(append([]CueProfile(nil), custom...), BuiltInCueProfiles()...) {
+		if strings.EqualFold(profile.Name, name) {
+			return profile, nil
+		}
+		names = append(names, profile.Name)
+	}
+	return CueProfile{}, fmt.Errorf("unknown cue profile %q (available: %s)", name, strings.Join(names, ", "))
+}
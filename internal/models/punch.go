@@ -37,6 +37,12 @@ func AllPunches() []Punch {
 	return []Punch{Jab, Cross, LeadHook, RearHook, LeadUppercut, RearUppercut}
 }
 
+// Number returns the punch's number in the usual boxing numbering: 1 jab, 2 cross, 3 lead hook, 4 rear hook,
+// 5 lead uppercut, 6 rear uppercut. Numbers don't depend on the stance.
+func (p Punch) Number() int {
+	return int(p)
+}
+
 // NameForStance returns the punch name based on the boxer's stance
 // For orthodox (right-handed): left is lead, right is rear
 // For southpaw (left-handed): right is lead, left is rear
@@ -0,0 +1,62 @@
+package models
+
+import "strings"
+
+// RestMode determines how long the rest periods of a workout last
+type RestMode int
+
+const (
+	RestFixed   RestMode = iota // Each round rests for its rest duration
+	RestAuto                    // Rest lasts until the boxer is ready, between a minimum and a maximum
+	RestEarned                  // Finishing a work period early adds the time left to the round's rest
+	RestUnknown                 // Invalid/unknown rest mode
+)
+
+// String returns the string representation of the rest mode
+func (m RestMode) String() string {
+	switch m {
+	case RestFixed:
+		return "fixed"
+	case RestAuto:
+		return "auto"
+	case RestEarned:
+		return "earned"
+	default:
+		return "unknown"
+	}
+}
+
+// DisplayName returns the display name for the rest mode
+func (m RestMode) DisplayName() string {
+	switch m {
+	case RestFixed:
+		return "Fixed"
+	case RestAuto:
+		return "Auto (until ready)"
+	case RestEarned:
+		return "Earned"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseRestMode parses a rest mode string and returns the corresponding RestMode value
+// Returns RestUnknown if the string is invalid
+// Empty string defaults to RestFixed
+func ParseRestMode(s string) RestMode {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "fixed", "":
+		return RestFixed
+	case "auto":
+		return RestAuto
+	case "earned":
+		return RestEarned
+	default:
+		return RestUnknown
+	}
+}
+
+// AllRestModes returns a slice of all available rest modes
+func AllRestModes() []RestMode {
+	return []RestMode{RestFixed, RestAuto, RestEarned}
+}
@@ -9,6 +9,7 @@ type WorkoutRound struct {
 	Combo        Combo         // The combo to perform during the work period
 	WorkDuration time.Duration // Duration of the work period
 	RestDuration time.Duration // Duration of the rest period
+	Cue          string        // Short coaching cue for the round, spoken during the round's rest period (may be empty)
 }
 
 // NewWorkoutRound creates a new workout round with a single combo
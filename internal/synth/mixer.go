@@ -0,0 +1,389 @@
+package synth
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MusicSampleRate is the sample rate the mixer plays music and cues at
+const MusicSampleRate = 44100
+
+const (
+	// DefaultMusicVolume is the music's volume until SetMusicVolume is called
+	DefaultMusicVolume = 0.5
+	// DefaultDuckLevel is the fraction of its volume the music drops to while it is ducked
+	DefaultDuckLevel = 0.25
+	// musicRamp is how long the music takes to fade between volumes, so ducking doesn't click
+	musicRamp = 150 * time.Millisecond
+	// mixChunk is how often the mixer writes to its output when it runs in real time
+	mixChunk = 20 * time.Millisecond
+	// mixLead is how far ahead of real time the mixer keeps its output, so the player never runs dry
+	mixLead = 100 * time.Millisecond
+)
+
+// ErrMixerClosed is returned when playing through a mixer that has been closed
+var ErrMixerClosed = errors.New("mixer closed")
+
+// MusicSource supplies the mixer's music a track at a time, at the mixer's sample rate
+type MusicSource interface {
+	Next() ([]int16, error)
+}
+
+// Mixer owns audio playback: it mixes background music and the sounds played through it into one stream. Music
+// fades down while the mixer is ducked (e.g. under callouts) and fades out when paused. Mixer is a Sink.
+type Mixer struct {
+	mu         sync.Mutex
+	sampleRate int
+	voices     []*voice // Sounds playing over the music
+
+	music      MusicSource
+	musicErr   error   // Why the music stopped, nil while it plays
+	track      []int16 // Track playing
+	position   int     // Next sample of the track
+	upcoming   chan []int16
+	loading    bool
+	paused     bool    // The music fades out and holds its place
+	volume     float64 // Music volume when not ducked
+	duckLevel  float64
+	ducks      int     // Ducks held; the music is ducked while there are any
+	gain       float64 // Music gain right now, moving towards the target by rampStep a sample
+	rampStep   float64
+	closed     chan struct{}
+	closeOnce  sync.Once
+	player     *exec.Cmd
+	playerDone chan error
+}
+
+// voice is a sound playing over the music
+type voice struct {
+	samples  []int16
+	position int
+	done     chan struct{}
+}
+
+// NewMixer creates a mixer at a sample rate, with no music
+func NewMixer(sampleRate int) *Mixer {
+	return &Mixer{
+		sampleRate: sampleRate,
+		volume:     DefaultMusicVolume,
+		duckLevel:  DefaultDuckLevel,
+		rampStep:   1 / float64(SamplesFor(musicRamp, sampleRate)),
+		closed:     make(chan struct{}),
+	}
+}
+
+// SetMusic sets the music to play, nil for none
+func (m *Mixer) SetMusic(music MusicSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.music = music
+	m.musicErr = nil
+	m.track, m.position = nil, 0
+	m.upcoming = nil
+	m.loading = false
+}
+
+// MusicErr returns why the music stopped playing, or nil
+func (m *Mixer) MusicErr() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.musicErr
+}
+
+// SetMusicVolume sets the music's volume, from 0 to 1. The music fades to the new volume.
+func (m *Mixer) SetMusicVolume(volume float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.volume = min(max(volume, 0), 1)
+}
+
+// SetDuckLevel sets the fraction of its volume the music drops to while the mixer is ducked, from 0 to 1
+func (m *Mixer) SetDuckLevel(level float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.duckLevel = min(max(level, 0), 1)
+}
+
+// PauseMusic fades the music out and holds its place
+func (m *Mixer) PauseMusic() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = true
+}
+
+// ResumeMusic fades the music back in where it was paused
+func (m *Mixer) ResumeMusic() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = false
+}
+
+// Duck fades the music down until the returned release function is called. Ducks can overlap; the music comes
+// back up once all of them are released.
+func (m *Mixer) Duck() (release func()) {
+	m.mu.Lock()
+	m.ducks++
+	m.mu.Unlock()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			m.ducks--
+			m.mu.Unlock()
+		})
+	}
+}
+
+// Play mixes the samples over the music, returning once they have been mixed, or ErrMixerClosed if the mixer is
+// closed first
+func (m *Mixer) Play(samples []int16, sampleRate int) error {
+	return m.PlayContext(context.Background(), samples, sampleRate)
+}
+
+// PlayContext plays like Play, cutting the samples off if ctx is done first
+func (m *Mixer) PlayContext(ctx context.Context, samples []int16, sampleRate int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	v := &voice{samples: Resample(samples, sampleRate, m.sampleRate), done: make(chan struct{})}
+	m.mu.Lock()
+	select {
+	case <-m.closed:
+		m.mu.Unlock()
+		return ErrMixerClosed
+	default:
+	}
+	m.voices = append(m.voices, v)
+	m.mu.Unlock()
+
+	select {
+	case <-v.done:
+		return nil
+	case <-m.closed:
+		return ErrMixerClosed
+	case <-ctx.Done():
+		m.stopVoice(v)
+		return ctx.Err()
+	}
+}
+
+// stopVoice cuts off a sound, unless it has already finished
+func (m *Mixer) stopVoice(v *voice) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, playing := range m.voices {
+		if playing == v {
+			m.voices = append(m.voices[:i], m.voices[i+1:]...)
+			close(v.done)
+			return
+		}
+	}
+}
+
+// StopSounds cuts off the sounds playing over the music, leaving the music playing
+func (m *Mixer) StopSounds() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range m.voices {
+		close(v.done)
+	}
+	m.voices = nil
+}
+
+// Mix mixes the next n samples
+func (m *Mixer) Mix(n int) []int16 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]int16, n)
+	target := m.volume
+	if m.ducks > 0 {
+		target *= m.duckLevel
+	}
+	if m.paused {
+		target = 0
+	}
+	for i := range out {
+		// Move the music's gain towards its target, a step a sample
+		if m.gain < target {
+			m.gain = min(m.gain+m.rampStep, target)
+		} else if m.gain > target {
+			m.gain = max(m.gain-m.rampStep, target)
+		}
+
+		var sum float64
+		if !m.paused || m.gain > 0 {
+			sum = float64(m.musicSampleLocked()) * m.gain
+		}
+		for _, v := range m.voices {
+			if v.position < len(v.samples) {
+				sum += float64(v.samples[v.position])
+				v.position++
+			}
+		}
+		out[i] = int16(min(max(sum, -32768), 32767))
+	}
+
+	// Let the voices that have finished go
+	playing := m.voices[:0]
+	for _, v := range m.voices {
+		if v.position < len(v.samples) {
+			playing = append(playing, v)
+		} else {
+			close(v.done)
+		}
+	}
+	m.voices = playing
+	return out
+}
+
+// musicSampleLocked returns the next sample of the music, or silence while the next track loads. The caller must
+// hold m.mu.
+func (m *Mixer) musicSampleLocked() int16 {
+	if m.music == nil || m.musicErr != nil {
+		return 0
+	}
+	if m.position >= len(m.track) {
+		if !m.nextTrackLocked() {
+			return 0
+		}
+	}
+	sample := m.track[m.position]
+	m.position++
+	if m.position == len(m.track)/2 {
+		m.loadLocked() // Load the following track in the background while this one plays
+	}
+	return sample
+}
+
+// nextTrackLocked moves on to the next track if it has loaded, and reports whether it did. The caller must hold
+// m.mu.
+func (m *Mixer) nextTrackLocked() bool {
+	m.loadLocked()
+	select {
+	case track, ok := <-m.upcoming:
+		m.upcoming, m.loading = nil, false
+		if !ok {
+			return false // The music failed; musicErr says why
+		}
+		m.track, m.position = track, 0
+		return true
+	default:
+		return false
+	}
+}
+
+// loadLocked starts loading the next track, unless one is loading already. The caller must hold m.mu.
+func (m *Mixer) loadLocked() {
+	if m.loading || m.music == nil {
+		return
+	}
+	m.loading = true
+	music, upcoming := m.music, make(chan []int16, 1)
+	m.upcoming = upcoming
+	go func() {
+		track, err := music.Next()
+		if err != nil {
+			m.mu.Lock()
+			if m.music == music {
+				m.musicErr = err
+			}
+			m.mu.Unlock()
+			close(upcoming)
+			return
+		}
+		upcoming <- track
+	}()
+}
+
+// Run mixes in real time, writing 16-bit little-endian samples to out, until the mixer is closed or writing fails
+func (m *Mixer) Run(out io.Writer) error {
+	ticker := time.NewTicker(mixChunk)
+	defer ticker.Stop()
+	start, written := time.Now(), 0
+	buf := make([]byte, 0, 2*SamplesFor(mixChunk+mixLead, m.sampleRate))
+	for {
+		if due := SamplesFor(time.Since(start)+mixLead, m.sampleRate) - written; due > 0 {
+			buf = buf[:0]
+			for _, sample := range m.Mix(due) {
+				buf = binary.LittleEndian.AppendUint16(buf, uint16(sample))
+			}
+			if _, err := out.Write(buf); err != nil {
+				return err
+			}
+			written += due
+		}
+		select {
+		case <-m.closed:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start plays the mixer through the system's audio player, which reads the mixed stream as it is written
+func (m *Mixer) Start() error {
+	cmd, err := StreamPlayerCommand(m.sampleRate)
+	if err != nil {
+		return err
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the audio player: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the audio player: %w", err)
+	}
+	m.player, m.playerDone = cmd, make(chan error, 1)
+	go func() {
+		err := m.Run(stdin)
+		stdin.Close()
+		m.playerDone <- err
+	}()
+	return nil
+}
+
+// Close stops the mixer, cutting off the sounds still playing, and the audio player if it was started
+func (m *Mixer) Close() error {
+	m.closeOnce.Do(func() { close(m.closed) })
+	if m.player == nil {
+		return nil
+	}
+	err := <-m.playerDone
+	m.player.Process.Kill()
+	m.player.Wait()
+	m.player = nil
+	return err
+}
+
+// StreamPlayerCommand returns a command for the installed audio player that plays mono 16-bit little-endian
+// samples at sampleRate from its standard input: paplay or aplay on Linux, otherwise ffplay or sox's play
+func StreamPlayerCommand(sampleRate int) (*exec.Cmd, error) {
+	rate := fmt.Sprint(sampleRate)
+	players := [][]string{
+		{"ffplay", "-nodisp", "-autoexit", "-loglevel", "error", "-f", "s16le", "-ar", rate, "-ac", "1", "-i", "-"},
+		{"play", "-q", "-t", "raw", "-e", "signed", "-b", "16", "-L", "-r", rate, "-c", "1", "-"},
+	}
+	if runtime.GOOS == "linux" {
+		players = append([][]string{
+			{"paplay", "--raw", "--format=s16le", "--rate=" + rate, "--channels=1"},
+			{"aplay", "-q", "-t", "raw", "-f", "S16_LE", "-r", rate, "-c", "1", "-"},
+		}, players...)
+	}
+	var tried []string
+	for _, player := range players {
+		if _, err := exec.LookPath(player[0]); err == nil {
+			return exec.Command(player[0], player[1:]...), nil
+		}
+		tried = append(tried, player[0])
+	}
+	return nil, fmt.Errorf("no audio player that can stream found (tried %s)", strings.Join(tried, ", "))
+}
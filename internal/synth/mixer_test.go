@@ -0,0 +1,212 @@
+package synth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testMixerRate keeps the mixer's fades short: musicRamp is 150 samples
+const testMixerRate = 1000
+
+// constantMusic is a music source whose tracks hold one value
+type constantMusic struct {
+	value  int16
+	length int
+}
+
+func (c constantMusic) Next() ([]int16, error) {
+	track := make([]int16, c.length)
+	for i := range track {
+		track[i] = c.value
+	}
+	return track, nil
+}
+
+// startMusic mixes until the mixer's first track has loaded and faded in
+func startMusic(t *testing.T, m *Mixer) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for m.Mix(1)[0] == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("music never started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	m.Mix(SamplesFor(musicRamp, testMixerRate))
+}
+
+// waitForVoices waits until n sounds are queued to play over the music
+func waitForVoices(m *Mixer, n int) {
+	for {
+		m.mu.Lock()
+		queued := len(m.voices)
+		m.mu.Unlock()
+		if queued >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMixer_FadesMusic(t *testing.T) {
+	m := NewMixer(testMixerRate)
+	m.SetMusic(constantMusic{value: 1000, length: 100000})
+	m.SetMusicVolume(0.8)
+	m.SetDuckLevel(0.25)
+	startMusic(t, m)
+	ramp := SamplesFor(musicRamp, testMixerRate)
+
+	if got := m.Mix(1)[0]; got != 800 {
+		t.Fatalf("expected the music at its volume (800), got %d", got)
+	}
+
+	release := m.Duck()
+	if got := m.Mix(ramp / 2)[ramp/2-1]; got <= 200 || got >= 800 {
+		t.Errorf("expected the music to fade down, not jump, got %d half way", got)
+	}
+	m.Mix(ramp)
+	if got := m.Mix(1)[0]; got != 200 {
+		t.Errorf("expected the ducked music at a quarter of its volume (200), got %d", got)
+	}
+	release()
+	release() // Releasing twice is harmless
+	m.Mix(ramp)
+	if got := m.Mix(1)[0]; got != 800 {
+		t.Errorf("expected the music back at its volume, got %d", got)
+	}
+
+	m.PauseMusic()
+	m.Mix(ramp)
+	position := m.position
+	if got := m.Mix(10); !reflect.DeepEqual(got, make([]int16, 10)) || m.position != position {
+		t.Errorf("expected paused music to be silent and hold its place, got %v at %d (was %d)", got, m.position, position)
+	}
+	m.ResumeMusic()
+	m.Mix(ramp)
+	if got := m.Mix(1)[0]; got != 800 || m.position == position {
+		t.Errorf("expected the music to resume, got %d at %d", got, m.position)
+	}
+}
+
+func TestMixer_PlaysOverMusic(t *testing.T) {
+	m := NewMixer(testMixerRate)
+	done := make(chan error, 1)
+	go func() { done <- m.Play([]int16{100, -100, 32000}, testMixerRate) }()
+	waitForVoices(m, 1)
+
+	second := make(chan error, 1)
+	go func() { second <- m.Play([]int16{1000, 1000, 1000, 1000}, 2*testMixerRate) }() // Resampled to two samples
+	waitForVoices(m, 2)
+
+	if got, want := m.Mix(4), []int16{1100, 900, 32000, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the sounds mixed and clipped, %v, got %v", want, got)
+	}
+	for _, result := range []chan error{done, second} {
+		if err := <-result; err != nil {
+			t.Errorf("Play failed: %v", err)
+		}
+	}
+
+	go m.Play(make([]int16, 1000), testMixerRate)
+	waitForVoices(m, 1)
+	m.StopSounds()
+	if got := m.Mix(1); got[0] != 0 || len(m.voices) != 0 {
+		t.Errorf("expected StopSounds to cut off the sound, got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cut := make(chan error, 1)
+	go func() { cut <- m.PlayContext(ctx, make([]int16, 1000), testMixerRate) }()
+	waitForVoices(m, 1)
+	cancel()
+	if err := <-cut; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if got := m.Mix(1); got[0] != 0 || len(m.voices) != 0 {
+		t.Errorf("expected the cancelled sound to be cut off, got %v", got)
+	}
+
+	m.Close()
+	if err := m.Play([]int16{1}, testMixerRate); !errors.Is(err, ErrMixerClosed) {
+		t.Errorf("expected ErrMixerClosed after Close, got %v", err)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to write from the mixer's goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Len()
+}
+
+func TestMixer_RunsInRealTime(t *testing.T) {
+	m := NewMixer(testMixerRate)
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- m.Run(&out) }()
+	time.Sleep(100 * time.Millisecond)
+	m.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	// 100ms of samples plus the lead, with room for a slow scheduler
+	if samples := out.Len() / 2; samples < 150 || samples > 400 || out.Len()%2 != 0 {
+		t.Errorf("expected about 200 samples of 16-bit audio, got %d bytes", out.Len())
+	}
+}
+
+func TestPlaylist_PlaysInOrderAndSkipsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, samples := range map[string][]int16{"b.wav": {2, 2}, "a.wav": {1}} {
+		var wav bytes.Buffer
+		if err := WriteWAV(&wav, 8000, samples); err != nil {
+			t.Fatalf("WriteWAV failed: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), wav.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "broken.wav"), []byte("not a wav"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not music"), 0644)
+
+	playlist, err := LoadPlaylist(dir, 8000)
+	if err != nil {
+		t.Fatalf("LoadPlaylist failed: %v", err)
+	}
+	if got := len(playlist.Files()); got != 3 {
+		t.Errorf("expected the three WAV files, got %d", got)
+	}
+	var played [][]int16
+	for range 3 {
+		track, err := playlist.Next()
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		played = append(played, track)
+	}
+	if want := [][]int16{{1}, {2, 2}, {1}}; !reflect.DeepEqual(played, want) {
+		t.Errorf("expected a.wav, b.wav (skipping broken.wav), then a.wav again, got %v", played)
+	}
+
+	if _, err := LoadPlaylist(t.TempDir(), 8000); err == nil {
+		t.Error("expected an error for a folder without music")
+	}
+}
@@ -0,0 +1,115 @@
+package synth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// musicExtensions are the audio files a playlist plays. WAV files are read directly; the others are decoded with
+// ffmpeg.
+var musicExtensions = map[string]bool{".wav": true, ".mp3": true, ".flac": true}
+
+// Playlist plays the audio files of a folder in name order, starting over after the last one
+type Playlist struct {
+	mu         sync.Mutex
+	files      []string
+	next       int // Index of the next file to play
+	sampleRate int
+	decode     func(path string, sampleRate int) ([]int16, error)
+}
+
+// LoadPlaylist lists the WAV, MP3 and FLAC files in dir, to be played at sampleRate. It returns an error if there
+// are none.
+func LoadPlaylist(dir string, sampleRate int) (*Playlist, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read music folder: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && musicExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no WAV, MP3 or FLAC files in %s", dir)
+	}
+	sort.Strings(files)
+	return &Playlist{files: files, sampleRate: sampleRate, decode: DecodeAudioFile}, nil
+}
+
+// Files returns the playlist's files in the order they play
+func (p *Playlist) Files() []string {
+	return append([]string(nil), p.files...)
+}
+
+// Next decodes the next track. Files that can't be decoded are skipped; it returns an error only if none can be.
+func (p *Playlist) Next() ([]int16, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for range p.files {
+		file := p.files[p.next]
+		p.next = (p.next + 1) % len(p.files)
+		samples, err := p.decode(file, p.sampleRate)
+		if err == nil && len(samples) > 0 {
+			return samples, nil
+		}
+		if err == nil {
+			err = errors.New("no audio")
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(file), err))
+	}
+	return nil, fmt.Errorf("no playable music: %w", errors.Join(errs...))
+}
+
+// DecodeAudioFile reads an audio file as mono samples at sampleRate. WAV files are read directly; other formats
+// need ffmpeg.
+func DecodeAudioFile(path string, sampleRate int) ([]int16, error) {
+	if strings.EqualFold(filepath.Ext(path), ".wav") {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		samples, rate, err := ReadWAV(file)
+		if err != nil {
+			return nil, err
+		}
+		return Resample(samples, rate, sampleRate), nil
+	}
+
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return nil, fmt.Errorf("ffmpeg is needed to play %s files", filepath.Ext(path))
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command("ffmpeg", "-loglevel", "error", "-i", path, "-f", "wav", "-acodec", "pcm_s16le", "-ac", "1", "-ar", fmt.Sprint(sampleRate), "-")
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode with ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	samples, _, err := ReadWAV(bytes.NewReader(output))
+	return samples, err
+}
+
+// StartMusic starts a mixer at MusicSampleRate that plays the music in folder through the system's audio player
+func StartMusic(folder string) (*Mixer, *Playlist, error) {
+	playlist, err := LoadPlaylist(folder, MusicSampleRate)
+	if err != nil {
+		return nil, nil, err
+	}
+	mixer := NewMixer(MusicSampleRate)
+	mixer.SetMusic(playlist)
+	if err := mixer.Start(); err != nil {
+		return nil, nil, err
+	}
+	return mixer, playlist, nil
+}
@@ -0,0 +1,186 @@
+package synth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// DefaultSampleRate is the sample rate tones are played at
+const DefaultSampleRate = 22050
+
+// Sink plays PCM audio
+type Sink interface {
+	// Play plays mono 16-bit samples, returning once they have been played
+	Play(samples []int16, sampleRate int) error
+}
+
+// ContextSink is a Sink that can cut a sound off part way through
+type ContextSink interface {
+	Sink
+	// PlayContext plays like Play, but returns ctx's error as soon as ctx is done, cutting the sound off
+	PlayContext(ctx context.Context, samples []int16, sampleRate int) error
+}
+
+// Play renders a tone and plays it through a sink at DefaultSampleRate
+func Play(sink Sink, tone Tone) error {
+	return sink.Play(ToPCM(tone.Render(DefaultSampleRate)), DefaultSampleRate)
+}
+
+// PlayContext plays samples through a sink unless ctx is done. A ContextSink cuts the sound off once ctx is done;
+// other sinks play it through.
+func PlayContext(ctx context.Context, sink Sink, samples []int16, sampleRate int) error {
+	if contextSink, ok := sink.(ContextSink); ok {
+		return contextSink.PlayContext(ctx, samples, sampleRate)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return sink.Play(samples, sampleRate)
+}
+
+// RunContext runs a command and waits for it, killing it if ctx is done first. It doesn't start the command if ctx
+// is already done.
+func RunContext(ctx context.Context, cmd *exec.Cmd) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { cmd.Process.Kill() })
+	err := cmd.Wait()
+	if !stop() {
+		return ctx.Err() // Killed
+	}
+	return err
+}
+
+// BufferSink collects everything played through it, back to back, so it can be written out as one WAV file
+type BufferSink struct {
+	mu         sync.Mutex
+	samples    []int16
+	sampleRate int
+}
+
+// NewBufferSink creates an empty buffer sink
+func NewBufferSink() *BufferSink {
+	return &BufferSink{}
+}
+
+// Play appends the samples to the buffer. Every sound played must have the same sample rate.
+func (b *BufferSink) Play(samples []int16, sampleRate int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.sampleRate != 0 && b.sampleRate != sampleRate {
+		return fmt.Errorf("sample rate %d doesn't match the buffer's %d", sampleRate, b.sampleRate)
+	}
+	b.sampleRate = sampleRate
+	b.samples = append(b.samples, samples...)
+	return nil
+}
+
+// Samples returns everything played so far and its sample rate
+func (b *BufferSink) Samples() ([]int16, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]int16(nil), b.samples...), b.sampleRate
+}
+
+// WriteWAV writes everything played so far as a WAV file
+func (b *BufferSink) WriteWAV(w io.Writer) error {
+	samples, sampleRate := b.Samples()
+	if sampleRate == 0 {
+		sampleRate = DefaultSampleRate
+	}
+	return WriteWAV(w, sampleRate, samples)
+}
+
+// PlayerSink plays sounds with the system's audio player (afplay on macOS, paplay or aplay on Linux,
+// Media.SoundPlayer on Windows), through a temporary WAV file
+type PlayerSink struct {
+	command func(path string) *exec.Cmd
+	run     func(ctx context.Context, cmd *exec.Cmd) error
+}
+
+// NewPlayerSink returns a sink for the audio player installed on this system, or an error if there is none
+func NewPlayerSink() (*PlayerSink, error) {
+	var command func(path string) *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("afplay"); err == nil {
+			command = func(path string) *exec.Cmd { return exec.Command("afplay", path) }
+		}
+	case "windows":
+		if _, err := exec.LookPath("powershell"); err == nil {
+			command = func(path string) *exec.Cmd {
+				return exec.Command("powershell", "-c", fmt.Sprintf("(New-Object Media.SoundPlayer '%s').PlaySync()", strings.ReplaceAll(path, "'", "''")))
+			}
+		}
+	default:
+		for _, player := range []string{"paplay", "aplay"} {
+			if _, err := exec.LookPath(player); err == nil {
+				command = func(path string) *exec.Cmd {
+					if player == "aplay" {
+						return exec.Command(player, "-q", path)
+					}
+					return exec.Command(player, path)
+				}
+				break
+			}
+		}
+	}
+	if command == nil {
+		return nil, fmt.Errorf("no audio player found for %s", runtime.GOOS)
+	}
+	return &PlayerSink{command: command, run: RunContext}, nil
+}
+
+// SetRunner sets how the player command is run, e.g. to track it so it can be cancelled.
+// The runner must return once the command has finished, and kill it if ctx is done first.
+func (p *PlayerSink) SetRunner(run func(ctx context.Context, cmd *exec.Cmd) error) {
+	p.run = run
+}
+
+// Play writes the samples to a temporary WAV file and plays it
+func (p *PlayerSink) Play(samples []int16, sampleRate int) error {
+	return p.PlayContext(context.Background(), samples, sampleRate)
+}
+
+// PlayContext plays like Play, killing the player if ctx is done first
+func (p *PlayerSink) PlayContext(ctx context.Context, samples []int16, sampleRate int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp("", "heavybag-sound-*.wav")
+	if err != nil {
+		return fmt.Errorf("failed to create sound file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	writer := bufio.NewWriter(file)
+	err = WriteWAV(writer, sampleRate, samples)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write sound file: %w", err)
+	}
+
+	if err := p.run(ctx, p.command(file.Name())); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("failed to play sound: %w", err)
+	}
+	return nil
+}
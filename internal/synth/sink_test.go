@@ -0,0 +1,79 @@
+package synth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBufferSink_PlaysBackToBack(t *testing.T) {
+	sink := NewBufferSink()
+	if err := sink.Play([]int16{1, 2}, 8000); err != nil {
+		t.Fatalf("Play failed: %v", err)
+	}
+	if err := sink.Play([]int16{3}, 8000); err != nil {
+		t.Fatalf("Play failed: %v", err)
+	}
+	if err := sink.Play([]int16{4}, 16000); err == nil {
+		t.Error("expected an error playing a different sample rate")
+	}
+
+	samples, rate := sink.Samples()
+	if want := []int16{1, 2, 3}; rate != 8000 || !reflect.DeepEqual(samples, want) {
+		t.Errorf("expected %v at 8000Hz, got %v at %dHz", want, samples, rate)
+	}
+}
+
+func TestPlayerSink_PlaysAWAVFile(t *testing.T) {
+	var played []int16
+	var playedRate int
+	sink := &PlayerSink{
+		command: func(path string) *exec.Cmd { return exec.Command("player", path) },
+		run: func(ctx context.Context, cmd *exec.Cmd) error {
+			file, err := os.Open(cmd.Args[1])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			played, playedRate, err = ReadWAV(file)
+			return err
+		},
+	}
+
+	if err := sink.Play([]int16{10, -10, 20}, 22050); err != nil {
+		t.Fatalf("Play failed: %v", err)
+	}
+	if want := []int16{10, -10, 20}; playedRate != 22050 || !reflect.DeepEqual(played, want) {
+		t.Errorf("expected the player to get %v at 22050Hz, got %v at %dHz", want, played, playedRate)
+	}
+}
+
+func TestRunContext_KillsTheCommand(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not found")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	cmd := exec.Command("sleep", "10")
+	go func() { done <- RunContext(ctx, cmd) }()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the command to be killed")
+	}
+
+	started := exec.Command("sleep", "10")
+	if err := RunContext(ctx, started); !errors.Is(err, context.Canceled) || started.Process != nil {
+		t.Errorf("expected the command not to start once the context is done, got %v", err)
+	}
+}
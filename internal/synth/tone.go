@@ -0,0 +1,164 @@
+// Package synth synthesizes the workout's bells, countdown pips and tempo clicks as PCM,
+// so they sound the same on every OS.
+package synth
+
+import (
+	"math"
+	"time"
+)
+
+// Envelope shapes a tone's volume over time: it rises linearly to full volume over Attack, falls to the
+// Sustain level over Decay, holds, then fades out over the last Release of the tone
+type Envelope struct {
+	Attack  time.Duration
+	Decay   time.Duration
+	Sustain float64 // Level held after the decay, from 0 to 1
+	Release time.Duration
+}
+
+// Partial is a sine wave mixed into a tone, at a multiple of its frequency
+type Partial struct {
+	Ratio     float64 // Frequency as a multiple of the tone's
+	Amplitude float64 // Level relative to the fundamental
+	Damping   float64 // How much faster than the fundamental it dies away, 0 to follow the envelope only
+}
+
+// Tone describes a synthesized sound
+type Tone struct {
+	Frequency float64 // Pitch of the fundamental in Hz
+	Duration  time.Duration
+	Volume    float64 // Peak level, from 0 to 1
+	Envelope  Envelope
+	Partials  []Partial // Overtones mixed with the fundamental, none for a pure sine
+}
+
+// Bell returns the round bell: a ringing metallic strike with inharmonic overtones
+func Bell() Tone {
+	return Tone{
+		Frequency: 830,
+		Duration:  1500 * time.Millisecond,
+		Volume:    0.6,
+		Envelope:  Envelope{Attack: 2 * time.Millisecond, Decay: 1400 * time.Millisecond, Sustain: 0, Release: 100 * time.Millisecond},
+		Partials: []Partial{
+			{Ratio: 2.76, Amplitude: 0.5, Damping: 2},
+			{Ratio: 5.40, Amplitude: 0.25, Damping: 4},
+			{Ratio: 8.93, Amplitude: 0.12, Damping: 6},
+		},
+	}
+}
+
+// Pip returns the countdown pip: a short, clean beep
+func Pip() Tone {
+	return Tone{
+		Frequency: 880,
+		Duration:  150 * time.Millisecond,
+		Volume:    0.5,
+		Envelope:  Envelope{Attack: 10 * time.Millisecond, Sustain: 1, Release: 10 * time.Millisecond},
+	}
+}
+
+// Click returns the tempo click: a percussive tick that cuts through music
+func Click() Tone {
+	return Tone{
+		Frequency: 2000,
+		Duration:  30 * time.Millisecond,
+		Volume:    0.6,
+		Envelope:  Envelope{Attack: time.Millisecond, Decay: 25 * time.Millisecond, Sustain: 0, Release: 4 * time.Millisecond},
+		Partials:  []Partial{{Ratio: 1.5, Amplitude: 0.4, Damping: 1}},
+	}
+}
+
+// Clapper returns the warning clapper: the dry wooden clack of a gym timer's sticks
+func Clapper() Tone {
+	return Tone{
+		Frequency: 1250,
+		Duration:  60 * time.Millisecond,
+		Volume:    0.7,
+		Envelope:  Envelope{Attack: time.Millisecond, Decay: 45 * time.Millisecond, Sustain: 0, Release: 10 * time.Millisecond},
+		Partials: []Partial{
+			{Ratio: 1.73, Amplitude: 0.8, Damping: 20},
+			{Ratio: 2.91, Amplitude: 0.5, Damping: 40},
+			{Ratio: 4.37, Amplitude: 0.3, Damping: 60},
+		},
+	}
+}
+
+// Strikes renders a tone struck the given number of times, each strike starting interval after the one before.
+// Strikes closer together than the tone is long ring over each other, like a bell rung three times.
+func Strikes(tone Tone, times int, interval time.Duration, sampleRate int) []float64 {
+	strike := tone.Render(sampleRate)
+	if times <= 1 {
+		return strike
+	}
+	step := SamplesFor(interval, sampleRate)
+	samples := make([]float64, (times-1)*step+len(strike))
+	for i := range times {
+		for j, sample := range strike {
+			samples[i*step+j] += sample
+		}
+	}
+	return samples
+}
+
+// Render synthesizes the tone at the given sample rate, as samples from -1 to 1.
+// Explicit float64 conversions stop the compiler fusing multiply-adds, so every platform renders the same samples.
+func (t Tone) Render(sampleRate int) []float64 {
+	samples := make([]float64, SamplesFor(t.Duration, sampleRate))
+	total := 1.0
+	for _, partial := range t.Partials {
+		total += partial.Amplitude
+	}
+	for i := range samples {
+		seconds := float64(i) / float64(sampleRate)
+		value := math.Sin(float64(2*math.Pi*t.Frequency) * seconds)
+		for _, partial := range t.Partials {
+			level := partial.Amplitude
+			if partial.Damping > 0 {
+				level = float64(level * math.Exp(float64(-partial.Damping*seconds)))
+			}
+			value += float64(level * math.Sin(float64(2*math.Pi*t.Frequency*partial.Ratio)*seconds))
+		}
+		samples[i] = float64(t.Volume*t.Envelope.level(i, len(samples), sampleRate)) * float64(value/total)
+	}
+	return samples
+}
+
+// level returns the envelope's level at sample i of a tone n samples long
+func (e Envelope) level(i, n, sampleRate int) float64 {
+	attack, decay, release := SamplesFor(e.Attack, sampleRate), SamplesFor(e.Decay, sampleRate), SamplesFor(e.Release, sampleRate)
+	level := e.Sustain
+	switch {
+	case i < attack:
+		level = float64(i) / float64(attack)
+	case i < attack+decay:
+		progress := float64(i-attack) / float64(decay)
+		level = 1 - float64(progress*(1-e.Sustain))
+	}
+	if tail := n - 1 - i; tail < release {
+		level = float64(level*float64(tail)) / float64(release)
+	}
+	return level
+}
+
+// ToPCM converts samples from -1 to 1 to 16-bit PCM, clipping anything too loud
+func ToPCM(samples []float64) []int16 {
+	pcm := make([]int16, len(samples))
+	for i, sample := range samples {
+		pcm[i] = int16(math.Round(max(-1, min(1, sample)) * math.MaxInt16))
+	}
+	return pcm
+}
+
+// FromPCM converts 16-bit PCM to samples from -1 to 1
+func FromPCM(pcm []int16) []float64 {
+	samples := make([]float64, len(pcm))
+	for i, sample := range pcm {
+		samples[i] = float64(sample) / math.MaxInt16
+	}
+	return samples
+}
+
+// SamplesFor returns the number of samples in a duration at a sample rate
+func SamplesFor(d time.Duration, sampleRate int) int {
+	return int(int64(d) * int64(sampleRate) / int64(time.Second))
+}
@@ -0,0 +1,112 @@
+package synth
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+// rms returns the root mean square level of samples
+func rms(samples []float64) float64 {
+	var sum float64
+	for _, sample := range samples {
+		sum += sample * sample
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+func TestTone_Presets(t *testing.T) {
+	tests := []struct {
+		name string
+		tone Tone
+	}{
+		{"bell", Bell()},
+		{"pip", Pip()},
+		{"click", Click()},
+		{"clapper", Clapper()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := NewBufferSink()
+			if err := Play(sink, tt.tone); err != nil {
+				t.Fatalf("Play failed: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := sink.WriteWAV(&buf); err != nil {
+				t.Fatalf("WriteWAV failed: %v", err)
+			}
+			pcm, rate, err := ReadWAV(&buf)
+			if err != nil {
+				t.Fatalf("ReadWAV failed: %v", err)
+			}
+
+			if want := SamplesFor(tt.tone.Duration, DefaultSampleRate); rate != DefaultSampleRate || len(pcm) != want {
+				t.Fatalf("expected %d samples at %dHz, got %d at %dHz", want, DefaultSampleRate, len(pcm), rate)
+			}
+			samples := FromPCM(pcm)
+			peak := 0.0
+			for _, sample := range samples {
+				peak = max(peak, math.Abs(sample))
+			}
+			if peak == 0 || peak > tt.tone.Volume+0.001 {
+				t.Errorf("expected a peak level up to %v, got %v", tt.tone.Volume, peak)
+			}
+			if samples[0] != 0 || samples[len(samples)-1] != 0 {
+				t.Errorf("expected the envelope to start and end silent, got %v and %v", samples[0], samples[len(samples)-1])
+			}
+		})
+	}
+}
+
+func TestTone_BellRingsOut(t *testing.T) {
+	samples := Bell().Render(DefaultSampleRate)
+	window := SamplesFor(100*time.Millisecond, DefaultSampleRate)
+	strike, tail := rms(samples[:window]), rms(samples[len(samples)/2:len(samples)/2+window])
+	if tail >= strike/2 {
+		t.Errorf("expected the bell to die away, strike level %v, level halfway %v", strike, tail)
+	}
+}
+
+func TestStrikes(t *testing.T) {
+	strike := Bell().Render(DefaultSampleRate)
+	if got := Strikes(Bell(), 1, time.Second, DefaultSampleRate); len(got) != len(strike) {
+		t.Errorf("expected one strike to be the tone, got %d samples", len(got))
+	}
+
+	samples := Strikes(Bell(), 3, 400*time.Millisecond, DefaultSampleRate)
+	step := SamplesFor(400*time.Millisecond, DefaultSampleRate)
+	if want := 2*step + len(strike); len(samples) != want {
+		t.Fatalf("expected %d samples, got %d", want, len(samples))
+	}
+	if tail := len(strike) - step/2; samples[2*step+tail] != strike[tail] {
+		t.Error("expected the last strike to ring out alone")
+	}
+	if samples[step+10] == strike[step+10] {
+		t.Error("expected the second strike to ring over the first")
+	}
+}
+
+func TestEnvelope_Level(t *testing.T) {
+	envelope := Envelope{Attack: 10 * time.Millisecond, Decay: 10 * time.Millisecond, Sustain: 0.5, Release: 10 * time.Millisecond}
+	tests := []struct {
+		sample int
+		want   float64
+	}{
+		{0, 0},
+		{5, 0.5},   // Halfway up the attack
+		{10, 1},    // Peak
+		{15, 0.75}, // Halfway through the decay
+		{20, 0.5},  // Sustain
+		{34, 0.5},
+		{44, 0.25}, // Into the release
+		{49, 0},
+	}
+
+	for _, tt := range tests {
+		if got := envelope.level(tt.sample, 50, 1000); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("level at sample %d: expected %v, got %v", tt.sample, tt.want, got)
+		}
+	}
+}
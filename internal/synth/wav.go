@@ -0,0 +1,142 @@
+package synth
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WriteWAV writes mono 16-bit PCM samples as a WAV file
+func WriteWAV(w io.Writer, sampleRate int, samples []int16) error {
+	dataSize := uint32(len(samples) * 2)
+	header := struct {
+		Riff          [4]byte
+		Size          uint32
+		Wave          [4]byte
+		Fmt           [4]byte
+		FmtSize       uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+		Data          [4]byte
+		DataSize      uint32
+	}{
+		Riff:          [4]byte{'R', 'I', 'F', 'F'},
+		Size:          36 + dataSize,
+		Wave:          [4]byte{'W', 'A', 'V', 'E'},
+		Fmt:           [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		Format:        1, // PCM
+		Channels:      1,
+		SampleRate:    uint32(sampleRate),
+		ByteRate:      uint32(sampleRate * 2),
+		BlockAlign:    2,
+		BitsPerSample: 16,
+		Data:          [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      dataSize,
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return fmt.Errorf("failed to write WAV header: %w", err)
+	}
+	if err := binary.Write(w, binary.LittleEndian, samples); err != nil {
+		return fmt.Errorf("failed to write WAV samples: %w", err)
+	}
+	return nil
+}
+
+// ReadWAV reads a 16-bit PCM WAV file, mixing stereo down to mono. It returns the samples and their sample rate.
+func ReadWAV(r io.Reader) ([]int16, int, error) {
+	var riff struct {
+		Riff [4]byte
+		Size uint32
+		Wave [4]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &riff); err != nil {
+		return nil, 0, fmt.Errorf("failed to read WAV header: %w", err)
+	}
+	if string(riff.Riff[:]) != "RIFF" || string(riff.Wave[:]) != "WAVE" {
+		return nil, 0, errors.New("not a WAV file")
+	}
+
+	var channels, bitsPerSample uint16
+	var sampleRate uint32
+	for {
+		var chunk struct {
+			ID   [4]byte
+			Size uint32
+		}
+		if err := binary.Read(r, binary.LittleEndian, &chunk); err != nil {
+			return nil, 0, fmt.Errorf("failed to read WAV chunk: %w", err)
+		}
+		switch string(chunk.ID[:]) {
+		case "fmt ":
+			var format struct {
+				Format        uint16
+				Channels      uint16
+				SampleRate    uint32
+				ByteRate      uint32
+				BlockAlign    uint16
+				BitsPerSample uint16
+			}
+			if err := binary.Read(r, binary.LittleEndian, &format); err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV format: %w", err)
+			}
+			if format.Format != 1 || format.BitsPerSample != 16 || format.Channels < 1 || format.Channels > 2 {
+				return nil, 0, fmt.Errorf("unsupported WAV format (format %d, %d channels, %d bits): only 16-bit PCM mono or stereo is supported",
+					format.Format, format.Channels, format.BitsPerSample)
+			}
+			channels, sampleRate, bitsPerSample = format.Channels, format.SampleRate, format.BitsPerSample
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size)-16+int64(chunk.Size%2)); err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV format: %w", err)
+			}
+		case "data":
+			if bitsPerSample == 0 {
+				return nil, 0, errors.New("WAV data before its format")
+			}
+			// Engines writing to a pipe can't know the size up front and leave it at its maximum
+			data, err := io.ReadAll(io.LimitReader(r, int64(chunk.Size)))
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV samples: %w", err)
+			}
+			frames := len(data) / (2 * int(channels))
+			samples := make([]int16, frames)
+			for i := range samples {
+				var sum int
+				for c := 0; c < int(channels); c++ {
+					offset := (i*int(channels) + c) * 2
+					sum += int(int16(binary.LittleEndian.Uint16(data[offset:])))
+				}
+				samples[i] = int16(sum / int(channels))
+			}
+			return samples, int(sampleRate), nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size)+int64(chunk.Size%2)); err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV chunk: %w", err)
+			}
+		}
+	}
+}
+
+// Resample converts samples from one sample rate to another by linear interpolation
+func Resample(samples []int16, from, to int) []int16 {
+	if from == to || from <= 0 || len(samples) == 0 {
+		return samples
+	}
+	out := make([]int16, int(int64(len(samples))*int64(to)/int64(from)))
+	for i := range out {
+		position := float64(i) * float64(from) / float64(to)
+		index := int(position)
+		if index >= len(samples)-1 {
+			out[i] = samples[len(samples)-1]
+			continue
+		}
+		fraction := position - float64(index)
+		step := float64(samples[index+1]) - float64(samples[index])
+		out[i] = int16(float64(samples[index]) + float64(fraction*step))
+	}
+	return out
+}
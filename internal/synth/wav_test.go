@@ -0,0 +1,67 @@
+package synth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestWAV_RoundTrip(t *testing.T) {
+	samples := []int16{0, 1000, -1000, 32767, -32768}
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, 8000, samples); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+	if buf.Len() != 44+2*len(samples) {
+		t.Errorf("expected a 44 byte header and %d bytes of samples, got %d bytes", 2*len(samples), buf.Len())
+	}
+
+	got, rate, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatalf("ReadWAV failed: %v", err)
+	}
+	if rate != 8000 || !reflect.DeepEqual(got, samples) {
+		t.Errorf("expected %v at 8000Hz, got %v at %dHz", samples, got, rate)
+	}
+}
+
+func TestReadWAV_StereoWithExtraChunks(t *testing.T) {
+	var buf bytes.Buffer
+	write := func(v any) { binary.Write(&buf, binary.LittleEndian, v) }
+	buf.WriteString("RIFF")
+	write(uint32(0))
+	buf.WriteString("WAVE")
+	buf.WriteString("LIST")
+	write(uint32(3))
+	buf.Write([]byte{1, 2, 3, 0}) // Odd-sized chunk with its padding byte
+	buf.WriteString("fmt ")
+	write(uint32(16))
+	write([]uint16{1, 2})
+	write([]uint32{22050, 22050 * 4})
+	write([]uint16{4, 16})
+	buf.WriteString("data")
+	write(uint32(8))
+	write([]int16{100, 300, -100, -300})
+
+	got, rate, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatalf("ReadWAV failed: %v", err)
+	}
+	if want := []int16{200, -200}; rate != 22050 || !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v at 22050Hz, got %v at %dHz", want, got, rate)
+	}
+}
+
+func TestReadWAV_RejectsOtherFormats(t *testing.T) {
+	if _, _, err := ReadWAV(bytes.NewReader([]byte("not a wav file at all"))); err == nil {
+		t.Error("expected an error reading a file that isn't a WAV file")
+	}
+}
+
+func TestResample(t *testing.T) {
+	got := Resample([]int16{0, 100, 200, 300}, 2, 4)
+	if want := []int16{0, 50, 100, 150, 200, 250, 300, 300}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,60 @@
+package timer
+
+import (
+	"context"
+	"sync"
+)
+
+// ContextAudioCueHandler is implemented by audio handlers whose cues can be tied to a context. Once the context is
+// done, the cue playing is cut off and no more are played. The workout timer ties its handler to each run, so
+// nothing plays after the run is stopped.
+type ContextAudioCueHandler interface {
+	SetContext(ctx context.Context)
+}
+
+// audioContext is the context an audio handler's cues observe. Each cue takes the current context when it starts;
+// stop cancels it, cutting off every cue in flight, and moves on to a new one for the cues played after. The zero
+// value is ready to use, with no parent.
+type audioContext struct {
+	mu     sync.Mutex
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// current returns the context a cue starting now observes
+func (c *audioContext) current() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx == nil {
+		c.renewLocked()
+	}
+	return c.ctx
+}
+
+// setParent ties the cues to ctx, cutting off the ones in flight
+func (c *audioContext) setParent(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.parent = ctx
+	c.renewLocked()
+}
+
+// stop cuts off the cues in flight
+func (c *audioContext) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.renewLocked()
+}
+
+// renewLocked cancels the current context and replaces it. The caller must hold c.mu.
+func (c *audioContext) renewLocked() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	parent := c.parent
+	if parent == nil {
+		parent = context.Background()
+	}
+	c.ctx, c.cancel = context.WithCancel(parent)
+}
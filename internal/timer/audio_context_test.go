@@ -0,0 +1,163 @@
+package timer
+
+import (
+	"context"
+	"errors"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+// holdingSink counts the sounds played through it and holds each one until its context is done
+type holdingSink struct {
+	mu      sync.Mutex
+	plays   int
+	playing chan struct{}
+}
+
+func newHoldingSink() *holdingSink {
+	return &holdingSink{playing: make(chan struct{}, 10)}
+}
+
+func (s *holdingSink) Play(samples []int16, sampleRate int) error {
+	return s.PlayContext(context.Background(), samples, sampleRate)
+}
+
+func (s *holdingSink) PlayContext(ctx context.Context, samples []int16, sampleRate int) error {
+	s.mu.Lock()
+	s.plays++
+	s.mu.Unlock()
+	s.playing <- struct{}{}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *holdingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.plays
+}
+
+// returnsSoon fails the test unless done returns within a second
+func returnsSoon(t *testing.T, what string, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected %s to be cut off", what)
+	}
+}
+
+func TestDefaultAudioCueHandler_StopCutsOffCues(t *testing.T) {
+	sink := newHoldingSink()
+	audio := &DefaultAudioCueHandler{enabled: true, sink: sink}
+
+	done := make(chan struct{})
+	go func() {
+		audio.PlayWorkoutStart()
+		close(done)
+	}()
+	<-sink.playing
+	audio.Stop()
+	returnsSoon(t, "the bell", done)
+
+	// Stop only cuts off the cues in flight
+	done = make(chan struct{})
+	go func() {
+		audio.PlayBeep()
+		close(done)
+	}()
+	<-sink.playing
+	audio.Stop()
+	returnsSoon(t, "the beep", done)
+	if got := sink.count(); got != 2 {
+		t.Errorf("expected 2 sounds played, got %d", got)
+	}
+}
+
+func TestDefaultAudioCueHandler_NothingPlaysOnceTheContextIsDone(t *testing.T) {
+	pack, err := LoadSamplePack(generateTestSamplePack(t, 3))
+	if err != nil {
+		t.Fatalf("LoadSamplePack failed: %v", err)
+	}
+	sink := newHoldingSink()
+	handlers := map[string]AudioCueHandler{
+		"default": &DefaultAudioCueHandler{enabled: true, sink: sink},
+		"file":    &FileAudioCueHandler{DefaultAudioCueHandler: &DefaultAudioCueHandler{enabled: true, sink: sink}, pack: pack},
+		"recording": &RecordingAudioCueHandler{
+			baseHandler: &DefaultAudioCueHandler{enabled: true, sink: sink},
+		},
+	}
+	for name, audio := range handlers {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			audio.(ContextAudioCueHandler).SetContext(ctx)
+			cancel()
+
+			audio.PlayBeep()
+			audio.PlayPeriodTransition(types.PeriodRest)
+			audio.PlayComboCallout(models.NewCombo([]models.Move{models.NewPunchMove(models.Jab)}), models.Orthodox)
+			audio.PlayRoundCallout(1, 3)
+			audio.PlayCoachingCue("breathe")
+			PlayTempoBeat(audio)
+			if got := sink.count(); got != 0 {
+				t.Errorf("expected nothing played once the context is done, got %d sounds", got)
+			}
+		})
+	}
+}
+
+func TestDefaultAudioCueHandler_StopKillsSpeech(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not found")
+	}
+	engine, err := NewTTSEngine(TTSCommandEngine, TTSSettings{SpeakCommand: "sleep 10 {text}"})
+	if err != nil {
+		t.Fatalf("NewTTSEngine failed: %v", err)
+	}
+	audio := &DefaultAudioCueHandler{enabled: true, tts: engine}
+
+	done := make(chan struct{})
+	go func() {
+		audio.PlayCoachingCue("1")
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for audio.RunningCommandsCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	audio.Stop()
+	returnsSoon(t, "the speech", done)
+	if got := audio.RunningCommandsCount(); got != 0 {
+		t.Errorf("expected no commands running after Stop, got %d", got)
+	}
+}
+
+func TestWorkoutTimer_StopCutsOffTheRunsAudio(t *testing.T) {
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(time.Second, time.Second, 1),
+		[]models.WorkoutRound{models.NewWorkoutRound(1, models.NewCombo([]models.Move{}), time.Second, time.Second)},
+	)
+	sink := newHoldingSink()
+	audio := &DefaultAudioCueHandler{enabled: true, sink: sink}
+	timer := NewWorkoutTimer(workout)
+	timer.SetAudioHandler(audio)
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	<-sink.playing // The bell holds the run until it is stopped
+	timer.Stop()
+
+	ctx := audio.cues.current()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Error("expected the handler's cues to be cancelled with the run")
+	}
+	audio.PlayBeep()
+	if got := sink.count(); got != 1 {
+		t.Errorf("expected nothing played after Stop, got %d sounds", got)
+	}
+}
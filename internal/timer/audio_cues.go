@@ -1,34 +1,86 @@
 package timer
 
 import (
+	"context"
 	"fmt"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
 	"heavybagworkout/internal/types"
-	"os"
 	"os/exec"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
 )
 
-// DefaultAudioCueHandler provides basic audio cues using system beep
+// DefaultAudioCueHandler provides basic audio cues: synthesized bells, pips and clicks, and text-to-speech
 type DefaultAudioCueHandler struct {
 	enabled     bool
 	runningCmds []*exec.Cmd
 	cmdsMutex   sync.Mutex
+	sink        synth.Sink // Plays the synthesized sounds, nil to fall back to the terminal bell
+	tts         TTSEngine  // Speaks the callouts, nil for silent callouts
+	locale      *i18n.Locale
+	style       models.CalloutStyle // How combos are called out
+	tempo       time.Duration       // Interval between beats the combo callouts should fit in, 0 for no limit
+	mixer       *synth.Mixer        // Plays the cues over background music, nil for none
+	musicVolume float64
+	musicRest   MusicRest
+	cues        audioContext // Cut off by Stop
 }
 
-// NewDefaultAudioCueHandler creates a new default audio cue handler
+// NewDefaultAudioCueHandler creates a new default audio cue handler, playing its sounds with the system's
+// audio player and speaking with the first text-to-speech engine found, if there are any
 func NewDefaultAudioCueHandler(enabled bool) *DefaultAudioCueHandler {
-	return &DefaultAudioCueHandler{
+	a := &DefaultAudioCueHandler{
 		enabled:     enabled,
 		runningCmds: make([]*exec.Cmd, 0),
 	}
+	if player, err := synth.NewPlayerSink(); err == nil {
+		// Track the player like any other audio command, so Stop cuts it off
+		player.SetRunner(a.trackAndWaitCommand)
+		a.sink = player
+	}
+	a.tts, _, _ = SelectTTSEngine("auto", TTSSettings{})
+	return a
+}
+
+// SetTTSEngine sets the text-to-speech engine that speaks the callouts, nil for silent callouts
+func (a *DefaultAudioCueHandler) SetTTSEngine(engine TTSEngine) {
+	a.tts = engine
+}
+
+// SetLocale sets the language of the callouts, nil for English
+func (a *DefaultAudioCueHandler) SetLocale(locale *i18n.Locale) {
+	a.locale = locale
+}
+
+// SetCalloutStyle sets how combos are called out. Callouts too long to say between two beats at the tempo fall back
+// to shorter styles; a tempo of 0 has no limit.
+func (a *DefaultAudioCueHandler) SetCalloutStyle(style models.CalloutStyle, tempo time.Duration) {
+	a.style = style
+	a.tempo = tempo
+}
+
+// comboStyle returns the style a combo is called out in
+func (a *DefaultAudioCueHandler) comboStyle(combo models.Combo, stance models.Stance) models.CalloutStyle {
+	return fitCalloutStyle(a.locale, combo, stance, a.style, a.tempo)
+}
+
+// SetSink sets where the synthesized sounds are played, nil to fall back to the terminal bell
+func (a *DefaultAudioCueHandler) SetSink(sink synth.Sink) {
+	a.sink = sink
+}
+
+// SetContext ties the handler's cues to ctx: once it is done, the cue playing is cut off and no more are played
+func (a *DefaultAudioCueHandler) SetContext(ctx context.Context) {
+	a.cues.setParent(ctx)
 }
 
-// Stop cancels all running audio commands
+// Stop cuts off the cues in flight, killing their audio commands and sounds. Cues played after it are played.
 func (a *DefaultAudioCueHandler) Stop() {
+	a.cues.stop()
+
 	a.cmdsMutex.Lock()
 	defer a.cmdsMutex.Unlock()
 
@@ -50,10 +102,14 @@ func (a *DefaultAudioCueHandler) RunningCommandsCount() int {
 
 // trackAndWaitCommand starts a command, tracks it, waits for it to complete, then removes it
 // This allows commands to run sequentially (one after another) while still being cancellable
-func (a *DefaultAudioCueHandler) trackAndWaitCommand(cmd *exec.Cmd) error {
+// The command isn't started if ctx is done, and is killed if ctx is done while it runs
+func (a *DefaultAudioCueHandler) trackAndWaitCommand(ctx context.Context, cmd *exec.Cmd) error {
 	if cmd == nil {
 		return nil
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// Start the command (non-blocking)
 	if err := cmd.Start(); err != nil {
@@ -65,8 +121,12 @@ func (a *DefaultAudioCueHandler) trackAndWaitCommand(cmd *exec.Cmd) error {
 	a.runningCmds = append(a.runningCmds, cmd)
 	a.cmdsMutex.Unlock()
 
-	// Wait for command to complete (blocking, so next command waits)
+	// Wait for command to complete (blocking, so next command waits), killing it if the cue is cut off
+	stop := context.AfterFunc(ctx, func() { cmd.Process.Kill() })
 	err := cmd.Wait()
+	if !stop() {
+		err = ctx.Err()
+	}
 
 	// Remove from tracking when command completes
 	a.cmdsMutex.Lock()
@@ -82,191 +142,109 @@ func (a *DefaultAudioCueHandler) trackAndWaitCommand(cmd *exec.Cmd) error {
 	return err
 }
 
-// PlayBeep plays a simple beep sound
+// PlayBeep plays the countdown pip
 func (a *DefaultAudioCueHandler) PlayBeep() {
-	if !a.enabled {
+	a.playTone(a.cues.current(), synth.Pip())
+}
+
+// PlayTempoClick plays the tempo click
+func (a *DefaultAudioCueHandler) PlayTempoClick() {
+	a.playTone(a.cues.current(), synth.Click())
+}
+
+// playTone plays a synthesized sound through the sink, or rings the terminal bell without one. Nothing is played
+// once ctx is done.
+func (a *DefaultAudioCueHandler) playTone(ctx context.Context, tone synth.Tone) {
+	a.playSamples(ctx, tone.Render(synth.DefaultSampleRate))
+}
+
+// playSamples plays synthesized samples at synth.DefaultSampleRate like playTone
+func (a *DefaultAudioCueHandler) playSamples(ctx context.Context, samples []float64) {
+	if !a.enabled || len(samples) == 0 || ctx.Err() != nil {
 		return
 	}
-
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin": // macOS
-		cmd = exec.Command("say", "-v", "Bells", "beep")
-	case "linux":
-		// Try to use beep command or system bell
-		cmd = exec.Command("beep")
-		fmt.Print("\a") // ASCII bell (can't cancel, but it's instant)
-	case "windows":
-		// Windows beep
-		fmt.Print("\a") // ASCII bell (can't cancel, but it's instant)
-		cmd = exec.Command("powershell", "-c", "[console]::beep(800,200)")
-	default:
+	if a.sink == nil {
 		fmt.Print("\a") // Fallback to ASCII bell
 		return
 	}
-
-	// Track and wait for beep command so it can be cancelled
-	if cmd != nil {
-		a.trackAndWaitCommand(cmd)
+	err := synth.PlayContext(ctx, a.sink, synth.ToPCM(samples), synth.DefaultSampleRate)
+	if err != nil && ctx.Err() == nil {
+		fmt.Print("\a") // Fallback to ASCII bell
 	}
 }
 
-// PlayPeriodTransition plays a sound for period transitions
+// PlayPeriodTransition says "work" or "rest" for period transitions
 func (a *DefaultAudioCueHandler) PlayPeriodTransition(periodType types.PeriodType) {
-	if !a.enabled {
-		return
-	}
+	a.followPeriod(periodType)
+	a.speak(a.cues.current(), a.locale.PeriodName(periodType))
+}
 
-	switch periodType {
-	case types.PeriodWork:
-		// Say "work" when transitioning to work period
-		cmd := exec.Command("say", "-v", "Alex", "work")
-		a.trackAndWaitCommand(cmd)
-	case types.PeriodRest:
-		// Say "rest" when transitioning to rest period
-		cmd := exec.Command("say", "-v", "Alex", "rest")
-		a.trackAndWaitCommand(cmd)
-	}
+// PlayCueSound plays a cue profile's bell, clapper or beep, struck as many times as the sound says
+func (a *DefaultAudioCueHandler) PlayCueSound(sound models.CueSound) {
+	a.playSamples(a.cues.current(), renderCueSound(sound, synth.DefaultSampleRate))
 }
 
-// PlayWorkoutStart plays a sound when workout starts
-func (a *DefaultAudioCueHandler) PlayWorkoutStart() {
-	if !a.enabled {
-		return
-	}
+// PlayPeriodSound plays a cue profile's sound for a period transition, with the music following the period as it
+// does for PlayPeriodTransition
+func (a *DefaultAudioCueHandler) PlayPeriodSound(periodType types.PeriodType, sound models.CueSound) {
+	a.followPeriod(periodType)
+	a.PlayCueSound(sound)
+}
 
-	// Play multiple beeps to indicate start
-	for i := 0; i < 3; i++ {
-		a.PlayBeep()
-		if i < 2 {
-			// Small delay between beeps
-			time.Sleep(200 * time.Millisecond)
-		}
-	}
+// PlayWorkoutStart rings the round bell when the workout starts
+func (a *DefaultAudioCueHandler) PlayWorkoutStart() {
+	a.followPeriod(types.PeriodWork)
+	a.playTone(a.cues.current(), synth.Bell())
 }
 
-// PlayWorkoutComplete plays a sound when workout completes
+// PlayWorkoutComplete says "workout complete" when the workout finishes
 func (a *DefaultAudioCueHandler) PlayWorkoutComplete() {
-	if !a.enabled {
-		return
-	}
-
-	// Say "workout complete" when workout finishes
-	cmd := exec.Command("say", "-v", "Alex", "workout complete")
-	a.trackAndWaitCommand(cmd)
+	a.speak(a.cues.current(), a.locale.WorkoutComplete())
+	a.stopMusic()
 }
 
-// PlayComboCallout speaks the combo moves using text-to-speech
+// PlayComboCallout speaks the combo moves
 // Note: No beep is played here - the first beep should play when the timer starts
 func (a *DefaultAudioCueHandler) PlayComboCallout(combo models.Combo, stance models.Stance) {
-	if !a.enabled {
-		return
-	}
-
-	if len(combo.Moves) == 0 {
-		return
-	}
+	a.speak(a.cues.current(), a.comboCallout(combo, stance))
+}
 
-	// Convert combo to speech-friendly string
-	comboText := comboToSpeechString(combo, stance)
-
-	// Use system text-to-speech
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin": // macOS
-		// Use 'say' command with a clear voice
-		cmd = exec.Command("say", "-v", "Alex", comboText)
-	case "linux":
-		// Try espeak or festival
-		cmd = exec.Command("espeak", comboText)
-		// Fallback to festival if espeak not available
-		// exec.Command("festival", "--tts").Run() // Would need stdin
-	case "windows":
-		// Use PowerShell text-to-speech
-		cmd = exec.Command("powershell", "-c", fmt.Sprintf("Add-Type -AssemblyName System.Speech; $synth = New-Object System.Speech.Synthesis.SpeechSynthesizer; $synth.Speak('%s')", comboText))
-	default:
-		// Fallback: no audio output
-		return
-	}
-	if cmd != nil {
-		a.trackAndWaitCommand(cmd)
-	}
+// comboCallout returns what is said to call out a combo
+func (a *DefaultAudioCueHandler) comboCallout(combo models.Combo, stance models.Stance) string {
+	return a.locale.StyledComboCallout(combo, stance, a.comboStyle(combo, stance))
 }
 
-// PlayRoundCallout speaks the round number using text-to-speech
+// PlayRoundCallout speaks the round number
 func (a *DefaultAudioCueHandler) PlayRoundCallout(roundNumber int, totalRounds int) {
-	if !a.enabled {
-		return
-	}
-
-	// Convert round numbers to speech-friendly string
-	roundText := roundToSpeechString(roundNumber, totalRounds)
-
-	// Use system text-to-speech
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin": // macOS
-		// Use 'say' command with a clear voice
-		cmd = exec.Command("say", "-v", "Alex", roundText)
-	case "linux":
-		// Try espeak or festival
-		cmd = exec.Command("espeak", roundText)
-		// Fallback to festival if espeak not available
-	case "windows":
-		// Use PowerShell text-to-speech
-		cmd = exec.Command("powershell", "-c", fmt.Sprintf("Add-Type -AssemblyName System.Speech; $synth = New-Object System.Speech.Synthesis.SpeechSynthesizer; $synth.Speak('%s')", roundText))
-	default:
-		// Fallback: just beep
-		a.PlayBeep()
-		return
-	}
-	if cmd != nil {
-		a.trackAndWaitCommand(cmd)
-	}
+	a.speak(a.cues.current(), a.locale.RoundCallout(roundNumber, totalRounds))
 }
 
-// comboToSpeechString converts a combo to a natural speech string
-func comboToSpeechString(combo models.Combo, stance models.Stance) string {
-	if len(combo.Moves) == 0 {
-		return ""
-	}
+// PlayCoachingCue speaks a coaching cue (e.g. "snap the jab back"), in the handler's language if it is from the cue bank
+func (a *DefaultAudioCueHandler) PlayCoachingCue(cue string) {
+	a.speak(a.cues.current(), strings.TrimSpace(a.locale.Cue(cue)))
+}
 
-	var parts []string
-	for _, move := range combo.Moves {
-		if move.IsPunch() && move.Punch != nil {
-			// Use stance-aware punch name
-			punchName := move.Punch.NameForStance(stance)
-			parts = append(parts, punchName)
-		} else if move.IsDefensive() && move.Defensive != nil {
-			// Use defensive move name
-			parts = append(parts, move.Defensive.String())
-		}
+// speak says the text with the text-to-speech engine, and returns once it has been said or ctx is done. Engines
+// that can only write files are played through the sink.
+func (a *DefaultAudioCueHandler) speak(ctx context.Context, text string) {
+	if !a.enabled || a.tts == nil || text == "" || ctx.Err() != nil {
+		return
 	}
-
-	// Join with "then" for natural speech flow
-	// Example: "jab, cross, then left hook"
-	if len(parts) == 0 {
-		return ""
+	defer a.duck()()
+	if cmd := a.tts.SpeakCommand(text); cmd != nil {
+		a.trackAndWaitCommand(ctx, cmd)
+		return
 	}
-
-	if len(parts) == 1 {
-		return parts[0]
+	if a.sink == nil {
+		return
 	}
-
-	// For multiple moves, join with commas and "then" before the last one
-	if len(parts) == 2 {
-		return parts[0] + ", then " + parts[1]
+	clip, sampleRate, err := renderSpeech(a.tts, text, func(cmd *exec.Cmd) error {
+		return a.trackAndWaitCommand(ctx, cmd)
+	})
+	if err != nil {
+		return // Stopped, or the engine failed; carry on without the callout
 	}
-
-	// For 3+ moves: "jab, cross, left hook, then right uppercut"
-	allButLast := strings.Join(parts[:len(parts)-1], ", ")
-	return allButLast + ", then " + parts[len(parts)-1]
-}
-
-// roundToSpeechString converts round numbers to a natural speech string
-// Example: "round 1 of 50", "round 2 of 50", etc.
-func roundToSpeechString(roundNumber int, totalRounds int) string {
-	return fmt.Sprintf("round %d of %d", roundNumber, totalRounds)
+	synth.PlayContext(ctx, a.sink, clip, sampleRate)
 }
 
 // NoOpAudioCueHandler is a no-op implementation for when audio is disabled
@@ -283,136 +261,88 @@ func (a *NoOpAudioCueHandler) PlayWorkoutStart()                            {}
 func (a *NoOpAudioCueHandler) PlayWorkoutComplete()                         {}
 func (a *NoOpAudioCueHandler) PlayComboCallout(models.Combo, models.Stance) {}
 func (a *NoOpAudioCueHandler) PlayRoundCallout(int, int)                    {}
+func (a *NoOpAudioCueHandler) PlayCoachingCue(string)                       {}
 func (a *NoOpAudioCueHandler) Stop()                                        {}
 
-// FileAudioCueHandler plays audio from files (for future implementation)
+// FileAudioCueHandler plays callouts from a sample pack through the sink of the default handler it wraps. The bell,
+// tempo clicks, coaching cues and anything else the pack can't call out are left to the default handler.
 type FileAudioCueHandler struct {
-	enabled      bool
-	beepFile     string
-	workFile     string
-	restFile     string
-	startFile    string
-	completeFile string
+	*DefaultAudioCueHandler
+	pack *SamplePack
 }
 
-// NewFileAudioCueHandler creates a file-based audio handler
-func NewFileAudioCueHandler(enabled bool) *FileAudioCueHandler {
+// NewFileAudioCueHandler creates a handler that plays callouts from a sample pack
+func NewFileAudioCueHandler(enabled bool, pack *SamplePack) *FileAudioCueHandler {
 	return &FileAudioCueHandler{
-		enabled: enabled,
+		DefaultAudioCueHandler: NewDefaultAudioCueHandler(enabled),
+		pack:                   pack,
 	}
 }
 
-// SetBeepFile sets the beep sound file
-func (f *FileAudioCueHandler) SetBeepFile(path string) {
-	f.beepFile = path
-}
-
-// SetWorkFile sets the work period sound file
-func (f *FileAudioCueHandler) SetWorkFile(path string) {
-	f.workFile = path
-}
-
-// SetRestFile sets the rest period sound file
-func (f *FileAudioCueHandler) SetRestFile(path string) {
-	f.restFile = path
-}
-
-// SetStartFile sets the workout start sound file
-func (f *FileAudioCueHandler) SetStartFile(path string) {
-	f.startFile = path
-}
-
-// SetCompleteFile sets the workout complete sound file
-func (f *FileAudioCueHandler) SetCompleteFile(path string) {
-	f.completeFile = path
-}
-
+// PlayBeep plays the pack's countdown clip
 func (f *FileAudioCueHandler) PlayBeep() {
-	if !f.enabled || f.beepFile == "" {
-		return
+	ctx := f.cues.current()
+	if clip, err := f.pack.Clip(SampleCountdown); !f.playClip(ctx, clip, err) {
+		f.playTone(ctx, synth.Pip())
 	}
-	f.playFile(f.beepFile)
 }
 
+// PlayPeriodTransition plays the pack's "work" or "rest" clip
 func (f *FileAudioCueHandler) PlayPeriodTransition(periodType types.PeriodType) {
-	if !f.enabled {
-		return
+	name := SampleWork
+	if periodType == types.PeriodRest {
+		name = SampleRest
 	}
-
-	switch periodType {
-	case types.PeriodWork:
-		if f.workFile != "" {
-			f.playFile(f.workFile)
-		} else {
-			f.PlayBeep()
-		}
-	case types.PeriodRest:
-		if f.restFile != "" {
-			f.playFile(f.restFile)
-		} else {
-			f.PlayBeep()
-		}
-	}
-}
-
-func (f *FileAudioCueHandler) PlayWorkoutStart() {
-	if !f.enabled {
-		return
-	}
-	if f.startFile != "" {
-		f.playFile(f.startFile)
-	} else {
-		f.PlayBeep()
+	ctx := f.cues.current()
+	f.followPeriod(periodType)
+	if clip, err := f.pack.Clip(name); !f.playCallout(ctx, clip, err) {
+		f.speak(ctx, f.locale.PeriodName(periodType))
 	}
 }
 
+// PlayWorkoutComplete plays the pack's "workout complete" clip
 func (f *FileAudioCueHandler) PlayWorkoutComplete() {
-	if !f.enabled {
-		return
-	}
-	if f.completeFile != "" {
-		f.playFile(f.completeFile)
-	} else {
-		f.PlayBeep()
+	ctx := f.cues.current()
+	if clip, err := f.pack.Clip(SampleWorkoutComplete); !f.playCallout(ctx, clip, err) {
+		f.speak(ctx, f.locale.WorkoutComplete())
 	}
+	f.stopMusic()
 }
 
+// PlayComboCallout plays the combo's moves from the pack. Packs only have full names, so other callout styles are
+// spoken.
 func (f *FileAudioCueHandler) PlayComboCallout(combo models.Combo, stance models.Stance) {
-	if !f.enabled {
-		return
+	ctx := f.cues.current()
+	if f.comboStyle(combo, stance) == models.CalloutFull {
+		if clip, err := f.pack.ComboClip(combo, stance); f.playCallout(ctx, clip, err) {
+			return
+		}
 	}
-	// For file-based handler, fall back to default text-to-speech
-	// In the future, could use pre-recorded audio files
-	defaultHandler := NewDefaultAudioCueHandler(true)
-	defaultHandler.PlayComboCallout(combo, stance)
+	f.speak(ctx, f.comboCallout(combo, stance))
 }
 
+// PlayRoundCallout plays "round N of M" from the pack, or speaks it if the pack doesn't go up to the numbers
 func (f *FileAudioCueHandler) PlayRoundCallout(roundNumber int, totalRounds int) {
-	if !f.enabled {
-		return
+	ctx := f.cues.current()
+	if clip, err := f.pack.RoundClip(roundNumber, totalRounds); !f.playCallout(ctx, clip, err) {
+		f.speak(ctx, f.locale.RoundCallout(roundNumber, totalRounds))
 	}
-	// For file-based handler, fall back to default text-to-speech
-	// In the future, could use pre-recorded audio files
-	defaultHandler := NewDefaultAudioCueHandler(true)
-	defaultHandler.PlayRoundCallout(roundNumber, totalRounds)
 }
 
-func (f *FileAudioCueHandler) Stop() {
-	// FileAudioCueHandler uses Start() which runs asynchronously, so we can't easily cancel
-	// For now, this is a no-op - file playback processes will complete on their own
+// playCallout plays a callout from the pack like playClip, ducking the music under it
+func (f *FileAudioCueHandler) playCallout(ctx context.Context, clip []int16, err error) bool {
+	defer f.duck()()
+	return f.playClip(ctx, clip, err)
 }
 
-func (f *FileAudioCueHandler) playFile(path string) {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return
+// playClip plays a clip from the pack, returning once it has played or ctx is done. It returns false if there is
+// no clip or no sink to play it, so the default handler can play the cue instead.
+func (f *FileAudioCueHandler) playClip(ctx context.Context, clip []int16, err error) bool {
+	if err != nil || f.sink == nil {
+		return false
 	}
-
-	switch runtime.GOOS {
-	case "darwin":
-		exec.Command("afplay", path).Start()
-	case "linux":
-		exec.Command("aplay", path).Start()
-	case "windows":
-		exec.Command("powershell", "-c", fmt.Sprintf("(New-Object Media.SoundPlayer '%s').PlaySync()", path)).Start()
+	if f.enabled && ctx.Err() == nil {
+		synth.PlayContext(ctx, f.sink, clip, f.pack.SampleRate()) // Fails only if stopped
 	}
+	return true
 }
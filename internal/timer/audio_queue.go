@@ -0,0 +1,280 @@
+package timer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AudioPriority orders the cues waiting in an AudioQueue
+type AudioPriority int
+
+const (
+	PriorityLow    AudioPriority = iota // Coaching cues
+	PriorityNormal                      // Round and combo callouts
+	PriorityHigh                        // Workout and period transitions, countdown beeps
+)
+
+// QueuedCue is a cue waiting in, or played by, an AudioQueue
+type QueuedCue struct {
+	name     string
+	priority AudioPriority
+	deadline time.Time // Latest time the cue may start, zero for no deadline
+	play     func(AudioCueHandler)
+	seq      int // Keeps cues of the same priority in the order they were queued
+	done     chan struct{}
+	played   bool
+}
+
+// Name returns the name the cue was queued with
+func (c *QueuedCue) Name() string {
+	return c.name
+}
+
+// Done returns a channel that is closed once the cue has been played or dropped
+func (c *QueuedCue) Done() <-chan struct{} {
+	return c.done
+}
+
+// Played reports whether the cue was played. It is only meaningful once Done is closed.
+func (c *QueuedCue) Played() bool {
+	<-c.done
+	return c.played
+}
+
+// finish marks the cue played or dropped
+func (c *QueuedCue) finish(played bool) {
+	c.played = played
+	close(c.done)
+}
+
+// AudioQueue plays cues on an AudioCueHandler one at a time on its own goroutine, so the caller never waits for
+// audio. The highest priority cue waiting is played next, and a cue that can't start before its deadline is
+// dropped rather than played late. While paused, the cues wait and the queue is silent. All methods are safe for
+// concurrent use.
+type AudioQueue struct {
+	mu       sync.Mutex
+	clock    Clock
+	handler  AudioCueHandler
+	pending  []*QueuedCue
+	seq      int
+	dropped  []string // Names of the cues dropped for being late
+	closed   bool
+	paused   bool
+	pausedAt time.Time
+	parent   context.Context    // Context set with SetContext, nil for none
+	cancel   context.CancelFunc // Cancels the handler's context on Pause, nil if it doesn't implement ContextAudioCueHandler
+	wake     chan struct{}
+}
+
+// NewAudioQueue creates an audio queue playing on the given handler and starts its goroutine
+func NewAudioQueue(handler AudioCueHandler) *AudioQueue {
+	return NewAudioQueueWithClock(handler, RealClock())
+}
+
+// NewAudioQueueWithClock creates an audio queue that checks deadlines against the given clock
+func NewAudioQueueWithClock(handler AudioCueHandler, clock Clock) *AudioQueue {
+	q := &AudioQueue{
+		clock:   clock,
+		handler: handler,
+		wake:    make(chan struct{}, 1),
+	}
+	q.renewContextLocked() // Not shared yet
+	go q.loop()
+	return q
+}
+
+// Enqueue queues a cue. A zero deadline means the cue is played however long it waits.
+// Once the queue is closed the cue is dropped straight away.
+func (q *AudioQueue) Enqueue(name string, priority AudioPriority, deadline time.Time, play func(AudioCueHandler)) *QueuedCue {
+	cue := &QueuedCue{name: name, priority: priority, deadline: deadline, play: play, done: make(chan struct{})}
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		cue.finish(false)
+		return cue
+	}
+	q.seq++
+	cue.seq = q.seq
+	q.pending = append(q.pending, cue)
+	q.mu.Unlock()
+
+	q.signal()
+	return cue
+}
+
+// SetContext ties the handler's cues to ctx, if it implements ContextAudioCueHandler. Pause also cuts them off
+// through a context of the queue's own, so a cue that starts just as the queue is paused is silent.
+func (q *AudioQueue) SetContext(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.parent = ctx
+	if !q.paused {
+		q.renewContextLocked()
+	}
+}
+
+// renewContextLocked ties the handler's cues to a new context of the queue's. The caller must hold q.mu.
+func (q *AudioQueue) renewContextLocked() {
+	handler, ok := q.handler.(ContextAudioCueHandler)
+	if !ok {
+		return
+	}
+	parent := q.parent
+	if parent == nil {
+		parent = context.Background()
+	}
+	if q.cancel != nil {
+		q.cancel()
+	}
+	var ctx context.Context
+	ctx, q.cancel = context.WithCancel(parent)
+	handler.SetContext(ctx)
+}
+
+// Clear drops every waiting cue and stops the one playing
+func (q *AudioQueue) Clear() {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+
+	for _, cue := range pending {
+		cue.finish(false)
+	}
+	q.handler.Stop()
+}
+
+// Pause cuts off the cue playing and holds the waiting cues until Resume. Cues queued while paused wait too.
+func (q *AudioQueue) Pause() {
+	q.mu.Lock()
+	if q.paused || q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.paused = true
+	q.pausedAt = q.clock.Now()
+	if q.cancel != nil {
+		q.cancel()
+	}
+	q.mu.Unlock()
+
+	q.handler.Stop()
+}
+
+// Resume plays the cues held by Pause. Their deadlines move on by the time the queue was paused, as the workout
+// was paused too.
+func (q *AudioQueue) Resume() {
+	q.mu.Lock()
+	if !q.paused {
+		q.mu.Unlock()
+		return
+	}
+	q.resumeLocked()
+	q.mu.Unlock()
+	q.signal()
+}
+
+// resumeLocked ends a pause. The caller must hold q.mu.
+func (q *AudioQueue) resumeLocked() {
+	paused := q.clock.Now().Sub(q.pausedAt)
+	for _, cue := range q.pending {
+		if !cue.deadline.IsZero() {
+			cue.deadline = cue.deadline.Add(paused)
+		}
+	}
+	q.paused = false
+	if q.cancel != nil {
+		q.renewContextLocked()
+	}
+}
+
+// Close lets the cues already queued play, then ends the queue's goroutine. It doesn't wait for them.
+// A paused queue is resumed.
+func (q *AudioQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	if q.paused {
+		q.resumeLocked()
+	}
+	q.mu.Unlock()
+	q.signal()
+}
+
+// signal wakes the queue's goroutine
+func (q *AudioQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default: // Already woken
+	}
+}
+
+// Pending returns the number of cues waiting to be played
+func (q *AudioQueue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Dropped returns the names of the cues dropped because they couldn't start before their deadline
+func (q *AudioQueue) Dropped() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]string(nil), q.dropped...)
+}
+
+// loop plays cues until the queue is closed and empty
+func (q *AudioQueue) loop() {
+	for {
+		cue, closed := q.next()
+		if cue == nil {
+			if closed {
+				return
+			}
+			<-q.wake
+			continue
+		}
+		if !q.start(cue) {
+			continue
+		}
+		cue.play(q.handler)
+		cue.finish(true)
+	}
+}
+
+// start reports whether the cue taken by next can be played. If the queue has been paused since, the cue goes back
+// to wait for Resume.
+func (q *AudioQueue) start(cue *QueuedCue) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.paused {
+		q.pending = append(q.pending, cue)
+		return false
+	}
+	return true
+}
+
+// next takes the cue to play next, dropping any that are already late. It returns nil if nothing is waiting, or the
+// queue is paused.
+func (q *AudioQueue) next() (*QueuedCue, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.pending) > 0 && !q.paused {
+		best := 0
+		for i, cue := range q.pending {
+			if cue.priority > q.pending[best].priority ||
+				(cue.priority == q.pending[best].priority && cue.seq < q.pending[best].seq) {
+				best = i
+			}
+		}
+		cue := q.pending[best]
+		q.pending = append(q.pending[:best], q.pending[best+1:]...)
+		if !cue.deadline.IsZero() && q.clock.Now().After(cue.deadline) {
+			q.dropped = append(q.dropped, cue.name)
+			cue.finish(false)
+			continue
+		}
+		return cue, q.closed
+	}
+	return nil, q.closed
+}
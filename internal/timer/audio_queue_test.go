@@ -0,0 +1,316 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cueRecorder records the cues it plays. The cue named by stallOn blocks until release or Stop is called.
+type cueRecorder struct {
+	*NoOpAudioCueHandler
+	mu      sync.Mutex
+	calls   []string
+	stops   int
+	stallOn string
+	stall   chan struct{}
+	once    sync.Once
+}
+
+func newCueRecorder(stallOn string) *cueRecorder {
+	return &cueRecorder{NoOpAudioCueHandler: NewNoOpAudioCueHandler(), stallOn: stallOn, stall: make(chan struct{})}
+}
+
+func (r *cueRecorder) play(call string) {
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+	if call == r.stallOn {
+		<-r.stall
+	}
+}
+
+func (r *cueRecorder) release() { r.once.Do(func() { close(r.stall) }) }
+
+func (r *cueRecorder) PlayBeep()            { r.play("beep") }
+func (r *cueRecorder) PlayWorkoutStart()    { r.play("workout start") }
+func (r *cueRecorder) PlayWorkoutComplete() { r.play("workout complete") }
+func (r *cueRecorder) PlayPeriodTransition(periodType types.PeriodType) {
+	r.play(strings.ToLower(periodName(periodType)))
+}
+func (r *cueRecorder) PlayRoundCallout(roundNumber int, totalRounds int) {
+	r.play(fmt.Sprintf("round %d", roundNumber))
+}
+func (r *cueRecorder) PlayComboCallout(combo models.Combo, stance models.Stance) { r.play("combo") }
+func (r *cueRecorder) PlayCoachingCue(cue string)                                { r.play(cue) }
+func (r *cueRecorder) Stop() {
+	r.mu.Lock()
+	r.stops++
+	r.mu.Unlock()
+	r.release()
+}
+
+// played returns the cues played so far, without the countdown beeps
+func (r *cueRecorder) played() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []string
+	for _, call := range r.calls {
+		if call != "beep" {
+			calls = append(calls, call)
+		}
+	}
+	return strings.Join(calls, ", ")
+}
+
+// waitForCount waits until call has been played n times
+func (r *cueRecorder) waitForCount(t *testing.T, call string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		count := 0
+		for _, c := range r.calls {
+			if c == call {
+				count++
+			}
+		}
+		r.mu.Unlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %q to be played %d times, got %s", call, n, r.played())
+}
+
+// say returns a cue that plays call on the recorder
+func (r *cueRecorder) say(call string) func(AudioCueHandler) {
+	return func(AudioCueHandler) { r.play(call) }
+}
+
+func TestAudioQueue_PlaysByPriority(t *testing.T) {
+	audio := newCueRecorder("first")
+	queue := NewAudioQueue(audio)
+	defer queue.Close()
+
+	// The others queue up while the first cue plays
+	queue.Enqueue("first", PriorityLow, time.Time{}, audio.say("first"))
+	audio.waitForCount(t, "first", 1)
+	queue.Enqueue("coaching", PriorityLow, time.Time{}, audio.say("coaching"))
+	queue.Enqueue("round", PriorityNormal, time.Time{}, audio.say("round"))
+	queue.Enqueue("transition", PriorityHigh, time.Time{}, audio.say("transition"))
+	last := queue.Enqueue("combo", PriorityNormal, time.Time{}, audio.say("combo"))
+	audio.release()
+
+	if !last.Played() {
+		t.Fatal("expected the combo to be played")
+	}
+	<-queue.Enqueue("end", PriorityLow, time.Time{}, audio.say("end")).Done()
+	if want := "first, transition, round, combo, coaching, end"; audio.played() != want {
+		t.Errorf("expected cues %s, got %s", want, audio.played())
+	}
+}
+
+func TestAudioQueue_DropsLateCues(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	audio := newCueRecorder("first")
+	queue := NewAudioQueueWithClock(audio, clock)
+	defer queue.Close()
+
+	queue.Enqueue("first", PriorityNormal, time.Time{}, audio.say("first"))
+	audio.waitForCount(t, "first", 1)
+	late := queue.Enqueue("late", PriorityHigh, clock.Now().Add(time.Second), audio.say("late"))
+	onTime := queue.Enqueue("on time", PriorityNormal, clock.Now().Add(3*time.Second), audio.say("on time"))
+	untimed := queue.Enqueue("untimed", PriorityLow, time.Time{}, audio.say("untimed"))
+
+	// The first cue runs long, past the late cue's deadline
+	clock.Advance(2 * time.Second)
+	audio.release()
+
+	if late.Played() {
+		t.Error("expected the late cue to be dropped")
+	}
+	if !onTime.Played() || !untimed.Played() {
+		t.Error("expected the cues still in time to be played")
+	}
+	if got := audio.played(); got != "first, on time, untimed" {
+		t.Errorf("expected cues first, on time, untimed, got %s", got)
+	}
+	if got := queue.Dropped(); len(got) != 1 || got[0] != "late" {
+		t.Errorf("expected the late cue to be reported dropped, got %v", got)
+	}
+}
+
+func TestAudioQueue_ClearDropsWaitingCues(t *testing.T) {
+	audio := newCueRecorder("first")
+	queue := NewAudioQueue(audio)
+
+	playing := queue.Enqueue("first", PriorityNormal, time.Time{}, audio.say("first"))
+	audio.waitForCount(t, "first", 1)
+	waiting := queue.Enqueue("second", PriorityNormal, time.Time{}, audio.say("second"))
+	queue.Clear() // Stops the first cue and drops the second
+
+	<-playing.Done()
+	if waiting.Played() {
+		t.Error("expected the waiting cue to be dropped")
+	}
+	audio.mu.Lock()
+	stops := audio.stops
+	audio.mu.Unlock()
+	if stops != 1 {
+		t.Errorf("expected the playing cue to be stopped, got %d stops", stops)
+	}
+
+	queue.Close()
+	if queue.Enqueue("after close", PriorityHigh, time.Time{}, audio.say("after close")).Played() {
+		t.Error("expected cues queued after Close to be dropped")
+	}
+}
+
+func TestAudioQueue_PauseHoldsCues(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	audio := newCueRecorder("first")
+	queue := NewAudioQueueWithClock(audio, clock)
+	defer queue.Close()
+
+	playing := queue.Enqueue("first", PriorityNormal, time.Time{}, audio.say("first"))
+	audio.waitForCount(t, "first", 1)
+	waiting := queue.Enqueue("waiting", PriorityNormal, clock.Now().Add(time.Second), audio.say("waiting"))
+	queue.Pause() // Mutes the first cue
+	<-playing.Done()
+	held := queue.Enqueue("held", PriorityHigh, time.Time{}, audio.say("held"))
+
+	// Paused for longer than the waiting cue's deadline, which moves on with the pause
+	clock.Advance(5 * time.Second)
+	time.Sleep(20 * time.Millisecond)
+	if got := audio.played(); got != "first" || queue.Pending() != 2 {
+		t.Fatalf("expected the cues held while paused, got %s played and %d pending", got, queue.Pending())
+	}
+	queue.Resume()
+
+	if !waiting.Played() || !held.Played() {
+		t.Error("expected the held cues to be played on Resume")
+	}
+	if got := audio.played(); got != "first, held, waiting" {
+		t.Errorf("expected cues first, held, waiting, got %s", got)
+	}
+	audio.mu.Lock()
+	stops := audio.stops
+	audio.mu.Unlock()
+	if stops != 1 {
+		t.Errorf("expected Pause to stop the playing cue, got %d stops", stops)
+	}
+}
+
+func TestAudioQueue_PauseHoldsCueAboutToPlay(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	audio := newCueRecorder("")
+	// The queue's goroutine isn't started yet: the test takes the cue the way it does
+	queue := &AudioQueue{clock: clock, handler: audio, wake: make(chan struct{}, 1)}
+	cue := queue.Enqueue("first", PriorityNormal, time.Time{}, audio.say("first"))
+
+	taken, _ := queue.next()
+	queue.Pause() // After the cue is taken, before it plays
+	if queue.start(taken) {
+		t.Fatal("expected the cue taken just before Pause to be held")
+	}
+	if queue.Pending() != 1 {
+		t.Errorf("expected the cue to wait for Resume, got %d pending", queue.Pending())
+	}
+
+	go queue.loop()
+	defer queue.Close()
+	time.Sleep(20 * time.Millisecond)
+	if got := audio.played(); got != "" {
+		t.Fatalf("expected nothing played while paused, got %s", got)
+	}
+	queue.Resume()
+	if !cue.Played() || audio.played() != "first" {
+		t.Errorf("expected the held cue to be played on Resume, got %s", audio.played())
+	}
+}
+
+func TestAudioQueue_PauseCancelsHandlerContext(t *testing.T) {
+	audio := &contextAudio{NoOpAudioCueHandler: NewNoOpAudioCueHandler()}
+	queue := NewAudioQueue(audio)
+	defer queue.Close()
+	run, cancelRun := context.WithCancel(context.Background())
+	queue.SetContext(run)
+
+	// A cue that starts as the queue is paused takes a context that is already done
+	playing := audio.ctx
+	queue.Pause()
+	if playing.Err() == nil {
+		t.Error("expected Pause to cancel the handler's context")
+	}
+	queue.Resume()
+	if audio.ctx == playing || audio.ctx.Err() != nil {
+		t.Error("expected Resume to tie the handler to a new context")
+	}
+	cancelRun()
+	if audio.ctx.Err() == nil {
+		t.Error("expected the handler's context to end with the context set on the queue")
+	}
+}
+
+func TestWorkoutTimer_PreRollKeepsWorkPeriodsOnTime(t *testing.T) {
+	timer, clock, display := newControlTestTimer(2)
+	audio := newCueRecorder("round 2") // The round 2 callout runs long
+	timer.SetAudioHandler(audio)
+	timer.SetAudioPreRoll(5 * time.Second)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+	sub := timer.Events().Subscribe(256)
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	// The first round is announced before its work period
+	display.waitFor(t, "start Work 1 3s")
+	if got := audio.played(); got != "workout start, work, round 1, combo" {
+		t.Errorf("expected round 1 to be announced first, got %s", got)
+	}
+	clock.BlockUntil(1)
+	clock.Advance(3 * time.Second)
+	display.waitFor(t, "start Rest 1 10s")
+	audio.waitForCount(t, "rest", 1)
+	clock.BlockUntil(1)
+
+	// Round 2 is called out during the last 5s of the rest period
+	clock.Advance(5 * time.Second)
+	audio.waitForCount(t, "round 2", 1)
+
+	// The work period starts on time while the callout is still speaking
+	clock.Advance(5 * time.Second)
+	display.waitFor(t, "start Work 2 3s")
+	audio.release()
+	audio.waitForCount(t, "work", 2)
+
+	if !advanceUntilDone(clock, completed, 40) {
+		t.Fatal("workout did not complete")
+	}
+	sub.Unsubscribe()
+
+	var starts []string
+	for event := range sub.Events() {
+		if event.Type == EventPeriodStarted {
+			starts = append(starts, fmt.Sprintf("%s %d@%v", periodName(event.Period), event.Round, event.Time.Sub(time.Unix(0, 0))))
+		}
+	}
+	if got, want := strings.Join(starts, ", "), "Work 1@0s, Rest 1@3s, Work 2@13s, Rest 2@16s"; got != want {
+		t.Errorf("expected periods %s, got %s", want, got)
+	}
+	// Round 2's "work" goes ahead of its combo, still within lateCueTolerance of the period starting
+	if got, want := audio.played(), "workout start, work, round 1, combo, rest, round 2, work, combo"; !strings.HasPrefix(got, want) {
+		t.Errorf("expected cues %s, got %s", want, got)
+	}
+	if got := audio.played(); !strings.HasSuffix(got, "workout complete") {
+		t.Errorf("expected the workout complete cue last, got %s", got)
+	}
+}
@@ -0,0 +1,162 @@
+package timer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMoveInterval is the time given to each move of a combo, whatever the tempo
+const DefaultMoveInterval = 400 * time.Millisecond
+
+// Beat is one step of a BeatScheduler
+type Beat struct {
+	Number    int // Beat within the work period, from 1
+	MoveIndex int // Move of the combo to throw (0 on the beat itself), or the number of moves once the combo is done
+}
+
+// BeatScheduler paces a combo through a work period. Every tempo interval a beat starts the combo, each following
+// move comes moveInterval after the one before it, and a last step marks the combo done until the next beat.
+// Moves that don't fit in the tempo interval are left out.
+//
+// Like a CountdownTimer it only counts running time, so pausing holds the schedule where it is, and every step is
+// scheduled against a deadline measured from the start so late steps don't drift. All methods are safe for
+// concurrent use; the OnBeat callback runs on the clock's goroutine and never while the lock is held.
+type BeatScheduler struct {
+	mu        sync.Mutex
+	clock     Clock
+	tempo     time.Duration
+	offsets   []time.Duration // Offset of each step of a beat from the beat itself
+	state     TimerState
+	elapsed   time.Duration // Running time up to resumedAt
+	resumedAt time.Time
+	next      int   // Step to fire next, counted from the first beat
+	pending   Timer // Timer for the next step, nil unless running
+	seq       int   // Identifies the pending step; stale fires carry an older value
+	onBeat    func(Beat)
+}
+
+// NewBeatScheduler creates a beat scheduler for a combo of the given number of moves
+func NewBeatScheduler(tempo, moveInterval time.Duration, moves int) *BeatScheduler {
+	return NewBeatSchedulerWithClock(tempo, moveInterval, moves, RealClock())
+}
+
+// NewBeatSchedulerWithClock creates a beat scheduler driven by the given clock
+func NewBeatSchedulerWithClock(tempo, moveInterval time.Duration, moves int, clock Clock) *BeatScheduler {
+	return &BeatScheduler{
+		clock:   clock,
+		tempo:   tempo,
+		offsets: beatOffsets(tempo, moveInterval, moves),
+		state:   StateIdle,
+	}
+}
+
+// beatOffsets returns the offset of each step of a beat from the beat itself: the beat, each following move that
+// fits in the tempo interval, and the end of the combo if it fits too
+func beatOffsets(tempo, moveInterval time.Duration, moves int) []time.Duration {
+	offsets := []time.Duration{0}
+	for i := 1; i <= moves; i++ {
+		offset := time.Duration(i) * moveInterval
+		if moveInterval <= 0 || offset >= tempo {
+			break
+		}
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// OnBeat sets the callback called for each step: each beat, each following move and the end of the combo
+func (s *BeatScheduler) OnBeat(callback func(Beat)) *BeatScheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onBeat = callback
+	return s
+}
+
+// Start starts the schedule with the first beat straight away, or resumes it if paused
+func (s *BeatScheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tempo <= 0 {
+		return fmt.Errorf("tempo must be positive, got %v", s.tempo)
+	}
+	if !CanTransition(s.state, StateRunning) {
+		return nil // Already running
+	}
+	if s.state != StatePaused {
+		s.elapsed = 0
+		s.next = 0
+	}
+	s.resumedAt = s.clock.Now()
+	s.state = StateRunning
+	s.scheduleLocked()
+	return nil
+}
+
+// Pause holds the schedule until Start is called again
+func (s *BeatScheduler) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != StateRunning {
+		return
+	}
+	s.elapsed += s.clock.Since(s.resumedAt)
+	s.state = StatePaused
+	s.cancelLocked()
+}
+
+// Stop stops the schedule. Starting it again begins from the first beat.
+func (s *BeatScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancelLocked()
+	s.state = StateStopped
+}
+
+// State returns the current state of the scheduler
+func (s *BeatScheduler) State() TimerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// scheduleLocked schedules the next step. The caller must hold s.mu.
+func (s *BeatScheduler) scheduleLocked() {
+	s.cancelLocked()
+	steps := len(s.offsets)
+	offset := time.Duration(s.next/steps)*s.tempo + s.offsets[s.next%steps]
+	seq := s.seq
+	s.pending = s.clock.AfterFunc(offset-s.elapsed-s.clock.Since(s.resumedAt), func() {
+		s.fire(seq)
+	})
+}
+
+// cancelLocked cancels the pending step; a fire already in flight is ignored. The caller must hold s.mu.
+func (s *BeatScheduler) cancelLocked() {
+	if s.pending != nil {
+		s.pending.Stop()
+		s.pending = nil
+	}
+	s.seq++
+}
+
+// fire delivers a step that fell due and schedules the one after it
+func (s *BeatScheduler) fire(seq int) {
+	s.mu.Lock()
+	if seq != s.seq || s.state != StateRunning {
+		s.mu.Unlock()
+		return
+	}
+	steps := len(s.offsets)
+	beat := Beat{Number: s.next/steps + 1, MoveIndex: s.next % steps}
+	s.next++
+	s.scheduleLocked()
+	onBeat := s.onBeat
+	s.mu.Unlock()
+
+	if onBeat != nil {
+		onBeat(beat)
+	}
+}
@@ -0,0 +1,193 @@
+package timer
+
+import (
+	"fmt"
+	"heavybagworkout/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// beatLog records each beat step with the fake time it fell due at
+type beatLog struct {
+	clock *FakeClock
+	start time.Time
+	steps chan string
+}
+
+func newBeatLog(clock *FakeClock) *beatLog {
+	return &beatLog{clock: clock, start: clock.Now(), steps: make(chan string, 64)}
+}
+
+func (l *beatLog) record(beat Beat) {
+	l.steps <- fmt.Sprintf("%d.%d@%v", beat.Number, beat.MoveIndex, l.clock.Since(l.start))
+}
+
+// take returns the next n steps
+func (l *beatLog) take(t *testing.T, n int) string {
+	t.Helper()
+	steps := make([]string, 0, n)
+	for len(steps) < n {
+		select {
+		case step := <-l.steps:
+			steps = append(steps, step)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d beat steps, got %v", n, steps)
+		}
+	}
+	return strings.Join(steps, " ")
+}
+
+// expectNone fails if another step has been recorded
+func (l *beatLog) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case step := <-l.steps:
+		t.Fatalf("expected no more beat steps, got %s", step)
+	default:
+	}
+}
+
+func TestBeatScheduler_Steps(t *testing.T) {
+	tests := []struct {
+		name  string
+		tempo time.Duration
+		moves int
+		want  string
+	}{
+		{
+			name:  "combo then done until the next beat",
+			tempo: 2 * time.Second,
+			moves: 3,
+			want:  "1.0@0s 1.1@400ms 1.2@800ms 1.3@1.2s 2.0@2s 2.1@2.4s 2.2@2.8s 2.3@3.2s 3.0@4s",
+		},
+		{
+			name:  "moves that don't fit the tempo are left out",
+			tempo: 1 * time.Second,
+			moves: 4,
+			want:  "1.0@0s 1.1@400ms 1.2@800ms 2.0@1s 2.1@1.4s 2.2@1.8s 3.0@2s",
+		},
+		{
+			name:  "empty combo still beats",
+			tempo: 2 * time.Second,
+			moves: 0,
+			want:  "1.0@0s 2.0@2s 3.0@4s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Unix(0, 0))
+			log := newBeatLog(clock)
+			scheduler := NewBeatSchedulerWithClock(tt.tempo, DefaultMoveInterval, tt.moves, clock).OnBeat(log.record)
+			if err := scheduler.Start(); err != nil {
+				t.Fatalf("unexpected error starting beat scheduler: %v", err)
+			}
+			defer scheduler.Stop()
+
+			want := strings.Fields(tt.want)
+			first := log.take(t, 1) // The first beat fires straight away
+			clock.Advance(2 * tt.tempo)
+			if got := first + " " + log.take(t, len(want)-1); got != tt.want {
+				t.Errorf("expected steps %s, got %s", tt.want, got)
+			}
+			log.expectNone(t)
+		})
+	}
+}
+
+func TestBeatScheduler_PauseHoldsSchedule(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	log := newBeatLog(clock)
+	scheduler := NewBeatSchedulerWithClock(2*time.Second, DefaultMoveInterval, 2, clock).OnBeat(log.record)
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("unexpected error starting beat scheduler: %v", err)
+	}
+	log.take(t, 1)
+
+	// Pause between the first and second move
+	clock.Advance(600 * time.Millisecond)
+	if got := log.take(t, 1); got != "1.1@400ms" {
+		t.Errorf("expected the second move at 400ms, got %s", got)
+	}
+	scheduler.Pause()
+	if scheduler.State() != StatePaused {
+		t.Fatalf("expected state Paused, got %v", scheduler.State())
+	}
+	clock.Advance(10 * time.Second)
+	log.expectNone(t)
+
+	// The remaining 200ms to the end of the combo, then the rest of the beat
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("unexpected error resuming beat scheduler: %v", err)
+	}
+	clock.Advance(1400 * time.Millisecond)
+	if got := log.take(t, 2); got != "1.2@10.8s 2.0@12s" {
+		t.Errorf("expected the schedule to continue where it was paused, got %s", got)
+	}
+
+	scheduler.Stop()
+	clock.Advance(10 * time.Second)
+	log.expectNone(t)
+	if clock.Waiters() != 0 {
+		t.Errorf("expected no pending steps after stop, got %d", clock.Waiters())
+	}
+}
+
+func TestBeatScheduler_RequiresTempo(t *testing.T) {
+	scheduler := NewBeatSchedulerWithClock(0, DefaultMoveInterval, 2, NewFakeClock(time.Unix(0, 0)))
+	if err := scheduler.Start(); err == nil {
+		t.Fatal("expected an error starting a beat scheduler without a tempo")
+	}
+}
+
+func TestWorkoutTimer_PublishesBeats(t *testing.T) {
+	combo := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab), models.NewPunchMove(models.Cross)})
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(5*time.Second, 1*time.Second, 1),
+		[]models.WorkoutRound{models.NewWorkoutRound(1, combo, 5*time.Second, 1*time.Second)},
+	)
+	clock := NewFakeClock(time.Unix(0, 0))
+	display := &eventRecorder{}
+	timer := NewWorkoutTimer(workout)
+	timer.SetClock(clock)
+	timer.SetDisplayHandler(display)
+	timer.SetTempo(2 * time.Second)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+	sub := timer.Events().Subscribe(256)
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	display.waitFor(t, "start Work 1 5s")
+	clock.BlockUntil(2) // The period countdown and the step after the first beat
+
+	// Pausing mid-beat holds the beats with the countdown
+	clock.Advance(time.Second)
+	timer.Pause()
+	clock.Advance(10 * time.Second)
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+	if !advanceUntilDone(clock, completed, 20) {
+		t.Fatal("workout did not complete")
+	}
+	sub.Unsubscribe()
+
+	var beats []string
+	for event := range sub.Events() {
+		switch event.Type {
+		case EventBeat, EventMove, EventComboDone:
+			if len(event.Combo.Moves) != 2 {
+				t.Errorf("expected the round's combo with the %v event, got %d moves", event.Type, len(event.Combo.Moves))
+			}
+			beats = append(beats, fmt.Sprintf("%v %d.%d", event.Type, event.Beat, event.MoveIndex))
+		}
+	}
+	// Beats at 0s, 2s and 4s of the 5s work period, none in the rest period
+	want := "beat 1.0, move 1.1, combo done 1.2, beat 2.0, move 2.1, combo done 2.2, beat 3.0, move 3.1, combo done 3.2"
+	if got := strings.Join(beats, ", "); got != want {
+		t.Errorf("expected beats %s, got %s", want, got)
+	}
+}
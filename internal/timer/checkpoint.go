@@ -0,0 +1,244 @@
+package timer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"heavybagworkout/internal/config"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CheckpointFileName is the name of the workout checkpoint file inside the user's data directory
+const CheckpointFileName = "checkpoint.json"
+
+// SessionLogFileName is the name of the session log file inside the user's data directory
+const SessionLogFileName = "sessions.jsonl"
+
+// DefaultCheckpointInterval is how often a running workout is checkpointed between period starts
+const DefaultCheckpointInterval = 5 * time.Second
+
+// Checkpoint is a snapshot of a running workout: the plan and the exact point reached in it
+type Checkpoint struct {
+	Workout   models.Workout   `json:"workout"`
+	Stance    models.Stance    `json:"stance"`
+	Tempo     time.Duration    `json:"tempo"`     // Interval between beats, 0 if disabled
+	Round     int              `json:"round"`     // Current round (1-indexed)
+	Period    types.PeriodType `json:"period"`    // Current period of the round
+	Remaining time.Duration    `json:"remaining"` // Time left in the current period
+	StartedAt time.Time        `json:"started_at"`
+	SavedAt   time.Time        `json:"saved_at"`
+}
+
+// RoundsCompleted returns the number of rounds finished before the checkpoint
+func (c Checkpoint) RoundsCompleted() int {
+	return c.Round - 1
+}
+
+// Summary describes the point the checkpoint was taken at, e.g. "round 3 of 8, 12s left in the work period"
+func (c Checkpoint) Summary() string {
+	period := "work"
+	if c.Period == types.PeriodRest {
+		period = "rest"
+	}
+	return fmt.Sprintf("round %d of %d, %v left in the %s period", c.Round, len(c.Workout.Rounds), c.Remaining.Round(time.Second), period)
+}
+
+// Validate checks that the checkpoint points into its workout
+func (c Checkpoint) Validate() error {
+	if c.Round < 1 || c.Round > len(c.Workout.Rounds) {
+		return fmt.Errorf("checkpoint round %d is out of range (1-%d)", c.Round, len(c.Workout.Rounds))
+	}
+	if c.Period != types.PeriodWork && c.Period != types.PeriodRest {
+		return fmt.Errorf("checkpoint has an unknown period %d", c.Period)
+	}
+	if c.Remaining <= 0 {
+		return fmt.Errorf("checkpoint remaining time must be positive, got %v", c.Remaining)
+	}
+	return nil
+}
+
+// PartialSession returns the session log entry for giving up on the checkpointed workout
+func (c Checkpoint) PartialSession(now time.Time) SessionEntry {
+	return SessionEntry{
+		Time:            now,
+		StartedAt:       c.StartedAt,
+		RoundsCompleted: c.RoundsCompleted(),
+		TotalRounds:     len(c.Workout.Rounds),
+		Completed:       false,
+	}
+}
+
+// CheckpointStore keeps the latest checkpoint of a workout in a file. It is safe for concurrent use.
+type CheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewCheckpointStore creates a checkpoint store backed by the given file
+func NewCheckpointStore(path string) *CheckpointStore {
+	return &CheckpointStore{path: path}
+}
+
+// DefaultCheckpointStore creates a checkpoint store in the user's data directory
+func DefaultCheckpointStore() (*CheckpointStore, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewCheckpointStore(filepath.Join(dir, CheckpointFileName)), nil
+}
+
+// Path returns the file backing the checkpoint store
+func (cs *CheckpointStore) Path() string {
+	return cs.path
+}
+
+// Save replaces the stored checkpoint. The file is written in full before it replaces the old one,
+// so a crash mid-save leaves the previous checkpoint intact.
+func (cs *CheckpointStore) Save(checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	tmp, err := os.CreateTemp(filepath.Dir(cs.path), filepath.Base(cs.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), cs.path); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Load returns the stored checkpoint, or nil if there is none
+func (cs *CheckpointStore) Load() (*Checkpoint, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	data, err := os.ReadFile(cs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if err := checkpoint.Validate(); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Clear removes the stored checkpoint. Clearing an empty store is not an error.
+func (cs *CheckpointStore) Clear() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if err := os.Remove(cs.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear checkpoint: %w", err)
+	}
+	return nil
+}
+
+// SessionEntry records a workout session in the session log
+type SessionEntry struct {
+	Time            time.Time `json:"time"` // When the session was logged
+	StartedAt       time.Time `json:"started_at"`
+	RoundsCompleted int       `json:"rounds_completed"`
+	TotalRounds     int       `json:"total_rounds"`
+	Completed       bool      `json:"completed"`
+}
+
+// SessionLog is an append-only JSON lines file recording workout sessions
+type SessionLog struct {
+	path string
+}
+
+// NewSessionLog creates a session log backed by the given file
+func NewSessionLog(path string) *SessionLog {
+	return &SessionLog{path: path}
+}
+
+// DefaultSessionLog creates a session log in the user's data directory
+func DefaultSessionLog() (*SessionLog, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewSessionLog(filepath.Join(dir, SessionLogFileName)), nil
+}
+
+// Path returns the file backing the session log
+func (sl *SessionLog) Path() string {
+	return sl.path
+}
+
+// Append records a session in the session log
+func (sl *SessionLog) Append(entry SessionEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session entry: %w", err)
+	}
+
+	f, err := os.OpenFile(sl.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write session log: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every entry in the session log. A missing file is treated as an empty log.
+func (sl *SessionLog) Entries() ([]SessionEntry, error) {
+	f, err := os.Open(sl.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []SessionEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry SessionEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse session log entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,168 @@
+package timer
+
+import (
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckpointStore_SaveLoadClear(t *testing.T) {
+	store := NewCheckpointStore(filepath.Join(t.TempDir(), CheckpointFileName))
+	if checkpoint, err := store.Load(); err != nil || checkpoint != nil {
+		t.Fatalf("expected no checkpoint in an empty store, got %v, %v", checkpoint, err)
+	}
+
+	combo := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab), models.NewDefensiveMove(models.Duck)})
+	workout := models.NewWorkout(models.NewWorkoutConfig(20*time.Second, 10*time.Second, 2), []models.WorkoutRound{
+		models.NewWorkoutRound(1, combo, 20*time.Second, 10*time.Second),
+		models.NewWorkoutRound(2, combo, 20*time.Second, 10*time.Second),
+	})
+	saved := Checkpoint{
+		Workout:   workout,
+		Stance:    models.Southpaw,
+		Tempo:     3 * time.Second,
+		Round:     2,
+		Period:    types.PeriodRest,
+		Remaining: 7 * time.Second,
+		StartedAt: time.Unix(100, 0).UTC(),
+		SavedAt:   time.Unix(150, 0).UTC(),
+	}
+	if err := store.Save(saved); err != nil {
+		t.Fatalf("unexpected error saving checkpoint: %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil || loaded == nil {
+		t.Fatalf("expected the saved checkpoint, got %v, %v", loaded, err)
+	}
+	if loaded.Round != 2 || loaded.Period != types.PeriodRest || loaded.Remaining != 7*time.Second || loaded.Stance != models.Southpaw || loaded.Tempo != 3*time.Second {
+		t.Errorf("expected round 2 rest with 7s left for southpaw at 3s tempo, got %+v", loaded)
+	}
+	if got := loaded.Workout.Rounds[1].Combo.String(); got != combo.String() {
+		t.Errorf("expected the plan's combo %s, got %s", combo, got)
+	}
+	if got, want := loaded.Summary(), "round 2 of 2, 7s left in the rest period"; got != want {
+		t.Errorf("expected summary %q, got %q", want, got)
+	}
+	if entries, _ := filepath.Glob(filepath.Join(filepath.Dir(store.Path()), "*.tmp")); len(entries) != 0 {
+		t.Errorf("expected no temporary files left behind, got %v", entries)
+	}
+
+	if err := store.Clear(); err != nil {
+		t.Fatalf("unexpected error clearing checkpoint: %v", err)
+	}
+	if checkpoint, err := store.Load(); err != nil || checkpoint != nil {
+		t.Errorf("expected no checkpoint after clearing, got %v, %v", checkpoint, err)
+	}
+	if err := store.Clear(); err != nil {
+		t.Errorf("expected clearing an empty store to succeed, got %v", err)
+	}
+}
+
+func TestCheckpointStore_RejectsInvalidCheckpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "corrupt file", content: "{not json"},
+		{name: "round out of range", content: `{"workout":{"Rounds":[{"RoundNumber":1}]},"round":2,"remaining":1000000000}`},
+		{name: "no time left", content: `{"workout":{"Rounds":[{"RoundNumber":1}]},"round":1,"remaining":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), CheckpointFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewCheckpointStore(path).Load(); err == nil {
+				t.Error("expected an error loading an invalid checkpoint")
+			}
+		})
+	}
+}
+
+func TestSessionLog_AppendAndEntries(t *testing.T) {
+	log := NewSessionLog(filepath.Join(t.TempDir(), SessionLogFileName))
+	if entries, err := log.Entries(); err != nil || len(entries) != 0 {
+		t.Fatalf("expected an empty log, got %v, %v", entries, err)
+	}
+
+	checkpoint := Checkpoint{Workout: models.Workout{Rounds: make([]models.WorkoutRound, 8)}, Round: 3, Remaining: time.Second}
+	if err := log.Append(checkpoint.PartialSession(time.Unix(200, 0))); err != nil {
+		t.Fatalf("unexpected error appending to session log: %v", err)
+	}
+	entries, err := log.Entries()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one entry, got %v, %v", entries, err)
+	}
+	if entries[0].RoundsCompleted != 2 || entries[0].TotalRounds != 8 || entries[0].Completed {
+		t.Errorf("expected a partial session with 2 of 8 rounds, got %+v", entries[0])
+	}
+}
+
+func TestWorkoutTimer_CheckpointsAndResumes(t *testing.T) {
+	store := NewCheckpointStore(filepath.Join(t.TempDir(), CheckpointFileName))
+	timer, clock, display := newControlTestTimer(2)
+	timer.SetCheckpointStore(store)
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	display.waitFor(t, "start Work 1 3s")
+	clock.BlockUntil(1)
+	clock.Advance(3 * time.Second)
+	display.waitFor(t, "start Rest 1 10s")
+	clock.BlockUntil(1)
+
+	// Stopped 4s into the rest period, as when the app is closed
+	clock.Advance(4 * time.Second)
+	display.waitFor(t, "update Rest 1 6s")
+	timer.Stop()
+
+	checkpoint, err := store.Load()
+	if err != nil || checkpoint == nil {
+		t.Fatalf("expected a checkpoint after stopping, got %v, %v", checkpoint, err)
+	}
+	if checkpoint.Round != 1 || checkpoint.Period != types.PeriodRest || checkpoint.Remaining != 6*time.Second {
+		t.Fatalf("expected round 1 rest with 6s left, got %s", checkpoint.Summary())
+	}
+	if !checkpoint.StartedAt.Equal(time.Unix(0, 0)) {
+		t.Errorf("expected the workout start time, got %v", checkpoint.StartedAt)
+	}
+
+	// Resume in a new timer, as on the next launch
+	resumed, clock, display := newControlTestTimer(2)
+	resumed.SetCheckpointStore(store)
+	completed := make(chan struct{})
+	resumed.OnWorkoutComplete(func() { close(completed) })
+	if err := resumed.StartFrom(*checkpoint); err != nil {
+		t.Fatalf("unexpected error resuming from checkpoint: %v", err)
+	}
+	if !advanceUntilDone(clock, completed, 40) {
+		t.Fatal("resumed workout did not complete")
+	}
+	events := periodEvents(display.waitFor(t, "workout complete"))
+	want := []string{"workout start 2", "start Rest 1 6s", "end Rest 1", "start Work 2 3s", "end Work 2", "start Rest 2 10s", "end Rest 2", "workout complete"}
+	if len(events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("expected events %v, got %v", want, events)
+			break
+		}
+	}
+	if checkpoint, err := store.Load(); err != nil || checkpoint != nil {
+		t.Errorf("expected the checkpoint to be cleared once the workout completes, got %v, %v", checkpoint, err)
+	}
+}
+
+func TestWorkoutTimer_StartFromRejectsOtherWorkout(t *testing.T) {
+	timer, _, _ := newControlTestTimer(2)
+	checkpoint := Checkpoint{Workout: models.Workout{Rounds: make([]models.WorkoutRound, 3)}, Round: 3, Remaining: time.Second}
+	if err := timer.StartFrom(checkpoint); err == nil {
+		t.Error("expected an error resuming a checkpoint of a different workout")
+	}
+}
@@ -0,0 +1,243 @@
+package timer
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts the passage of time so timers can be driven by a fake clock in tests
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	NewTicker(d time.Duration) Ticker
+	After(d time.Duration) <-chan time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker delivers ticks at a fixed interval, like time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer is a one-shot timer created by AfterFunc, like time.Timer
+type Timer interface {
+	Stop() bool
+}
+
+// realClock is a Clock backed by the time package
+type realClock struct{}
+
+// RealClock returns a Clock backed by the system time
+func RealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{ticker: time.NewTicker(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// realTicker adapts time.Ticker to the Ticker interface
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.ticker.C }
+func (t realTicker) Stop()               { t.ticker.Stop() }
+
+// FakeClock is a Clock that only moves when Advance is called.
+// Ticks are delivered synchronously: Advance blocks until each due tick is received or its ticker is stopped,
+// and AfterFunc callbacks run on the goroutine calling Advance.
+// Like the real clock, After and AfterFunc with a non-positive duration fire straight away
+// (AfterFunc on its own goroutine).
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending ticker, After channel or AfterFunc callback
+type fakeWaiter struct {
+	when    time.Time
+	period  time.Duration // Non-zero for tickers
+	ch      chan time.Time
+	fn      func()
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// NewFakeClock creates a fake clock set to start
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns the fake time elapsed since t
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// NewTicker creates a ticker that fires every d of fake time
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("timer: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{period: d, ch: make(chan time.Time), stopped: make(chan struct{})}
+	c.add(w, d)
+	return &fakeTicker{clock: c, waiter: w}
+}
+
+// After returns a channel that receives the fake time once d has elapsed
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	w := &fakeWaiter{ch: make(chan time.Time, 1), stopped: make(chan struct{})}
+	if d <= 0 {
+		w.ch <- c.Now()
+		return w.ch
+	}
+	c.add(w, d)
+	return w.ch
+}
+
+// AfterFunc calls f once d of fake time has elapsed
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	w := &fakeWaiter{fn: f, stopped: make(chan struct{})}
+	if d <= 0 {
+		go f()
+		return &fakeTimer{clock: c, waiter: w}
+	}
+	c.add(w, d)
+	return &fakeTimer{clock: c, waiter: w}
+}
+
+// Advance moves the fake time forward by d, firing every ticker, After channel and AfterFunc callback that
+// falls due, in deadline order. Waiters registered while firing (e.g. by a callback) fire too if they fall due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	for {
+		w := c.nextDue(target)
+		if w == nil {
+			break
+		}
+		c.now = w.when
+		if w.period > 0 {
+			w.when = w.when.Add(w.period)
+		} else {
+			c.removeLocked(w)
+		}
+		now := c.now
+		c.mu.Unlock()
+		c.fire(w, now)
+		c.mu.Lock()
+	}
+	if target.After(c.now) {
+		c.now = target
+	}
+	c.mu.Unlock()
+}
+
+// BlockUntil blocks until at least n tickers, After channels or AfterFunc callbacks are pending.
+// Use it to wait for a goroutine to register its ticker before advancing the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// Waiters returns the number of pending tickers, After channels and AfterFunc callbacks
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// add registers a waiter due after d
+func (c *FakeClock) add(w *fakeWaiter, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.when = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+}
+
+// nextDue returns the waiter with the earliest deadline at or before target, or nil
+func (c *FakeClock) nextDue(target time.Time) *fakeWaiter {
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].when.Before(c.waiters[j].when)
+	})
+	if len(c.waiters) == 0 || c.waiters[0].when.After(target) {
+		return nil
+	}
+	return c.waiters[0]
+}
+
+// remove unregisters a waiter, reporting whether it was still pending
+func (c *FakeClock) remove(w *fakeWaiter) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.once.Do(func() { close(w.stopped) })
+	return c.removeLocked(w)
+}
+
+func (c *FakeClock) removeLocked(w *fakeWaiter) bool {
+	for i, pending := range c.waiters {
+		if pending == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+// fire delivers a due waiter. Ticks block until received so that the receiving goroutine keeps pace with Advance.
+func (c *FakeClock) fire(w *fakeWaiter, now time.Time) {
+	switch {
+	case w.fn != nil:
+		w.fn()
+	case w.period > 0:
+		select {
+		case w.ch <- now:
+		case <-w.stopped:
+		}
+	default:
+		w.ch <- now // Buffered, never blocks
+	}
+}
+
+// fakeTicker is a Ticker driven by a FakeClock
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.waiter.ch }
+func (t *fakeTicker) Stop()               { t.clock.remove(t.waiter) }
+
+// fakeTimer is a Timer driven by a FakeClock
+type fakeTimer struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTimer) Stop() bool { return t.clock.remove(t.waiter) }
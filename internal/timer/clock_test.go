@@ -0,0 +1,158 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_NowAndSince(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Fatalf("expected Now() = %v, got %v", start, clock.Now())
+	}
+
+	clock.Advance(90 * time.Second)
+	if got := clock.Since(start); got != 90*time.Second {
+		t.Errorf("expected Since() = 90s, got %v", got)
+	}
+}
+
+func TestFakeClock_TickerDeliversEachInterval(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+
+	ticks := make(chan time.Time, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			ticks <- <-ticker.C()
+		}
+	}()
+
+	// Advance blocks until each due tick is received
+	clock.Advance(3500 * time.Millisecond)
+	<-done
+	ticker.Stop()
+
+	if len(ticks) != 3 {
+		t.Fatalf("expected 3 ticks, got %d", len(ticks))
+	}
+	for i := 1; i <= 3; i++ {
+		tick := <-ticks
+		if want := time.Unix(int64(i), 0); !tick.Equal(want) {
+			t.Errorf("tick %d: expected %v, got %v", i, want, tick)
+		}
+	}
+	if clock.Waiters() != 0 {
+		t.Errorf("expected stopped ticker to be unregistered, got %d waiters", clock.Waiters())
+	}
+}
+
+func TestFakeClock_StoppedTickerDoesNotBlockAdvance(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+	ticker.Stop()
+
+	// Nobody is reading the ticker; Advance must not wait for it
+	clock.Advance(5 * time.Second)
+}
+
+func TestFakeClock_AfterAndAfterFunc(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	after := clock.After(2 * time.Second)
+	var order []string
+	clock.AfterFunc(time.Second, func() { order = append(order, "first") })
+	clock.AfterFunc(3*time.Second, func() { order = append(order, "third") })
+	stopped := clock.AfterFunc(2*time.Second, func() { order = append(order, "stopped") })
+
+	if !stopped.Stop() {
+		t.Error("expected Stop() to report a pending timer")
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-after:
+		t.Fatal("After fired early")
+	default:
+	}
+
+	clock.Advance(2 * time.Second)
+	select {
+	case <-after:
+	default:
+		t.Fatal("expected After to fire")
+	}
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "third" {
+		t.Errorf("expected [first third], got %v", order)
+	}
+	if stopped.Stop() {
+		t.Error("expected Stop() on a stopped timer to return false")
+	}
+}
+
+func TestFakeClock_AfterFuncCanReschedule(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	fired := 0
+	var schedule func()
+	schedule = func() {
+		clock.AfterFunc(400*time.Millisecond, func() {
+			fired++
+			schedule()
+		})
+	}
+	schedule()
+
+	// Callbacks registered while advancing fire too if they fall due
+	clock.Advance(2 * time.Second)
+	if fired != 5 {
+		t.Errorf("expected 5 callbacks in 2s, got %d", fired)
+	}
+}
+
+func TestCountdownTimer_WithFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(5*time.Second, clock)
+
+	var ticks []time.Duration
+	completed := make(chan struct{})
+	timer.OnTick(func(remaining time.Duration) {
+		ticks = append(ticks, remaining)
+	}).OnComplete(func() {
+		close(completed)
+	})
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting timer: %v", err)
+	}
+
+	clock.Advance(2 * time.Second)
+	if got := timer.Remaining(); got != 3*time.Second {
+		t.Errorf("expected 3s remaining, got %v", got)
+	}
+
+	clock.Advance(3 * time.Second)
+	select {
+	case <-completed:
+	case <-time.After(time.Second):
+		t.Fatal("timer should have completed")
+	}
+
+	want := []time.Duration{5 * time.Second, 4 * time.Second, 3 * time.Second, 2 * time.Second, 1 * time.Second, 0}
+	if len(ticks) != len(want) {
+		t.Fatalf("expected ticks %v, got %v", want, ticks)
+	}
+	for i := range want {
+		if ticks[i] != want[i] {
+			t.Errorf("tick %d: expected %v, got %v", i, want[i], ticks[i])
+		}
+	}
+	if timer.State() != StateCompleted {
+		t.Errorf("expected state Completed, got %v", timer.State())
+	}
+}
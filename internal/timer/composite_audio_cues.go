@@ -0,0 +1,223 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"sync"
+	"time"
+)
+
+// fanOutQueueSize is how many cues an output can fall behind by before its cues are dropped
+const fanOutQueueSize = 16
+
+// AudioOutput is one of the handlers a CompositeAudioCueHandler sends the cues to
+type AudioOutput struct {
+	Name       string // Names the output in errors
+	Handler    AudioCueHandler
+	Background bool          // The composite doesn't wait for the output's cues
+	Timeout    time.Duration // Longest the composite waits for one of the output's cues, 0 for as long as it takes
+}
+
+// CompositeAudioCueHandler sends every cue to several outputs at once, e.g. the speakers and a recording. Each
+// output plays its cues in order on its own goroutine, so a slow or failing output doesn't hold up the others: a
+// cue that panics is reported by Errors and the output carries on with the next one, and an output that falls too
+// far behind has cues dropped. Each cue returns once the outputs the composite waits for have played it, or their
+// timeouts have passed.
+type CompositeAudioCueHandler struct {
+	mu      sync.Mutex
+	outputs []*fanOutput
+	errs    []error
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// fanOutput is an output and the cues waiting to be played on it
+type fanOutput struct {
+	AudioOutput
+	cues chan *fanCue
+}
+
+// fanCue is a cue waiting to be played on one output
+type fanCue struct {
+	name string
+	play func(AudioCueHandler)
+	done chan struct{} // Closed once the cue has been played or dropped
+}
+
+// NewCompositeAudioCueHandler creates a handler that sends the cues to the outputs and starts their goroutines
+func NewCompositeAudioCueHandler(outputs ...AudioOutput) *CompositeAudioCueHandler {
+	c := &CompositeAudioCueHandler{}
+	for _, output := range outputs {
+		out := &fanOutput{AudioOutput: output, cues: make(chan *fanCue, fanOutQueueSize)}
+		c.outputs = append(c.outputs, out)
+		c.wg.Add(1)
+		go c.run(out)
+	}
+	return c
+}
+
+// Errors returns what went wrong on the outputs so far: cues that failed, were dropped or timed out
+func (c *CompositeAudioCueHandler) Errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]error(nil), c.errs...)
+}
+
+// Close lets the outputs play the cues already sent to them, then ends their goroutines and waits for them
+func (c *CompositeAudioCueHandler) Close() {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		for _, out := range c.outputs {
+			close(out.cues)
+		}
+	}
+	c.mu.Unlock()
+	c.wg.Wait()
+}
+
+func (c *CompositeAudioCueHandler) PlayBeep() {
+	c.play("beep", func(audio AudioCueHandler) { audio.PlayBeep() })
+}
+
+// PlayTempoClick plays each output's tempo click, or its beep if it has none
+func (c *CompositeAudioCueHandler) PlayTempoClick() {
+	c.play("tempo click", PlayTempoBeat)
+}
+
+func (c *CompositeAudioCueHandler) PlayPeriodTransition(periodType types.PeriodType) {
+	c.play("period transition", func(audio AudioCueHandler) { audio.PlayPeriodTransition(periodType) })
+}
+
+// PlayCueSound plays a cue profile's sound on each output, as beeps on the ones that can't play it
+func (c *CompositeAudioCueHandler) PlayCueSound(sound models.CueSound) {
+	c.play("cue sound", func(audio AudioCueHandler) { PlayCueSound(audio, sound, "") })
+}
+
+// PlayPeriodSound plays a cue profile's sound for a period transition on each output
+func (c *CompositeAudioCueHandler) PlayPeriodSound(periodType types.PeriodType, sound models.CueSound) {
+	c.play("period transition", func(audio AudioCueHandler) { PlayPeriodCue(audio, periodType, sound) })
+}
+
+func (c *CompositeAudioCueHandler) PlayWorkoutStart() {
+	c.play("workout start", func(audio AudioCueHandler) { audio.PlayWorkoutStart() })
+}
+
+func (c *CompositeAudioCueHandler) PlayWorkoutComplete() {
+	c.play("workout complete", func(audio AudioCueHandler) { audio.PlayWorkoutComplete() })
+}
+
+func (c *CompositeAudioCueHandler) PlayComboCallout(combo models.Combo, stance models.Stance) {
+	c.play("combo callout", func(audio AudioCueHandler) { audio.PlayComboCallout(combo, stance) })
+}
+
+func (c *CompositeAudioCueHandler) PlayRoundCallout(roundNumber int, totalRounds int) {
+	c.play("round callout", func(audio AudioCueHandler) { audio.PlayRoundCallout(roundNumber, totalRounds) })
+}
+
+func (c *CompositeAudioCueHandler) PlayCoachingCue(cue string) {
+	c.play("coaching cue", func(audio AudioCueHandler) { audio.PlayCoachingCue(cue) })
+}
+
+// Stop drops the cues waiting on every output and stops the ones playing
+func (c *CompositeAudioCueHandler) Stop() {
+	for _, out := range c.outputs {
+		c.drop(out)
+		c.call(out, "stop", func(audio AudioCueHandler) { audio.Stop() })
+	}
+}
+
+// SetContext ties the cues of the outputs that implement ContextAudioCueHandler to ctx
+func (c *CompositeAudioCueHandler) SetContext(ctx context.Context) {
+	for _, out := range c.outputs {
+		if handler, ok := out.Handler.(ContextAudioCueHandler); ok {
+			c.call(out, "set context", func(AudioCueHandler) { handler.SetContext(ctx) })
+		}
+	}
+}
+
+// play sends a cue to every output and waits for the ones the composite waits for
+func (c *CompositeAudioCueHandler) play(name string, play func(AudioCueHandler)) {
+	type waiting struct {
+		out *fanOutput
+		cue *fanCue
+	}
+	var waits []waiting
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	for _, out := range c.outputs {
+		cue := &fanCue{name: name, play: play, done: make(chan struct{})}
+		select {
+		case out.cues <- cue:
+		default:
+			c.errs = append(c.errs, fmt.Errorf("%s: fell behind, dropped the %s", out.Name, name))
+			continue
+		}
+		if !out.Background {
+			waits = append(waits, waiting{out, cue})
+		}
+	}
+	c.mu.Unlock()
+
+	sent := time.Now()
+	for _, w := range waits {
+		if w.out.Timeout <= 0 {
+			<-w.cue.done
+			continue
+		}
+		timeout := time.NewTimer(w.out.Timeout - time.Since(sent))
+		select {
+		case <-w.cue.done:
+		case <-timeout.C:
+			c.fail(fmt.Errorf("%s: the %s took longer than %v", w.out.Name, name, w.out.Timeout))
+		}
+		timeout.Stop()
+	}
+}
+
+// run plays an output's cues until the composite is closed
+func (c *CompositeAudioCueHandler) run(out *fanOutput) {
+	defer c.wg.Done()
+	for cue := range out.cues {
+		c.call(out, cue.name, cue.play)
+		close(cue.done)
+	}
+}
+
+// drop drops the cues waiting on an output
+func (c *CompositeAudioCueHandler) drop(out *fanOutput) {
+	for {
+		select {
+		case cue, ok := <-out.cues:
+			if !ok {
+				return
+			}
+			close(cue.done)
+		default:
+			return
+		}
+	}
+}
+
+// call calls the output's handler, recording a panic as the output's error rather than letting it take down the
+// others
+func (c *CompositeAudioCueHandler) call(out *fanOutput, what string, call func(AudioCueHandler)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.fail(fmt.Errorf("%s: %s failed: %v", out.Name, what, r))
+		}
+	}()
+	call(out.Handler)
+}
+
+// fail records an output's error
+func (c *CompositeAudioCueHandler) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, err)
+}
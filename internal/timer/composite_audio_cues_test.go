@@ -0,0 +1,105 @@
+package timer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// panickingAudio panics on the beep
+type panickingAudio struct {
+	*NoOpAudioCueHandler
+}
+
+func (panickingAudio) PlayBeep() { panic("no sound card") }
+
+// contextAudio records the context it is tied to
+type contextAudio struct {
+	*NoOpAudioCueHandler
+	ctx context.Context
+}
+
+func (c *contextAudio) SetContext(ctx context.Context) { c.ctx = ctx }
+
+func TestCompositeAudioCueHandler_FansOut(t *testing.T) {
+	speakers, recording := newCueRecorder(""), newCueRecorder("")
+	withContext := &contextAudio{NoOpAudioCueHandler: NewNoOpAudioCueHandler()}
+	composite := NewCompositeAudioCueHandler(
+		AudioOutput{Name: "speakers", Handler: speakers},
+		AudioOutput{Name: "recording", Handler: recording, Background: true},
+		AudioOutput{Name: "context", Handler: withContext},
+	)
+
+	ctx := context.Background()
+	composite.SetContext(ctx)
+	composite.PlayWorkoutStart()
+	composite.PlayRoundCallout(1, 2)
+	PlayTempoBeat(composite)
+	composite.PlayCoachingCue("breathe")
+	if got := speakers.played(); got != "workout start, round 1, breathe" {
+		t.Errorf("expected the speakers to have played every cue by the time it returned, got %s", got)
+	}
+	composite.Close()
+
+	if got := recording.played(); got != "workout start, round 1, breathe" {
+		t.Errorf("expected the recording to play every cue, got %s", got)
+	}
+	recording.waitForCount(t, "beep", 1) // The tempo beat, without a click of its own
+	if withContext.ctx != ctx {
+		t.Error("expected the context to be passed on")
+	}
+	if errs := composite.Errors(); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+	composite.PlayBeep() // Dropped once closed
+}
+
+func TestCompositeAudioCueHandler_IsolatesOutputs(t *testing.T) {
+	speakers := newCueRecorder("")
+	stalled := newCueRecorder("stall")
+	composite := NewCompositeAudioCueHandler(
+		AudioOutput{Name: "broken", Handler: panickingAudio{NewNoOpAudioCueHandler()}},
+		AudioOutput{Name: "slow", Handler: stalled, Timeout: 20 * time.Millisecond},
+		AudioOutput{Name: "speakers", Handler: speakers},
+	)
+
+	composite.PlayBeep()
+	composite.PlayCoachingCue("stall")
+	composite.PlayCoachingCue("breathe")
+	if got := speakers.played(); got != "stall, breathe" {
+		t.Errorf("expected the speakers to carry on, got %s", got)
+	}
+
+	stalled.release()
+	composite.Close()
+	errs := composite.Errors()
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	joined := strings.Join(messages, "; ")
+	for _, want := range []string{"broken: beep failed: no sound card", "slow: the coaching cue took longer than 20ms"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected an error %q, got %s", want, joined)
+		}
+	}
+}
+
+func TestCompositeAudioCueHandler_StopDropsWaitingCues(t *testing.T) {
+	recording := newCueRecorder("first")
+	composite := NewCompositeAudioCueHandler(AudioOutput{Name: "recording", Handler: recording, Background: true})
+
+	composite.PlayCoachingCue("first")
+	recording.waitForCount(t, "first", 1)
+	composite.PlayCoachingCue("second")
+	composite.Stop() // Stops the first cue and drops the second
+	composite.Close()
+
+	if got := recording.played(); got != "first" {
+		t.Errorf("expected the waiting cue to be dropped, got %s", got)
+	}
+	if recording.stops != 1 {
+		t.Errorf("expected the output to be stopped once, got %d", recording.stops)
+	}
+}
@@ -1,7 +1,7 @@
 package timer
 
 import (
-	"context"
+	"sync"
 	"time"
 )
 
@@ -13,164 +13,438 @@ const (
 	StateRunning
 	StatePaused
 	StateCompleted
+	StateStopped
 )
 
+// String returns the state name
+func (s TimerState) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateRunning:
+		return "running"
+	case StatePaused:
+		return "paused"
+	case StateCompleted:
+		return "completed"
+	case StateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// stateTransitions lists the states each state can move to.
+// Reset returns any timer to idle and is not listed.
+var stateTransitions = map[TimerState][]TimerState{
+	StateIdle:      {StateRunning, StateStopped},
+	StateRunning:   {StatePaused, StateCompleted, StateStopped},
+	StatePaused:    {StateRunning, StateStopped},
+	StateCompleted: {StateRunning, StateStopped},
+	StateStopped:   {StateRunning},
+}
+
+// CanTransition reports whether a timer in state from can move to state to
+func CanTransition(from, to TimerState) bool {
+	for _, next := range stateTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
 // TimerCallback is a function type for timer callbacks
 // It receives the remaining time duration
 type TimerCallback func(remaining time.Duration)
 
-// CountdownTimer represents a countdown timer that can be started, paused, and stopped
+// noAnnounce marks that there is no start/resume tick waiting to be delivered
+const noAnnounce time.Duration = -1
+
+// tickInterval is the interval between OnTick callbacks, in remaining time
+const tickInterval = 1 * time.Second
+
+// deadlineFire is sent to the run goroutine when a scheduled deadline falls due.
+// The sender waits on ack so that a FakeClock's Advance returns only once the deadline has been handled.
+type deadlineFire struct {
+	seq int
+	ack chan struct{}
+}
+
+// countdownRun is one run of a countdown timer, from Start until it completes or is stopped.
+// A single goroutine serves each run and delivers all of its callbacks, including across pause and resume.
+type countdownRun struct {
+	done chan struct{}     // Closed when the run is stopped
+	wake chan struct{}     // Signals the goroutine that there is a resume tick to deliver
+	fire chan deadlineFire // Scheduled deadlines
+}
+
+// CountdownTimer represents a countdown timer that can be started, paused, and stopped.
+// All methods are safe for concurrent use. Callbacks run on the timer's goroutine, one at a time and never while
+// its state lock is held, so they may call back into the timer.
+//
+// Ticks are scheduled against deadlines rather than a free-running ticker: OnTick fires when the remaining time
+// crosses each whole second (and OnFineTick at each multiple of its interval), measured from when the timer was
+// started, so pausing mid-second doesn't shift the ticks and late ticks don't accumulate drift.
 type CountdownTimer struct {
+	mu            sync.Mutex
+	callbackMu    sync.Mutex // Serializes callbacks, including a stopped run's last callback and a new run's first
 	duration      time.Duration
 	state         TimerState
-	ctx           context.Context
-	cancel        context.CancelFunc
+	run           *countdownRun
 	onTick        TimerCallback
+	onFineTick    TimerCallback
+	fineInterval  time.Duration // 0 disables fine ticks
 	onComplete    func()
-	ticker        *time.Ticker
+	clock         Clock
 	startTime     time.Time
 	pausedAt      time.Duration
 	initialRemain time.Duration // Tracks the remaining time when timer started/resumed
+	announce      time.Duration // Remaining time to report immediately on start/resume, or noAnnounce
+	pending       Timer         // Timer for the next deadline, nil unless running
+	seq           int           // Identifies the current deadline; stale fires carry an older value
+	nextTarget    time.Duration // Remaining time at the next deadline
 }
 
 // NewCountdownTimer creates a new countdown timer with the specified duration
 func NewCountdownTimer(duration time.Duration) *CountdownTimer {
+	return NewCountdownTimerWithClock(duration, RealClock())
+}
+
+// NewCountdownTimerWithClock creates a new countdown timer driven by the given clock
+func NewCountdownTimerWithClock(duration time.Duration, clock Clock) *CountdownTimer {
 	return &CountdownTimer{
 		duration: duration,
 		state:    StateIdle,
+		clock:    clock,
+		announce: noAnnounce,
 	}
 }
 
-// OnTick sets a callback function that will be called on each tick (every second)
+// OnTick sets a callback function that will be called on start, resume and each whole second of remaining time
 func (t *CountdownTimer) OnTick(callback TimerCallback) *CountdownTimer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.onTick = callback
 	return t
 }
 
+// OnFineTick sets a callback called every interval of remaining time (e.g. 100ms for a smooth progress bar),
+// in addition to OnTick. Set it before starting the timer; an interval of 0 disables fine ticks.
+func (t *CountdownTimer) OnFineTick(interval time.Duration, callback TimerCallback) *CountdownTimer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if interval < 0 {
+		interval = 0
+	}
+	t.fineInterval = interval
+	t.onFineTick = callback
+	return t
+}
+
 // OnComplete sets a callback function that will be called when the timer completes
 func (t *CountdownTimer) OnComplete(callback func()) *CountdownTimer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.onComplete = callback
 	return t
 }
 
-// Start starts the countdown timer
+// Start starts the countdown timer, or resumes it if paused
 func (t *CountdownTimer) Start() error {
-	if t.state == StateRunning {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !CanTransition(t.state, StateRunning) {
 		return nil // Already running
 	}
 
-	t.ctx, t.cancel = context.WithCancel(context.Background())
 	if t.state == StatePaused {
-		// Resume from paused state
+		// Resume from paused state on the same run goroutine
 		t.initialRemain = t.pausedAt
-		t.startTime = time.Now()
+		t.startTime = t.clock.Now()
 		t.state = StateRunning
-		go t.run(t.pausedAt)
+		t.announce = t.pausedAt
+		t.scheduleLocked(t.pausedAt)
+		select {
+		case t.run.wake <- struct{}{}:
+		default:
+		}
 		return nil
 	}
 
 	// Start fresh
 	t.initialRemain = t.duration
-	t.startTime = time.Now()
+	t.startTime = t.clock.Now()
 	t.pausedAt = 0
 	t.state = StateRunning
-	go t.run(t.duration)
+	t.announce = t.duration
+	t.run = &countdownRun{
+		done: make(chan struct{}),
+		wake: make(chan struct{}, 1),
+		fire: make(chan deadlineFire),
+	}
+	t.scheduleLocked(t.duration)
+	go t.loop(t.run)
 	return nil
 }
 
 // Pause pauses the countdown timer
 func (t *CountdownTimer) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	if t.state != StateRunning {
 		return
 	}
-
-	t.cancel()
-	t.pausedAt = t.Remaining() // Use Remaining() to get accurate value
+	t.pausedAt = t.remainingAt(t.clock.Now())
 	t.state = StatePaused
-	if t.ticker != nil {
-		t.ticker.Stop()
-	}
+	t.cancelDeadlineLocked()
+	t.announce = noAnnounce
 }
 
-// Stop stops the countdown timer and resets it
+// Stop stops the countdown timer. A stopped timer reports its full duration and can be started again.
 func (t *CountdownTimer) Stop() {
-	if t.cancel != nil {
-		t.cancel()
-	}
-	if t.ticker != nil {
-		t.ticker.Stop()
-	}
-	t.state = StateIdle
-	t.pausedAt = 0
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopLocked()
 }
 
 // Reset resets the timer to its initial duration
 func (t *CountdownTimer) Reset() {
-	t.Stop()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopLocked()
+	t.state = StateIdle
+}
+
+// stopLocked ends the current run. The caller must hold t.mu.
+func (t *CountdownTimer) stopLocked() {
+	if t.run != nil {
+		close(t.run.done)
+		t.run = nil
+	}
+	t.cancelDeadlineLocked()
+	t.state = StateStopped
+	t.pausedAt = 0
+	t.announce = noAnnounce
+}
+
+// AddTime extends the countdown by d. A running timer reschedules its next tick so ticks stay on whole seconds
+// of the new remaining time. It has no effect on a completed or stopped timer.
+func (t *CountdownTimer) AddTime(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if d <= 0 {
+		return
+	}
+	switch t.state {
+	case StateRunning:
+		t.duration += d
+		t.initialRemain += d
+		t.scheduleLocked(t.remainingAt(t.clock.Now()))
+	case StatePaused:
+		t.duration += d
+		t.pausedAt += d
+	case StateIdle:
+		t.duration += d
+	}
+}
+
+// TrimTo shortens the countdown so that no more than d remains. It has no effect if less than d remains already,
+// or on a completed or stopped timer.
+func (t *CountdownTimer) TrimTo(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if d < 0 {
+		d = 0
+	}
+	switch t.state {
+	case StateRunning:
+		remaining := t.remainingAt(t.clock.Now())
+		if remaining <= d {
+			return
+		}
+		t.duration -= remaining - d
+		t.initialRemain -= remaining - d
+		t.scheduleLocked(d)
+	case StatePaused:
+		if t.pausedAt > d {
+			t.duration -= t.pausedAt - d
+			t.pausedAt = d
+		}
+	case StateIdle:
+		if t.duration > d {
+			t.duration = d
+		}
+	}
+}
+
+// nextBoundary returns the next remaining time below from at which a tick is due:
+// the next whole second, or the next multiple of fineInterval if that comes first
+func nextBoundary(from, fineInterval time.Duration) time.Duration {
+	if from <= 0 {
+		return 0
+	}
+	target := ((from - 1) / tickInterval) * tickInterval
+	if fineInterval > 0 {
+		if fine := ((from - 1) / fineInterval) * fineInterval; fine > target {
+			target = fine
+		}
+	}
+	return target
+}
+
+// scheduleLocked schedules the deadline for the next boundary below the given remaining time.
+// The deadline is fixed relative to when the timer was started or resumed, so a late tick doesn't delay later ones.
+// The caller must hold t.mu.
+func (t *CountdownTimer) scheduleLocked(from time.Duration) {
+	t.cancelDeadlineLocked()
+	target := nextBoundary(from, t.fineInterval)
+	deadline := t.startTime.Add(t.initialRemain - target)
+	t.nextTarget = target
+	seq, run := t.seq, t.run
+	t.pending = t.clock.AfterFunc(deadline.Sub(t.clock.Now()), func() {
+		ack := make(chan struct{})
+		select {
+		case run.fire <- deadlineFire{seq: seq, ack: ack}:
+			<-ack
+		case <-run.done:
+		}
+	})
+}
+
+// cancelDeadlineLocked cancels the pending deadline; a fire already in flight is ignored. The caller must hold t.mu.
+func (t *CountdownTimer) cancelDeadlineLocked() {
+	if t.pending != nil {
+		t.pending.Stop()
+		t.pending = nil
+	}
+	t.seq++
 }
 
 // Remaining returns the remaining time duration
 func (t *CountdownTimer) Remaining() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	switch t.state {
 	case StateRunning:
-		elapsed := time.Since(t.startTime)
-		remaining := t.initialRemain - elapsed
-		if remaining < 0 {
-			remaining = 0
-		}
-		return remaining
+		return t.remainingAt(t.clock.Now())
 	case StatePaused:
 		return t.pausedAt
 	case StateCompleted:
 		return 0
-	default: // StateIdle
+	default: // StateIdle, StateStopped
 		return t.duration
 	}
 }
 
+// remainingAt returns the time left on a running timer at the given instant. The caller must hold t.mu.
+func (t *CountdownTimer) remainingAt(now time.Time) time.Duration {
+	remaining := t.initialRemain - now.Sub(t.startTime)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining
+}
+
 // State returns the current state of the timer
 func (t *CountdownTimer) State() TimerState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	return t.state
 }
 
 // Duration returns the total duration of the timer
 func (t *CountdownTimer) Duration() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	return t.duration
 }
 
-// run is the internal goroutine that runs the countdown
-func (t *CountdownTimer) run(startFrom time.Duration) {
-	t.ticker = time.NewTicker(1 * time.Second)
-	defer t.ticker.Stop()
+// loop is the goroutine that serves one run of the countdown and delivers its callbacks
+func (t *CountdownTimer) loop(run *countdownRun) {
+	for {
+		t.callbackMu.Lock()
+		t.mu.Lock()
+		if t.run != run {
+			t.mu.Unlock()
+			t.callbackMu.Unlock()
+			return // Stopped or restarted
+		}
+		announce := t.announce
+		t.announce = noAnnounce
+		onTick, onFineTick := t.onTick, t.onFineTick
+		t.mu.Unlock()
 
-	// Initial callback
-	if t.onTick != nil {
-		t.onTick(startFrom)
-	}
+		// Initial callback on start and resume
+		if announce != noAnnounce {
+			if onFineTick != nil {
+				onFineTick(announce)
+			}
+			if onTick != nil {
+				onTick(announce)
+			}
+		}
+		t.callbackMu.Unlock()
+		if announce != noAnnounce {
+			continue
+		}
 
-	for {
 		select {
-		case <-t.ctx.Done():
+		case <-run.done:
 			return
-		case <-t.ticker.C:
-			remaining := t.Remaining()
-			if remaining <= 0 {
-				t.state = StateCompleted
-				if t.onTick != nil {
-					t.onTick(0)
-				}
-				if t.onComplete != nil {
-					t.onComplete()
-				}
+		case <-run.wake:
+			// Resumed: deliver the resume tick
+		case fire := <-run.fire:
+			completed := t.onDeadline(run, fire.seq)
+			close(fire.ack)
+			if completed {
 				return
 			}
-			if t.onTick != nil {
-				t.onTick(remaining)
-			}
 		}
 	}
 }
 
+// onDeadline delivers the callbacks for a deadline, schedules the next one and reports whether the timer completed
+func (t *CountdownTimer) onDeadline(run *countdownRun, seq int) bool {
+	t.callbackMu.Lock()
+	defer t.callbackMu.Unlock()
+
+	t.mu.Lock()
+	if t.run != run || t.state != StateRunning || seq != t.seq {
+		// Stale deadline from before a pause or stop
+		t.mu.Unlock()
+		return false
+	}
+	remaining := t.nextTarget
+	completed := remaining <= 0
+	if completed {
+		t.state = StateCompleted
+		t.pending = nil
+		t.run = nil
+	} else {
+		t.scheduleLocked(remaining)
+	}
+	onTick, onFineTick, onComplete := t.onTick, t.onFineTick, t.onComplete
+	wholeSecond := remaining%tickInterval == 0
+	t.mu.Unlock()
+
+	if onFineTick != nil {
+		onFineTick(remaining)
+	}
+	if wholeSecond && onTick != nil {
+		onTick(remaining)
+	}
+	if completed && onComplete != nil {
+		onComplete()
+	}
+	return completed
+}
+
 // WorkPeriodTimer is a specialized timer for work periods
 type WorkPeriodTimer struct {
 	*CountdownTimer
@@ -178,8 +452,13 @@ type WorkPeriodTimer struct {
 
 // NewWorkPeriodTimer creates a new work period timer
 func NewWorkPeriodTimer(duration time.Duration) *WorkPeriodTimer {
+	return NewWorkPeriodTimerWithClock(duration, RealClock())
+}
+
+// NewWorkPeriodTimerWithClock creates a new work period timer driven by the given clock
+func NewWorkPeriodTimerWithClock(duration time.Duration, clock Clock) *WorkPeriodTimer {
 	return &WorkPeriodTimer{
-		CountdownTimer: NewCountdownTimer(duration),
+		CountdownTimer: NewCountdownTimerWithClock(duration, clock),
 	}
 }
 
@@ -190,7 +469,12 @@ type RestPeriodTimer struct {
 
 // NewRestPeriodTimer creates a new rest period timer
 func NewRestPeriodTimer(duration time.Duration) *RestPeriodTimer {
+	return NewRestPeriodTimerWithClock(duration, RealClock())
+}
+
+// NewRestPeriodTimerWithClock creates a new rest period timer driven by the given clock
+func NewRestPeriodTimerWithClock(duration time.Duration, clock Clock) *RestPeriodTimer {
 	return &RestPeriodTimer{
-		CountdownTimer: NewCountdownTimer(duration),
+		CountdownTimer: NewCountdownTimerWithClock(duration, clock),
 	}
 }
@@ -2,19 +2,20 @@ package timer
 
 import (
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
 func TestCountdownTimer_StartAndComplete(t *testing.T) {
 	timer := NewCountdownTimer(2 * time.Second)
-	completed := false
-	var lastTick time.Duration
+	var completed atomic.Bool
+	var lastTick atomic.Int64
 
 	timer.OnTick(func(remaining time.Duration) {
-		lastTick = remaining
+		lastTick.Store(int64(remaining))
 	}).OnComplete(func() {
-		completed = true
+		completed.Store(true)
 	})
 
 	// After the timer completes, validate that lastTick reached zero (or close, given possible race)
@@ -27,7 +28,7 @@ func TestCountdownTimer_StartAndComplete(t *testing.T) {
 	// Wait for completion
 	time.Sleep(2500 * time.Millisecond)
 
-	if !completed {
+	if !completed.Load() {
 		t.Fatalf("timer should have completed")
 	}
 
@@ -39,8 +40,8 @@ func TestCountdownTimer_StartAndComplete(t *testing.T) {
 		t.Fatalf("expected remaining time 0, got %v", timer.Remaining())
 	}
 
-	if lastTick != 0 {
-		t.Fatalf("expected last tick to be 0, got %v", lastTick)
+	if lastTick.Load() != 0 {
+		t.Fatalf("expected last tick to be 0, got %v", time.Duration(lastTick.Load()))
 	}
 }
 
@@ -106,10 +107,10 @@ func TestCountdownTimer_PauseAndResume(t *testing.T) {
 
 func TestCountdownTimer_Stop(t *testing.T) {
 	timer := NewCountdownTimer(5 * time.Second)
-	completed := false
+	var completed atomic.Bool
 
 	timer.OnComplete(func() {
-		completed = true
+		completed.Store(true)
 	})
 
 	if err := timer.Start(); err != nil {
@@ -120,13 +121,13 @@ func TestCountdownTimer_Stop(t *testing.T) {
 
 	timer.Stop()
 
-	if timer.State() != StateIdle {
-		t.Fatalf("expected state Idle, got %v", timer.State())
+	if timer.State() != StateStopped {
+		t.Fatalf("expected state Stopped, got %v", timer.State())
 	}
 
 	time.Sleep(2 * time.Second)
 
-	if completed {
+	if completed.Load() {
 		t.Fatalf("timer should not complete after being stopped")
 	}
 
@@ -157,10 +158,10 @@ func TestCountdownTimer_Reset(t *testing.T) {
 
 func TestWorkPeriodTimer(t *testing.T) {
 	workTimer := NewWorkPeriodTimer(2 * time.Second)
-	completed := false
+	var completed atomic.Bool
 
 	workTimer.OnComplete(func() {
-		completed = true
+		completed.Store(true)
 	})
 
 	if err := workTimer.Start(); err != nil {
@@ -169,17 +170,17 @@ func TestWorkPeriodTimer(t *testing.T) {
 
 	time.Sleep(2500 * time.Millisecond)
 
-	if !completed {
+	if !completed.Load() {
 		t.Fatalf("work timer should have completed")
 	}
 }
 
 func TestRestPeriodTimer(t *testing.T) {
 	restTimer := NewRestPeriodTimer(1 * time.Second)
-	completed := false
+	var completed atomic.Bool
 
 	restTimer.OnComplete(func() {
-		completed = true
+		completed.Store(true)
 	})
 
 	if err := restTimer.Start(); err != nil {
@@ -188,7 +189,7 @@ func TestRestPeriodTimer(t *testing.T) {
 
 	time.Sleep(1500 * time.Millisecond)
 
-	if !completed {
+	if !completed.Load() {
 		t.Fatalf("rest timer should have completed")
 	}
 }
@@ -207,3 +208,402 @@ func TestCountdownTimer_RemainingTime(t *testing.T) {
 		t.Fatalf("expected remaining time around 3s, got %v", remaining)
 	}
 }
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to TimerState
+		want     bool
+	}{
+		{StateIdle, StateRunning, true},
+		{StateIdle, StatePaused, false},
+		{StateRunning, StatePaused, true},
+		{StateRunning, StateCompleted, true},
+		{StateRunning, StateStopped, true},
+		{StatePaused, StateRunning, true},
+		{StatePaused, StateCompleted, false},
+		{StateCompleted, StateRunning, true},
+		{StateCompleted, StatePaused, false},
+		{StateStopped, StateRunning, true},
+		{StateStopped, StatePaused, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCountdownTimer_StopThenStartAgain(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(3*time.Second, clock)
+	completed := make(chan struct{})
+	timer.OnComplete(func() { close(completed) })
+
+	timer.Start()
+	clock.Advance(time.Second)
+	timer.Stop()
+	if timer.State() != StateStopped || timer.Remaining() != 3*time.Second {
+		t.Fatalf("expected stopped timer with full duration, got %v with %v", timer.State(), timer.Remaining())
+	}
+
+	// Ticks after Stop are ignored
+	clock.Advance(5 * time.Second)
+	if timer.State() != StateStopped {
+		t.Fatalf("expected timer to stay stopped, got %v", timer.State())
+	}
+
+	// A stopped timer starts again from its full duration
+	timer.Start()
+	clock.Advance(3 * time.Second)
+	select {
+	case <-completed:
+	case <-time.After(time.Second):
+		t.Fatal("restarted timer should have completed")
+	}
+}
+
+// TestCountdownTimer_ConcurrentControl hammers a timer from several goroutines; run with -race
+func TestCountdownTimer_ConcurrentControl(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(30*time.Second, clock)
+
+	var inCallback, overlaps atomic.Int32
+	callback := func() {
+		if inCallback.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(10 * time.Microsecond)
+		inCallback.Add(-1)
+	}
+	timer.OnTick(func(time.Duration) { callback() }).OnComplete(callback)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	controls := []func(){
+		func() { timer.Start() },
+		timer.Pause,
+		timer.Stop,
+		func() { _ = timer.Remaining() },
+		func() { _ = timer.State() },
+	}
+	for _, control := range controls {
+		wg.Add(1)
+		go func(control func()) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				control()
+			}
+		}(control)
+	}
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				clock.Advance(500 * time.Millisecond)
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	timer.Stop()
+
+	if overlaps.Load() > 0 {
+		t.Errorf("callbacks overlapped %d times", overlaps.Load())
+	}
+	if timer.State() != StateStopped {
+		t.Errorf("expected state Stopped, got %v", timer.State())
+	}
+}
+
+func TestNextBoundary(t *testing.T) {
+	tests := []struct {
+		name         string
+		from         time.Duration
+		fineInterval time.Duration
+		want         time.Duration
+	}{
+		{name: "whole second", from: 5 * time.Second, want: 4 * time.Second},
+		{name: "mid second", from: 4300 * time.Millisecond, want: 4 * time.Second},
+		{name: "under a second", from: 300 * time.Millisecond, want: 0},
+		{name: "zero", from: 0, want: 0},
+		{name: "fine interval", from: 5 * time.Second, fineInterval: 100 * time.Millisecond, want: 4900 * time.Millisecond},
+		{name: "fine interval mid step", from: 4350 * time.Millisecond, fineInterval: 100 * time.Millisecond, want: 4300 * time.Millisecond},
+		{name: "fine interval not dividing a second", from: 1100 * time.Millisecond, fineInterval: 300 * time.Millisecond, want: 1 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextBoundary(tt.from, tt.fineInterval); got != tt.want {
+				t.Errorf("nextBoundary(%v, %v) = %v, want %v", tt.from, tt.fineInterval, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountdownTimer_PauseMidSecondKeepsTicksOnWholeSeconds(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(10*time.Second, clock)
+	var mu sync.Mutex
+	var ticks []time.Duration
+	timer.OnTick(func(remaining time.Duration) {
+		mu.Lock()
+		ticks = append(ticks, remaining)
+		mu.Unlock()
+	})
+
+	timer.Start()
+	clock.Advance(2500 * time.Millisecond)
+	timer.Pause()
+	clock.Advance(3 * time.Second)
+	timer.Start()
+	// The first tick after resuming comes half a second later, when 7s remain
+	clock.Advance(500 * time.Millisecond)
+	clock.Advance(1 * time.Second)
+	timer.Stop()
+
+	// Let the run goroutine deliver the resume tick if it hasn't yet
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	want := []time.Duration{10 * time.Second, 9 * time.Second, 8 * time.Second, 7500 * time.Millisecond, 7 * time.Second, 6 * time.Second}
+	if len(ticks) != len(want) {
+		t.Fatalf("expected ticks %v, got %v", want, ticks)
+	}
+	for i := range want {
+		if ticks[i] != want[i] {
+			t.Errorf("tick %d: expected %v, got %v", i, want[i], ticks[i])
+		}
+	}
+}
+
+func TestCountdownTimer_FineTicks(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(1*time.Second, clock)
+	var fine, whole []time.Duration
+	completed := make(chan struct{})
+	timer.OnTick(func(remaining time.Duration) {
+		whole = append(whole, remaining)
+	}).OnFineTick(100*time.Millisecond, func(remaining time.Duration) {
+		fine = append(fine, remaining)
+	}).OnComplete(func() {
+		close(completed)
+	})
+
+	timer.Start()
+	for i := 0; i < 10; i++ {
+		clock.Advance(100 * time.Millisecond)
+	}
+	select {
+	case <-completed:
+	case <-time.After(time.Second):
+		t.Fatal("timer should have completed")
+	}
+
+	if len(fine) != 11 || fine[0] != time.Second || fine[5] != 500*time.Millisecond || fine[10] != 0 {
+		t.Errorf("expected fine ticks from 1s to 0 every 100ms, got %v", fine)
+	}
+	if len(whole) != 2 || whole[0] != time.Second || whole[1] != 0 {
+		t.Errorf("expected whole-second ticks [1s 0s], got %v", whole)
+	}
+}
+
+// TestCountdownTimer_NoDriftOverLongWorkout runs a 40 minute countdown with irregular clock steps and mid-second
+// pauses, and checks every whole-second tick lands exactly on its deadline
+func TestCountdownTimer_NoDriftOverLongWorkout(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	duration := 40 * time.Minute
+	timer := NewCountdownTimerWithClock(duration, clock)
+
+	type tick struct {
+		remaining time.Duration
+		at        time.Time
+	}
+	var mu sync.Mutex
+	var ticks []tick
+	var paused time.Duration // Total time spent paused, for computing running time
+	completed := make(chan struct{})
+	timer.OnTick(func(remaining time.Duration) {
+		mu.Lock()
+		ticks = append(ticks, tick{remaining: remaining, at: clock.Now().Add(-paused)})
+		mu.Unlock()
+	}).OnComplete(func() {
+		close(completed)
+	})
+
+	timer.Start()
+	steps := []time.Duration{730 * time.Millisecond, 1100 * time.Millisecond, 90 * time.Millisecond, 2300 * time.Millisecond}
+	for i := 0; ; i++ {
+		select {
+		case <-completed:
+		default:
+			if i%97 == 50 {
+				// Pause mid-second for a while
+				timer.Pause()
+				clock.Advance(1750 * time.Millisecond)
+				mu.Lock()
+				paused += 1750 * time.Millisecond
+				mu.Unlock()
+				timer.Start()
+			}
+			clock.Advance(steps[i%len(steps)])
+			continue
+		}
+		break
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := duration
+	for _, tk := range ticks {
+		if tk.remaining%time.Second != 0 {
+			continue // Resume tick
+		}
+		if tk.remaining != expected {
+			t.Fatalf("expected tick at %v remaining, got %v (skipped or repeated a second)", expected, tk.remaining)
+		}
+		// The starting tick is delivered asynchronously, so only deadline ticks are checked for drift
+		if drift := tk.at.Sub(start) - (duration - tk.remaining); tk.remaining != duration && drift != 0 {
+			t.Errorf("tick at %v remaining drifted by %v", tk.remaining, drift)
+		}
+		expected -= time.Second
+	}
+	if expected != -time.Second {
+		t.Errorf("expected ticks down to 0, stopped at %v", expected+time.Second)
+	}
+}
+
+func TestCountdownTimer_FineTicksRealClock(t *testing.T) {
+	timer := NewCountdownTimer(1 * time.Second)
+	var fineTicks atomic.Int32
+	completed := make(chan struct{})
+	timer.OnFineTick(100*time.Millisecond, func(time.Duration) {
+		fineTicks.Add(1)
+	}).OnComplete(func() {
+		close(completed)
+	})
+
+	started := time.Now()
+	timer.Start()
+	select {
+	case <-completed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timer should have completed")
+	}
+
+	if elapsed := time.Since(started); elapsed < time.Second || elapsed > 1300*time.Millisecond {
+		t.Errorf("expected completion after about 1s, took %v", elapsed)
+	}
+	if got := fineTicks.Load(); got != 11 {
+		t.Errorf("expected 11 fine ticks, got %d", got)
+	}
+}
+
+func TestCountdownTimer_AddTime(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(5*time.Second, clock)
+	var mu sync.Mutex
+	var ticks []time.Duration
+	timer.OnTick(func(remaining time.Duration) {
+		mu.Lock()
+		ticks = append(ticks, remaining)
+		mu.Unlock()
+	})
+
+	// Idle timers grow their duration
+	timer.AddTime(1 * time.Second)
+	if got := timer.Remaining(); got != 6*time.Second {
+		t.Errorf("expected 6s on an idle timer, got %v", got)
+	}
+
+	timer.Start()
+	clock.Advance(1500 * time.Millisecond)
+	timer.AddTime(10 * time.Second)
+	if got := timer.Remaining(); got != 14500*time.Millisecond {
+		t.Errorf("expected 14.5s remaining, got %v", got)
+	}
+
+	// The next tick comes at 14s remaining, not 4s
+	clock.Advance(500 * time.Millisecond)
+	timer.Pause()
+	timer.AddTime(1 * time.Second)
+	if got := timer.Remaining(); got != 15*time.Second {
+		t.Errorf("expected 15s remaining while paused, got %v", got)
+	}
+	if got := timer.Duration(); got != 17*time.Second {
+		t.Errorf("expected duration 17s, got %v", got)
+	}
+	timer.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []time.Duration{6 * time.Second, 5 * time.Second, 14 * time.Second}
+	if len(ticks) != len(want) {
+		t.Fatalf("expected ticks %v, got %v", want, ticks)
+	}
+	for i := range want {
+		if ticks[i] != want[i] {
+			t.Errorf("tick %d: expected %v, got %v", i, want[i], ticks[i])
+		}
+	}
+}
+
+func TestCountdownTimer_TrimTo(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewCountdownTimerWithClock(10*time.Second, clock)
+	var mu sync.Mutex
+	var ticks []time.Duration
+	completed := make(chan struct{})
+	timer.OnTick(func(remaining time.Duration) {
+		mu.Lock()
+		ticks = append(ticks, remaining)
+		mu.Unlock()
+	}).OnComplete(func() { close(completed) })
+
+	timer.Start()
+	clock.Advance(1500 * time.Millisecond)
+	timer.TrimTo(3 * time.Second)
+	if got := timer.Remaining(); got != 3*time.Second {
+		t.Errorf("expected 3s remaining, got %v", got)
+	}
+	timer.TrimTo(5 * time.Second) // Never lengthens the countdown
+	if got := timer.Remaining(); got != 3*time.Second {
+		t.Errorf("expected 3s remaining after trimming to more, got %v", got)
+	}
+
+	// The next tick comes at 2s remaining
+	clock.Advance(1 * time.Second)
+	timer.Pause()
+	timer.TrimTo(1 * time.Second)
+	if got := timer.Remaining(); got != 1*time.Second {
+		t.Errorf("expected 1s remaining while paused, got %v", got)
+	}
+	if got := timer.Duration(); got != 3500*time.Millisecond {
+		t.Errorf("expected duration 3.5s, got %v", got)
+	}
+
+	timer.Start()
+	clock.BlockUntil(1)
+	clock.Advance(1 * time.Second)
+	select {
+	case <-completed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timer should have completed")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []time.Duration{10 * time.Second, 9 * time.Second, 2 * time.Second, 1 * time.Second, 0}
+	if len(ticks) != len(want) {
+		t.Fatalf("expected ticks %v, got %v", want, ticks)
+	}
+	for i := range want {
+		if ticks[i] != want[i] {
+			t.Errorf("tick %d: expected %v, got %v", i, want[i], ticks[i])
+		}
+	}
+}
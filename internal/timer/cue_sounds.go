@@ -0,0 +1,79 @@
+package timer
+
+import (
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
+	"heavybagworkout/internal/types"
+	"time"
+)
+
+// cueStrikeInterval is the time between the strikes of a sound played more than once, e.g. the three bells at the
+// end of a round
+const cueStrikeInterval = 400 * time.Millisecond
+
+// CueSoundHandler is implemented by audio handlers that can play the bells and clappers of a cue profile.
+// Without it, each strike of a bell or clapper plays the beep.
+type CueSoundHandler interface {
+	PlayCueSound(sound models.CueSound)                                 // Plays a sound other than speech
+	PlayPeriodSound(periodType types.PeriodType, sound models.CueSound) // Plays a period transition's sound in place of PlayPeriodTransition
+}
+
+// PlayCueSound plays a cue profile's sound on an audio handler: voice speaks text, the beep plays the handler's
+// beep, and bells and clappers are played by handlers that implement CueSoundHandler
+func PlayCueSound(audio AudioCueHandler, sound models.CueSound, text string) {
+	switch {
+	case sound.IsSilent():
+	case sound.IsVoice():
+		audio.PlayCoachingCue(text)
+	case sound.Sound == models.SoundBeep:
+		playBeeps(audio, sound.Times)
+	default:
+		if handler, ok := audio.(CueSoundHandler); ok {
+			handler.PlayCueSound(sound)
+		} else {
+			playBeeps(audio, sound.Times)
+		}
+	}
+}
+
+// PlayPeriodCue plays a cue profile's sound for a period transition on an audio handler: voice plays the handler's
+// period transition, other sounds are played by handlers that implement CueSoundHandler, or as PlayCueSound does
+func PlayPeriodCue(audio AudioCueHandler, periodType types.PeriodType, sound models.CueSound) {
+	if sound.IsVoice() {
+		audio.PlayPeriodTransition(periodType)
+	} else if handler, ok := audio.(CueSoundHandler); ok {
+		handler.PlayPeriodSound(periodType, sound)
+	} else {
+		PlayCueSound(audio, sound, "")
+	}
+}
+
+// playBeeps plays the handler's beep the given number of times, at least once
+func playBeeps(audio AudioCueHandler, times int) {
+	for range max(times, 1) {
+		audio.PlayBeep()
+	}
+}
+
+// cueTone returns the tone of a cue profile's sound, false for speech and silence
+func cueTone(sound models.CueSound) (synth.Tone, bool) {
+	switch sound.Sound {
+	case models.SoundBell:
+		return synth.Bell(), true
+	case models.SoundClapper:
+		return synth.Clapper(), true
+	case models.SoundBeep:
+		return synth.Pip(), true
+	default:
+		return synth.Tone{}, false
+	}
+}
+
+// renderCueSound renders a cue profile's sound with its strikes, nil for speech and silence
+func renderCueSound(sound models.CueSound, sampleRate int) []float64 {
+	tone, ok := cueTone(sound)
+	if !ok {
+		return nil
+	}
+	return synth.Strikes(tone, sound.Times, cueStrikeInterval, sampleRate)
+}
@@ -0,0 +1,93 @@
+package timer
+
+import (
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+// soundRecorder records the cues it plays, with the cue profile's sounds
+type soundRecorder struct {
+	*cueRecorder
+}
+
+func (r soundRecorder) PlayCueSound(sound models.CueSound) { r.play(sound.String()) }
+func (r soundRecorder) PlayPeriodSound(periodType types.PeriodType, sound models.CueSound) {
+	r.play(strings.ToLower(periodName(periodType)) + " " + sound.String())
+}
+
+func TestPlayCueSound(t *testing.T) {
+	bells := models.CueSound{Sound: models.SoundBell, Times: 3}
+	tests := []struct {
+		name  string
+		play  func(AudioCueHandler)
+		want  string
+		beeps int
+	}{
+		{"voice", func(a AudioCueHandler) { PlayCueSound(a, models.Voice, "last round") }, "last round", 0},
+		{"silent", func(a AudioCueHandler) { PlayCueSound(a, models.CueSound{}, "last round") }, "", 0},
+		{"beeps", func(a AudioCueHandler) { PlayCueSound(a, models.CueSound{Sound: models.SoundBeep, Times: 2}, "") }, "", 2},
+		{"bells as beeps", func(a AudioCueHandler) { PlayCueSound(a, bells, "") }, "", 3},
+		{"spoken period", func(a AudioCueHandler) { PlayPeriodCue(a, types.PeriodRest, models.Voice) }, "rest", 0},
+		{"period bells as beeps", func(a AudioCueHandler) { PlayPeriodCue(a, types.PeriodRest, bells) }, "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audio := newCueRecorder("")
+			tt.play(audio)
+			if got := audio.played(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			beeps := 0
+			for _, call := range audio.calls {
+				if call == "beep" {
+					beeps++
+				}
+			}
+			if beeps != tt.beeps {
+				t.Errorf("expected %d beeps, got %d", tt.beeps, beeps)
+			}
+		})
+	}
+
+	audio := soundRecorder{newCueRecorder("")}
+	PlayPeriodCue(audio, types.PeriodRest, bells)
+	PlayCueSound(audio, models.CueSound{Sound: models.SoundClapper, Times: 1}, "")
+	if got := audio.played(); got != "rest bell x3, clapper" {
+		t.Errorf("expected the handler to play the sounds, got %s", got)
+	}
+}
+
+func TestWorkoutTimer_GymCueProfile(t *testing.T) {
+	timer, clock, _ := newControlTestTimer(2)
+	audio := soundRecorder{newCueRecorder("")}
+	timer.SetAudioHandler(audio)
+	gym := models.GymCueProfile()
+	gym.WarningBefore = time.Second // The work periods are 3s
+	timer.SetCueProfile(&gym)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	if !advanceUntilDone(clock, completed, 40) {
+		t.Fatal("workout did not complete")
+	}
+
+	want := "workout start, work bell, round 1, combo, clapper, rest bell x3, " +
+		"work bell, last round, round 2, combo, clapper, rest bell x3, workout complete"
+	if got := audio.played(); got != want {
+		t.Errorf("expected cues %s, got %s", want, got)
+	}
+	audio.mu.Lock()
+	defer audio.mu.Unlock()
+	for _, call := range audio.calls {
+		if call == "beep" {
+			t.Fatal("expected no countdown beeps with the gym profile")
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package timer
+
+import (
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType identifies a workout event
+type EventType int
+
+const (
+	EventWorkoutStarted EventType = iota
+	EventPeriodStarted
+	EventTick
+	EventPeriodEnded
+	EventComboCue // The round's combo is called out, before its work period starts
+	EventPaused
+	EventResumed
+	EventRoundCompleted
+	EventWorkoutCompleted
+	EventStopped
+	EventBeat      // A tempo beat in a work period: throw the combo, starting with its first move
+	EventMove      // The next move of the combo within a beat
+	EventComboDone // Every move of the combo has been thrown; nothing until the next beat
+)
+
+// String returns the event type name
+func (t EventType) String() string {
+	switch t {
+	case EventWorkoutStarted:
+		return "workout started"
+	case EventPeriodStarted:
+		return "period started"
+	case EventTick:
+		return "tick"
+	case EventPeriodEnded:
+		return "period ended"
+	case EventComboCue:
+		return "combo cue"
+	case EventPaused:
+		return "paused"
+	case EventResumed:
+		return "resumed"
+	case EventRoundCompleted:
+		return "round completed"
+	case EventWorkoutCompleted:
+		return "workout completed"
+	case EventStopped:
+		return "stopped"
+	case EventBeat:
+		return "beat"
+	case EventMove:
+		return "move"
+	case EventComboDone:
+		return "combo done"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a workout event. Fields that don't apply to the event type are left at their zero value.
+type Event struct {
+	Type        EventType
+	Time        time.Time        // Clock time the event was published
+	TotalRounds int              // Workout started
+	Round       int              // Round the event belongs to (1-indexed)
+	Period      types.PeriodType // Period started, tick and period ended
+	Duration    time.Duration    // Period started
+	Remaining   time.Duration    // Tick
+	Elapsed     time.Duration    // Tick in an open-ended period: time since the period started
+	OpenEnded   bool             // Period started and tick: an auto rest period, lasting until Ready or Duration
+	Combo       models.Combo     // Combo cue, beat, move and combo done
+	Stance      models.Stance    // Combo cue
+	Beat        int              // Beat, move and combo done: beat within the work period, from 1
+	MoveIndex   int              // Beat and move: move of the combo to throw. Combo done: the number of moves
+}
+
+// EventBus fans workout events out to any number of subscribers, each with its own buffer.
+// It is safe for concurrent use.
+type EventBus struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// Subscription receives events from an EventBus until it is unsubscribed
+type Subscription struct {
+	bus     *EventBus
+	ch      chan Event
+	block   bool // Wait for buffer space instead of dropping events
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+}
+
+// NewEventBus creates an event bus with no subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe adds a subscriber that buffers up to bufferSize events. When its buffer is full, new events are
+// dropped for that subscriber (and counted by Dropped) so a slow observer never holds up the workout.
+func (b *EventBus) Subscribe(bufferSize int) *Subscription {
+	return b.subscribe(bufferSize, false)
+}
+
+// SubscribeBlocking adds a subscriber that never misses events: when its buffer is full, publishing waits for it.
+// Use it for observers that must see every event, such as a display, and keep them quick. A blocking subscriber
+// must not subscribe or unsubscribe others while handling an event, since publishing may be waiting on it.
+func (b *EventBus) SubscribeBlocking(bufferSize int) *Subscription {
+	return b.subscribe(bufferSize, true)
+}
+
+func (b *EventBus) subscribe(bufferSize int, block bool) *Subscription {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	sub := &Subscription{
+		bus:   b,
+		ch:    make(chan Event, bufferSize),
+		block: block,
+		done:  make(chan struct{}),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish delivers an event to every subscriber
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs {
+		sub.deliver(event)
+	}
+}
+
+// Subscribers returns the number of active subscribers
+func (b *EventBus) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// deliver sends an event to the subscriber, dropping or waiting if its buffer is full
+func (s *Subscription) deliver(event Event) {
+	if s.block {
+		select {
+		case s.ch <- event:
+		case <-s.done:
+		}
+		return
+	}
+	select {
+	case s.ch <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Events returns the channel events are delivered on. It is closed by Unsubscribe.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped returns how many events were dropped because the subscriber's buffer was full
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe removes the subscriber and closes its events channel. Events already buffered can still be read.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.done) // Release a publisher waiting on a full buffer
+		s.bus.mu.Lock()
+		delete(s.bus.subs, s)
+		s.bus.mu.Unlock()
+		close(s.ch)
+	})
+}
+
+// DispatchToDisplay calls the display handler for each event until the subscription is closed.
+// It lets any number of displays follow one workout; run it on its own goroutine.
+func DispatchToDisplay(sub *Subscription, handler TimerDisplayHandler) {
+	for event := range sub.Events() {
+		switch event.Type {
+		case EventWorkoutStarted:
+			handler.OnWorkoutStart(event.TotalRounds)
+		case EventPeriodStarted:
+			handler.OnPeriodStart(event.Period, event.Round, event.Duration)
+		case EventTick:
+			handler.OnTimerUpdate(event.Remaining, event.Period, event.Round)
+		case EventPeriodEnded:
+			handler.OnPeriodEnd(event.Period, event.Round)
+		case EventWorkoutCompleted:
+			handler.OnWorkoutComplete()
+		}
+	}
+}
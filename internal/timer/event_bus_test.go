@@ -0,0 +1,191 @@
+package timer
+
+import (
+	"heavybagworkout/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventBus_FansOutToEverySubscriber(t *testing.T) {
+	bus := NewEventBus()
+	first := bus.Subscribe(4)
+	second := bus.Subscribe(4)
+
+	bus.Publish(Event{Type: EventWorkoutStarted, TotalRounds: 3})
+	bus.Publish(Event{Type: EventTick, Round: 1, Remaining: time.Second})
+
+	for i, sub := range []*Subscription{first, second} {
+		if got := (<-sub.Events()).Type; got != EventWorkoutStarted {
+			t.Errorf("subscriber %d: expected workout started, got %v", i, got)
+		}
+		if got := (<-sub.Events()).Remaining; got != time.Second {
+			t.Errorf("subscriber %d: expected a 1s tick, got %v", i, got)
+		}
+	}
+}
+
+func TestEventBus_DropsWhenBufferIsFull(t *testing.T) {
+	bus := NewEventBus()
+	slow := bus.Subscribe(2)
+	fast := bus.Subscribe(10)
+
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: EventTick, Remaining: time.Duration(i) * time.Second})
+	}
+
+	if got := slow.Dropped(); got != 3 {
+		t.Errorf("expected 3 dropped events for the slow subscriber, got %d", got)
+	}
+	if got := fast.Dropped(); got != 0 {
+		t.Errorf("expected no dropped events for the fast subscriber, got %d", got)
+	}
+	// The oldest events are kept
+	if got := (<-slow.Events()).Remaining; got != 0 {
+		t.Errorf("expected the first event to be kept, got %v", got)
+	}
+}
+
+func TestEventBus_BlockingSubscriberSeesEveryEvent(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeBlocking(0)
+
+	received := make(chan int)
+	go func() {
+		count := 0
+		for range sub.Events() {
+			count++
+		}
+		received <- count
+	}()
+
+	for i := 0; i < 100; i++ {
+		bus.Publish(Event{Type: EventTick})
+	}
+	sub.Unsubscribe()
+
+	if got := <-received; got != 100 {
+		t.Errorf("expected 100 events, got %d", got)
+	}
+}
+
+func TestEventBus_UnsubscribeReleasesBlockedPublisher(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeBlocking(0)
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(Event{Type: EventTick}) // Nobody is reading
+		close(published)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	sub.Unsubscribe()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("expected Unsubscribe to release the publisher")
+	}
+
+	if bus.Subscribers() != 0 {
+		t.Errorf("expected no subscribers, got %d", bus.Subscribers())
+	}
+	if _, open := <-sub.Events(); open {
+		t.Error("expected the events channel to be closed")
+	}
+	sub.Unsubscribe() // Safe to call twice
+}
+
+func TestWorkoutTimer_PublishesEvents(t *testing.T) {
+	timer, clock, _ := newControlTestTimer(2)
+	timer.SetStance(models.Southpaw)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+	sub := timer.Events().Subscribe(256)
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	if !advanceUntilDone(clock, completed, 40) {
+		t.Fatal("workout did not complete")
+	}
+	sub.Unsubscribe()
+
+	var kinds []string
+	for event := range sub.Events() {
+		if event.Type == EventComboCue && event.Stance != models.Southpaw {
+			t.Errorf("expected combo cue for southpaw, got %v", event.Stance)
+		}
+		if event.Type != EventTick {
+			kinds = append(kinds, event.Type.String())
+		}
+	}
+	round := []string{"combo cue", "period started", "period ended", "period started", "period ended", "round completed"}
+	want := append(append(append([]string{"workout started"}, round...), round...), "workout completed")
+	if strings.Join(kinds, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected events %v, got %v", want, kinds)
+	}
+	if sub.Dropped() != 0 {
+		t.Errorf("expected no dropped events, got %d", sub.Dropped())
+	}
+}
+
+func TestWorkoutTimer_PublishesControlEvents(t *testing.T) {
+	timer, _, display := newControlTestTimer(1)
+	sub := timer.Events().Subscribe(64)
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	display.waitFor(t, "start Work 1 3s")
+	timer.Pause()
+	timer.Pause() // Already paused: no second event
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+	timer.Stop()
+	timer.Stop() // Already stopped: no second event
+	sub.Unsubscribe()
+
+	var controls []string
+	for event := range sub.Events() {
+		switch event.Type {
+		case EventPaused, EventResumed, EventStopped:
+			if event.Round != 1 {
+				t.Errorf("expected %v event for round 1, got round %d", event.Type, event.Round)
+			}
+			controls = append(controls, event.Type.String())
+		}
+	}
+	if want := "paused, resumed, stopped"; strings.Join(controls, ", ") != want {
+		t.Errorf("expected %s, got %v", want, controls)
+	}
+}
+
+func TestDispatchToDisplay_MatchesPrimaryDisplay(t *testing.T) {
+	timer, clock, primary := newControlTestTimer(2)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+
+	secondary := &eventRecorder{}
+	sub := timer.Events().SubscribeBlocking(0)
+	dispatched := make(chan struct{})
+	go func() {
+		DispatchToDisplay(sub, secondary)
+		close(dispatched)
+	}()
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	if !advanceUntilDone(clock, completed, 40) {
+		t.Fatal("workout did not complete")
+	}
+	primary.waitFor(t, "workout complete")
+	sub.Unsubscribe()
+	<-dispatched
+
+	if got, want := strings.Join(secondary.events, ", "), strings.Join(primary.events, ", "); got != want {
+		t.Errorf("expected the secondary display to see %v, got %v", primary.events, secondary.events)
+	}
+}
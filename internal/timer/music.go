@@ -0,0 +1,79 @@
+package timer
+
+import (
+	"fmt"
+	"heavybagworkout/internal/synth"
+	"heavybagworkout/internal/types"
+	"strings"
+)
+
+// MusicRest is what the background music does in rest periods
+type MusicRest int
+
+const (
+	MusicRestPlay   MusicRest = iota // The music plays on
+	MusicRestSoften                  // The music plays at half its volume
+	MusicRestPause                   // The music pauses until the next work period
+)
+
+// restSoftenLevel is the fraction of its volume the music plays at in rest periods with MusicRestSoften
+const restSoftenLevel = 0.5
+
+// ParseMusicRest parses "play" (or ""), "soften" or "pause"
+func ParseMusicRest(s string) (MusicRest, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "play", "":
+		return MusicRestPlay, nil
+	case "soften":
+		return MusicRestSoften, nil
+	case "pause":
+		return MusicRestPause, nil
+	default:
+		return MusicRestPlay, fmt.Errorf("unknown music rest %q: must be play, soften or pause", s)
+	}
+}
+
+// SetMusic plays the cues through a mixer playing background music, which ducks under the callouts and follows
+// the workout's periods: it starts with the workout, does what rest says in rest periods and stops when the
+// workout is complete. volume is the music's volume, from 0 to 1.
+func (a *DefaultAudioCueHandler) SetMusic(mixer *synth.Mixer, volume float64, rest MusicRest) {
+	a.mixer = mixer
+	a.musicVolume = volume
+	a.musicRest = rest
+	if mixer != nil {
+		mixer.PauseMusic() // Until the workout starts
+		a.sink = mixer
+	}
+}
+
+// duck ducks the music under a callout until the returned function is called
+func (a *DefaultAudioCueHandler) duck() (release func()) {
+	if a.mixer == nil || !a.enabled {
+		return func() {}
+	}
+	return a.mixer.Duck()
+}
+
+// followPeriod sets the music for the start of a period
+func (a *DefaultAudioCueHandler) followPeriod(periodType types.PeriodType) {
+	if a.mixer == nil || !a.enabled {
+		return
+	}
+	if periodType == types.PeriodWork || a.musicRest == MusicRestPlay {
+		a.mixer.SetMusicVolume(a.musicVolume)
+		a.mixer.ResumeMusic()
+		return
+	}
+	if a.musicRest == MusicRestSoften {
+		a.mixer.SetMusicVolume(a.musicVolume * restSoftenLevel)
+		return
+	}
+	a.mixer.PauseMusic()
+}
+
+// stopMusic fades the music out at the end of the workout
+func (a *DefaultAudioCueHandler) stopMusic() {
+	if a.mixer != nil {
+		a.mixer.PauseMusic()
+	}
+}
@@ -0,0 +1,98 @@
+package timer
+
+import (
+	"heavybagworkout/internal/synth"
+	"heavybagworkout/internal/types"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// steadyMusic is a music source whose tracks hold one value. asked is closed once the mixer asks for a track.
+type steadyMusic struct {
+	asked chan struct{}
+	once  sync.Once
+}
+
+func newSteadyMusic() *steadyMusic {
+	return &steadyMusic{asked: make(chan struct{})}
+}
+
+func (m *steadyMusic) Next() ([]int16, error) {
+	m.once.Do(func() { close(m.asked) })
+	track := make([]int16, 100000)
+	for i := range track {
+		track[i] = 1000
+	}
+	return track, nil
+}
+
+// waitForTrack mixes until the mixer is playing the music's first track, which loads in the background once the
+// music is playing
+func waitForTrack(mixer *synth.Mixer, music *steadyMusic) {
+	mixer.Mix(1) // Starts loading the track
+	<-music.asked
+	for mixer.Mix(1)[0] == 0 {
+		runtime.Gosched() // The loader hands the track over just after the mixer asks for it
+	}
+}
+
+// musicLevel mixes long enough for the music to settle and returns its level
+func musicLevel(mixer *synth.Mixer) int16 {
+	samples := mixer.Mix(2 * synth.SamplesFor(200*time.Millisecond, 1000))
+	return samples[len(samples)-1]
+}
+
+func TestDefaultAudioCueHandler_MusicFollowsPeriods(t *testing.T) {
+	tests := []struct {
+		rest      string
+		wantRest  int16
+		wantAfter int16 // After the workout is complete
+	}{
+		{"play", 800, 0},
+		{"soften", 400, 0},
+		{"pause", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rest, func(t *testing.T) {
+			rest, err := ParseMusicRest(tt.rest)
+			if err != nil {
+				t.Fatalf("ParseMusicRest failed: %v", err)
+			}
+			mixer := synth.NewMixer(1000)
+			music := newSteadyMusic()
+			mixer.SetMusic(music)
+			handler := NewDefaultAudioCueHandler(true)
+			handler.SetTTSEngine(nil)
+			handler.SetMusic(mixer, 0.8, rest)
+			handler.SetSink(synth.NewBufferSink()) // Keep the bell out of the mix
+
+			if got := mixer.Mix(10)[9]; got != 0 {
+				t.Errorf("expected no music before the workout starts, got %d", got)
+			}
+			handler.PlayWorkoutStart()
+			waitForTrack(mixer, music)
+			if got := musicLevel(mixer); got != 800 {
+				t.Errorf("expected the music at 800 in the work period, got %d", got)
+			}
+			handler.PlayPeriodTransition(types.PeriodRest)
+			if got := musicLevel(mixer); got != tt.wantRest {
+				t.Errorf("expected the music at %d in the rest period, got %d", tt.wantRest, got)
+			}
+			handler.PlayPeriodTransition(types.PeriodWork)
+			if got := musicLevel(mixer); got != 800 {
+				t.Errorf("expected the music back at 800 in the next work period, got %d", got)
+			}
+			handler.PlayWorkoutComplete()
+			if got := mixer.Mix(synth.SamplesFor(time.Second, 1000))[999]; got != tt.wantAfter {
+				t.Errorf("expected the music to stop when the workout is complete, got %d", got)
+			}
+		})
+	}
+
+	if _, err := ParseMusicRest("stop"); err == nil {
+		t.Error("expected an error for an unknown music rest")
+	}
+}
@@ -0,0 +1,139 @@
+package timer
+
+import (
+	"fmt"
+	"heavybagworkout/internal/i18n"
+	"heavybagworkout/internal/synth"
+	"heavybagworkout/internal/types"
+	"io"
+	"time"
+)
+
+// DefaultRenderSampleRate is the sample rate of rendered workouts
+const DefaultRenderSampleRate = synth.DefaultSampleRate
+
+// OfflineRenderer renders a workout's timeline to audio faster than real time, mixing synthesized sounds with
+// pre-rendered speech clips. It plays the timeline as the WorkoutTimer does with an audio queue: callouts in line
+// hold back everything after them, other speech is queued behind any speech still playing and dropped if it can't
+// start before its rest period ends, and pips, clicks and the cue profile's bells and clappers play on time over
+// the top.
+type OfflineRenderer struct {
+	clips      SpeechClipSource
+	sampleRate int
+}
+
+// NewOfflineRenderer creates an offline renderer with the given speech clips at DefaultRenderSampleRate
+func NewOfflineRenderer(clips SpeechClipSource) *OfflineRenderer {
+	return NewOfflineRendererWithSampleRate(clips, DefaultRenderSampleRate)
+}
+
+// NewOfflineRendererWithSampleRate creates an offline renderer with the given speech clips and sample rate
+func NewOfflineRendererWithSampleRate(clips SpeechClipSource, sampleRate int) *OfflineRenderer {
+	return &OfflineRenderer{clips: clips, sampleRate: sampleRate}
+}
+
+// SampleRate returns the sample rate the renderer renders at
+func (r *OfflineRenderer) SampleRate() int {
+	return r.sampleRate
+}
+
+// RenderWAV renders a timeline and writes it as a WAV file
+func (r *OfflineRenderer) RenderWAV(w io.Writer, timeline *Timeline) error {
+	samples, err := r.Render(timeline)
+	if err != nil {
+		return err
+	}
+	return synth.WriteWAV(w, r.sampleRate, samples)
+}
+
+// Render renders a timeline to mono 16-bit PCM
+func (r *OfflineRenderer) Render(timeline *Timeline) ([]int16, error) {
+	mix := &mixer{sampleRate: r.sampleRate}
+	pip, click := synth.Pip().Render(r.sampleRate), synth.Click().Render(r.sampleRate)
+
+	var delay time.Duration      // How late in-line cues have made the workout run
+	var speechFree time.Duration // When the speech playing finishes
+	var restEnd time.Duration    // When the current rest period ends
+	for _, entry := range timeline.Entries {
+		at := entry.Offset + delay
+		switch entry.Kind {
+		case TimelinePeriodStart:
+			if entry.Period == types.PeriodRest {
+				restEnd = at + entry.Duration
+			}
+		case TimelineBeat:
+			mix.add(at, click)
+		case TimelineCue:
+			if entry.Cue == CueBeep {
+				mix.add(at, pip)
+				continue
+			}
+			clip, err := r.cueClip(entry, timeline.locale)
+			if err != nil {
+				return nil, err
+			}
+			if !entry.InLine && !entry.sound().IsVoice() {
+				mix.add(at, clip)
+				continue
+			}
+			start := max(at, speechFree)
+			if !entry.InLine && entry.Period == types.PeriodRest && start >= restEnd {
+				continue // Too late to say before the rest period ends
+			}
+			speechFree = start + mix.add(start, clip)
+			if entry.InLine {
+				delay += speechFree - at
+			}
+		}
+	}
+	mix.extend(timeline.Duration + delay)
+	return synth.ToPCM(mix.track), nil
+}
+
+// cueClip returns the sound of a cue other than a countdown pip, speaking in the timeline's language
+func (r *OfflineRenderer) cueClip(entry TimelineEntry, locale *i18n.Locale) ([]float64, error) {
+	if sound := entry.sound(); !sound.IsVoice() {
+		return renderCueSound(sound, r.sampleRate), nil
+	}
+	text := entry.Text
+	switch entry.Cue {
+	case CueWorkoutStart:
+		return synth.Bell().Render(r.sampleRate), nil
+	case CueWork, CueRest:
+		text = locale.PeriodName(entry.Period)
+	case CueWorkoutComplete:
+		text = locale.WorkoutComplete()
+	}
+	clip, err := r.clips.SpeechClip(text, r.sampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", entry.Cue, err)
+	}
+	return synth.FromPCM(clip), nil
+}
+
+// mixer adds sounds together on a growing track
+type mixer struct {
+	sampleRate int
+	track      []float64
+}
+
+// add mixes a sound in at the given time and returns how long it lasts
+func (m *mixer) add(at time.Duration, sound []float64) time.Duration {
+	start := synth.SamplesFor(at, m.sampleRate)
+	m.extendSamples(start + len(sound))
+	for i, sample := range sound {
+		m.track[start+i] += sample
+	}
+	return time.Duration(len(sound)) * time.Second / time.Duration(m.sampleRate)
+}
+
+// extend makes the track at least the given length
+func (m *mixer) extend(length time.Duration) {
+	m.extendSamples(synth.SamplesFor(length, m.sampleRate))
+}
+
+func (m *mixer) extendSamples(n int) {
+	if n > len(m.track) {
+		m.track = append(m.track, make([]float64, n-len(m.track))...)
+	}
+}
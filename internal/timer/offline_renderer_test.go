@@ -0,0 +1,106 @@
+package timer
+
+import (
+	"bytes"
+	"flag"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "Update golden files")
+
+// sawtoothClips stands in for a text-to-speech engine with a quiet sawtooth a tenth of a second per word,
+// pitched by the length of the text
+type sawtoothClips struct {
+	spoken []string
+}
+
+// SpeechClip records the text and returns its sawtooth
+func (s *sawtoothClips) SpeechClip(text string, sampleRate int) ([]int16, error) {
+	s.spoken = append(s.spoken, text)
+	clip := make([]int16, len(strings.Fields(text))*sampleRate/10)
+	period := 8 + len(text)%8
+	for i := range clip {
+		clip[i] = int16((i%period)*500 - 2000)
+	}
+	return clip, nil
+}
+
+func TestOfflineRenderer_Golden(t *testing.T) {
+	clips := &sawtoothClips{}
+	renderer := NewOfflineRendererWithSampleRate(clips, 4000)
+	timeline := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{
+		Tempo:        2 * time.Second,
+		MoveInterval: 500 * time.Millisecond,
+		AudioPreRoll: 4 * time.Second,
+	})
+
+	var out bytes.Buffer
+	if err := renderer.RenderWAV(&out, timeline); err != nil {
+		t.Fatalf("RenderWAV failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "offline_render.golden.wav")
+	if *updateGolden {
+		if err := os.WriteFile(golden, out.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("rendered workout differs from %s (%d bytes, want %d); run with -update if the change is intended", golden, out.Len(), len(want))
+	}
+
+	// The first round is announced in line, the second during the first rest period
+	wantSpoken := "work|round 1 of 2|jab, then cross|rest|Keep your hands up|round 2 of 2|jab, then cross|work|rest|workout complete"
+	if got := strings.Join(clips.spoken, "|"); got != wantSpoken {
+		t.Errorf("unexpected speech clips:\n got %s\nwant %s", got, wantSpoken)
+	}
+}
+
+func TestOfflineRenderer_InLineCalloutsDelayTheWorkout(t *testing.T) {
+	renderer := NewOfflineRendererWithSampleRate(NewSilentSpeechClips(), 1000)
+	timeline := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{})
+
+	samples, err := renderer.Render(timeline)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	// Both rounds are announced in line: the start bell, then "work", "round N of 2" and "jab, then cross"
+	// (8 words of silence) before each work period
+	delay := synth.Bell().Duration + 2*(8*silentWordDuration)
+	if got, want := time.Duration(len(samples))*time.Millisecond, timeline.Duration+delay; got != want {
+		t.Errorf("expected %v of audio, got %v", want, got)
+	}
+}
+
+func TestOfflineRenderer_CueProfileSounds(t *testing.T) {
+	clips := &sawtoothClips{}
+	renderer := NewOfflineRendererWithSampleRate(clips, 4000)
+	gym := models.GymCueProfile()
+	timeline := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{AudioPreRoll: 4 * time.Second, CueProfile: &gym})
+
+	samples, err := renderer.Render(timeline)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	// The bells ring instead of "work" and "rest" being said
+	wantSpoken := "round 1 of 2|jab, then cross|Keep your hands up|last round|round 2 of 2|jab, then cross|workout complete"
+	if got := strings.Join(clips.spoken, "|"); got != wantSpoken {
+		t.Errorf("unexpected speech clips:\n got %s\nwant %s", got, wantSpoken)
+	}
+	// The round's bell rings in line after the start bell; the three bells ending round 2 ring over the top
+	delay := 2*synth.Bell().Duration + 7*time.Second/10 // Then "round 1 of 2" and "jab, then cross"
+	roundEnd := synth.SamplesFor(11*time.Second+delay, renderer.SampleRate())
+	if samples[roundEnd+renderer.SampleRate()/10] == 0 {
+		t.Error("expected the bells at the end of round 2")
+	}
+}
@@ -1,6 +1,7 @@
 package timer
 
 import (
+	"context"
 	"fmt"
 	"heavybagworkout/internal/models"
 	"heavybagworkout/internal/types"
@@ -57,6 +58,18 @@ func NewRecordingAudioCueHandlerWithWorkout(outputPath string, workout models.Wo
 	return handler, nil
 }
 
+// SetPlayback sets whether the handler plays the cues itself. Without playback it only records the system's audio,
+// e.g. the cues played by the speakers next to it in a CompositeAudioCueHandler.
+func (r *RecordingAudioCueHandler) SetPlayback(enabled bool) {
+	r.baseHandler.enabled = enabled
+}
+
+// StartRecording starts recording the system's audio ahead of the workout, so nothing at its start is missed.
+// Otherwise recording starts with the workout.
+func (r *RecordingAudioCueHandler) StartRecording() error {
+	return r.startSystemAudioRecording()
+}
+
 // findBlackHoleDeviceIndex finds the BlackHole audio device index on macOS
 func findBlackHoleDeviceIndex() (int, error) {
 	// List all available audio devices using ffmpeg
@@ -350,6 +363,16 @@ func (r *RecordingAudioCueHandler) PlayPeriodTransition(periodType types.PeriodT
 	r.baseHandler.PlayPeriodTransition(periodType)
 }
 
+// PlayCueSound plays a cue profile's sound (delegates to base handler)
+func (r *RecordingAudioCueHandler) PlayCueSound(sound models.CueSound) {
+	r.baseHandler.PlayCueSound(sound)
+}
+
+// PlayPeriodSound plays a cue profile's sound for a period transition (delegates to base handler)
+func (r *RecordingAudioCueHandler) PlayPeriodSound(periodType types.PeriodType, sound models.CueSound) {
+	r.baseHandler.PlayPeriodSound(periodType, sound)
+}
+
 // PlayWorkoutStart starts the workout and begins recording
 func (r *RecordingAudioCueHandler) PlayWorkoutStart() {
 	r.workoutStartTime = time.Now()
@@ -362,16 +385,34 @@ func (r *RecordingAudioCueHandler) PlayWorkoutStart() {
 	} else {
 		// Give ffmpeg a small moment to initialize and start capturing
 		// This ensures we don't miss the first audio cues
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.baseHandler.cues.current().Done():
+			return // Stopped while waiting: nothing is played
+		}
 	}
 
 	// Now play the workout start audio cues (which will be captured by the recording)
 	r.baseHandler.PlayWorkoutStart()
 }
 
-// PlayWorkoutComplete stops recording and finalizes the audio file
+// PlayWorkoutComplete stops recording and finalizes the audio file. With playback off, the other outputs may still
+// be playing the cue, so the recording is left for StopRecording.
 func (r *RecordingAudioCueHandler) PlayWorkoutComplete() {
 	r.baseHandler.PlayWorkoutComplete()
+	if r.baseHandler.enabled {
+		r.StopRecording()
+	}
+}
+
+// StopRecording stops recording and finalizes the audio file, unless the recording has already stopped
+func (r *RecordingAudioCueHandler) StopRecording() {
+	r.recordingMu.Lock()
+	recording := r.isRecording
+	r.recordingMu.Unlock()
+	if !recording {
+		return
+	}
 
 	// Stop system audio recording
 	if err := r.stopSystemAudioRecording(); err != nil {
@@ -391,11 +432,21 @@ func (r *RecordingAudioCueHandler) PlayRoundCallout(roundNumber int, totalRounds
 	r.baseHandler.PlayRoundCallout(roundNumber, totalRounds)
 }
 
+// PlayCoachingCue plays a coaching cue (delegates to base handler)
+func (r *RecordingAudioCueHandler) PlayCoachingCue(cue string) {
+	r.baseHandler.PlayCoachingCue(cue)
+}
+
 // Stop cancels all running audio commands
 func (r *RecordingAudioCueHandler) Stop() {
 	r.baseHandler.Stop()
 }
 
+// SetContext ties the cues to ctx (delegates to base handler)
+func (r *RecordingAudioCueHandler) SetContext(ctx context.Context) {
+	r.baseHandler.SetContext(ctx)
+}
+
 // Cleanup stops recording if workout is interrupted
 func (r *RecordingAudioCueHandler) Cleanup() {
 	r.stopSystemAudioRecording()
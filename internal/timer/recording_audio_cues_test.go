@@ -334,6 +334,10 @@ func (t *trackingAudioHandler) PlayRoundCallout(roundNumber int, totalRounds int
 	t.delegate.PlayRoundCallout(roundNumber, totalRounds)
 }
 
+func (t *trackingAudioHandler) PlayCoachingCue(cue string) {
+	t.delegate.PlayCoachingCue(cue)
+}
+
 func (t *trackingAudioHandler) Stop() {
 	t.delegate.Stop()
 }
@@ -452,3 +456,36 @@ func verifyAudioTiming(t *testing.T, audioPath string, workout models.Workout, t
 
 	return nil
 }
+
+// TestRecordingAudioCueHandler_FanOutRecordsLastCue tests that a recording without playback keeps recording past
+// the workout complete cue, which the other outputs may still be playing, until StopRecording
+func TestRecordingAudioCueHandler_FanOutRecordsLastCue(t *testing.T) {
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep not available to stand in for ffmpeg")
+	}
+	outputPath := filepath.Join(t.TempDir(), "workout.mp3")
+	if err := os.WriteFile(outputPath, nil, 0644); err != nil {
+		t.Fatalf("failed to create output file: %v", err)
+	}
+	handler, err := NewRecordingAudioCueHandler(outputPath)
+	if err != nil {
+		t.Fatalf("failed to create recording handler: %v", err)
+	}
+	handler.SetPlayback(false)
+	handler.recordingCmd = exec.Command(sleep, "10")
+	if err := handler.recordingCmd.Start(); err != nil {
+		t.Fatalf("failed to start stand-in recorder: %v", err)
+	}
+	handler.isRecording = true
+
+	handler.PlayWorkoutComplete()
+	if !handler.isRecording {
+		t.Fatal("expected the recording to carry on after the workout complete cue")
+	}
+	handler.StopRecording()
+	if handler.isRecording {
+		t.Error("expected StopRecording to stop the recording")
+	}
+	handler.StopRecording() // Already stopped
+}
@@ -0,0 +1,396 @@
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"heavybagworkout/internal/i18n"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
+	"heavybagworkout/internal/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SamplePackManifestFile is the name of a sample pack's manifest, in the pack's directory
+const SamplePackManifestFile = "manifest.json"
+
+// DefaultSamplePackRounds is how many round numbers GenerateSamplePack records by default
+const DefaultSamplePackRounds = 50
+
+// Clip names in a sample pack manifest's "clips"
+const (
+	SampleWork            = "work"
+	SampleRest            = "rest"
+	SampleCountdown       = "countdown" // Played in place of each countdown pip
+	SampleWorkoutComplete = "workout_complete"
+	SampleRound           = "round" // "round" and "of" join round numbers into "round 3 of 12"
+	SampleOf              = "of"
+)
+
+// sampleClipNames lists the clips every pack must have
+var sampleClipNames = []string{SampleWork, SampleRest, SampleCountdown, SampleWorkoutComplete, SampleRound, SampleOf}
+
+// Default gaps between the clips joined into a callout
+const (
+	DefaultComboGap = 150 * time.Millisecond
+	DefaultWordGap  = 50 * time.Millisecond
+)
+
+// SamplePackManifest maps each callout to a WAV file, relative to the pack's directory
+type SamplePackManifest struct {
+	Name       string                       `json:"name"`
+	Language   string                       `json:"language,omitempty"` // Language the callouts are in, e.g. "es"
+	ComboGapMs int                          `json:"combo_gap_ms"`       // Silence between the moves of a combo callout
+	WordGapMs  int                          `json:"word_gap_ms"`        // Silence between the words of a round callout
+	Clips      map[string]string            `json:"clips"`              // "work", "rest", "countdown", "workout_complete", "round" and "of"
+	Punches    map[string]map[string]string `json:"punches"`            // By stance, then punch: "jab", "cross", "lead_hook", ...
+	Defensive  map[string]string            `json:"defensive"`          // By move: "left_slip", "duck", ...
+	Numbers    map[string]string            `json:"numbers"`            // Round numbers, from "1" up
+}
+
+// SamplePack is a set of pre-recorded callouts. Combo and round callouts are built by joining clips.
+type SamplePack struct {
+	Name     string
+	Language string // Language the callouts are in, "" if the manifest doesn't say
+	comboGap time.Duration
+	wordGap  time.Duration
+	clips    map[string][]int16 // By clip key, at synth.DefaultSampleRate
+	rounds   int                // Highest round number recorded
+}
+
+// LoadSamplePack loads the sample pack in dir, checking that its manifest covers every callout and that every clip
+// is a readable WAV file
+func LoadSamplePack(dir string) (*SamplePack, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SamplePackManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sample pack manifest: %w", err)
+	}
+	var manifest SamplePackManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse sample pack manifest: %w", err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sample pack %s: %w", dir, err)
+	}
+
+	pack := &SamplePack{
+		Name:     manifest.Name,
+		Language: manifest.Language,
+		comboGap: time.Duration(manifest.ComboGapMs) * time.Millisecond,
+		wordGap:  time.Duration(manifest.WordGapMs) * time.Millisecond,
+		clips:    make(map[string][]int16),
+		rounds:   len(manifest.Numbers),
+	}
+	var errs []error
+	for key, file := range manifest.clipFiles() {
+		clip, err := loadSampleClip(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		pack.clips[key] = clip
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid sample pack %s: %w", dir, errors.Join(errs...))
+	}
+	return pack, nil
+}
+
+// Validate checks that the manifest has a clip for every callout, and nothing it doesn't know
+func (m SamplePackManifest) Validate() error {
+	var errs []error
+	if m.ComboGapMs < 0 || m.WordGapMs < 0 {
+		errs = append(errs, errors.New("gaps must not be negative"))
+	}
+	errs = append(errs, checkSampleNames("clips", m.Clips, sampleClipNames)...)
+
+	stances := []string{models.Orthodox.String(), models.Southpaw.String()}
+	var punches []string
+	for _, punch := range models.AllPunches() {
+		punches = append(punches, punchSampleName(punch))
+	}
+	for _, stance := range stances {
+		errs = append(errs, checkSampleNames("punches."+stance, m.Punches[stance], punches)...)
+	}
+	for stance := range m.Punches {
+		if stance != stances[0] && stance != stances[1] {
+			errs = append(errs, fmt.Errorf("punches: unknown stance %q", stance))
+		}
+	}
+
+	var defensive []string
+	for _, move := range models.AllDefensiveMoves() {
+		defensive = append(defensive, defensiveSampleName(move))
+	}
+	errs = append(errs, checkSampleNames("defensive", m.Defensive, defensive)...)
+
+	// Round numbers must run from 1 with no gaps, so the highest round recorded is their count
+	var numbers []string
+	for n := 1; n <= max(len(m.Numbers), 1); n++ {
+		numbers = append(numbers, strconv.Itoa(n))
+	}
+	errs = append(errs, checkSampleNames("numbers", m.Numbers, numbers)...)
+	return errors.Join(errs...)
+}
+
+// checkSampleNames checks that files has a file for each name and nothing else
+func checkSampleNames(section string, files map[string]string, names []string) []error {
+	var errs []error
+	known := make(map[string]bool)
+	for _, name := range names {
+		known[name] = true
+		if files[name] == "" {
+			errs = append(errs, fmt.Errorf("%s: missing %q", section, name))
+		}
+	}
+	for name := range files {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("%s: unknown %q", section, name))
+		}
+	}
+	return errs
+}
+
+// clipFiles returns the file of every clip in the manifest, by clip key
+func (m SamplePackManifest) clipFiles() map[string]string {
+	files := make(map[string]string)
+	for name, file := range m.Clips {
+		files[name] = file
+	}
+	for stance, punches := range m.Punches {
+		for name, file := range punches {
+			files[punchSampleKey(stance, name)] = file
+		}
+	}
+	for name, file := range m.Defensive {
+		files[defensiveSampleKey(name)] = file
+	}
+	for number, file := range m.Numbers {
+		files[numberSampleKey(number)] = file
+	}
+	return files
+}
+
+// loadSampleClip reads a WAV file and resamples it to the pack's sample rate
+func loadSampleClip(filePath string) ([]int16, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	samples, rate, err := synth.ReadWAV(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return synth.Resample(samples, rate, synth.DefaultSampleRate), nil
+}
+
+// SampleRate returns the sample rate of the pack's clips
+func (p *SamplePack) SampleRate() int {
+	return synth.DefaultSampleRate
+}
+
+// MaxRound returns the highest round number the pack can call out
+func (p *SamplePack) MaxRound() int {
+	return p.rounds
+}
+
+// Clip returns one of the named clips, e.g. SampleWork
+func (p *SamplePack) Clip(name string) ([]int16, error) {
+	return p.clip(name)
+}
+
+// ComboClip returns the combo's moves called out one after another, with the pack's combo gap between them
+func (p *SamplePack) ComboClip(combo models.Combo, stance models.Stance) ([]int16, error) {
+	var clips [][]int16
+	for _, move := range combo.Moves {
+		var key string
+		switch {
+		case move.IsPunch() && move.Punch != nil:
+			key = punchSampleKey(stance.String(), punchSampleName(*move.Punch))
+		case move.IsDefensive() && move.Defensive != nil:
+			key = defensiveSampleKey(defensiveSampleName(*move.Defensive))
+		default:
+			continue
+		}
+		clip, err := p.clip(key)
+		if err != nil {
+			return nil, err
+		}
+		clips = append(clips, clip)
+	}
+	return p.join(p.comboGap, clips), nil
+}
+
+// RoundClip returns "round N of M", or an error if the pack doesn't go up to either number
+func (p *SamplePack) RoundClip(roundNumber, totalRounds int) ([]int16, error) {
+	var clips [][]int16
+	for _, key := range []string{SampleRound, numberSampleKey(strconv.Itoa(roundNumber)), SampleOf, numberSampleKey(strconv.Itoa(totalRounds))} {
+		clip, err := p.clip(key)
+		if err != nil {
+			return nil, err
+		}
+		clips = append(clips, clip)
+	}
+	return p.join(p.wordGap, clips), nil
+}
+
+func (p *SamplePack) clip(key string) ([]int16, error) {
+	clip, ok := p.clips[key]
+	if !ok {
+		return nil, fmt.Errorf("sample pack %s has no %s clip", p.Name, key)
+	}
+	return clip, nil
+}
+
+// join joins clips with a gap of silence between each
+func (p *SamplePack) join(gap time.Duration, clips [][]int16) []int16 {
+	silence := make([]int16, synth.SamplesFor(gap, p.SampleRate()))
+	var joined []int16
+	for i, clip := range clips {
+		if i > 0 {
+			joined = append(joined, silence...)
+		}
+		joined = append(joined, clip...)
+	}
+	return joined
+}
+
+// punchSampleName returns a punch's name in a manifest, e.g. "lead_hook"
+func punchSampleName(punch models.Punch) string {
+	return sampleName(punch.String())
+}
+
+// defensiveSampleName returns a defensive move's name in a manifest, e.g. "left_slip"
+func defensiveSampleName(move models.DefensiveMove) string {
+	return sampleName(move.String())
+}
+
+func sampleName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+func punchSampleKey(stance, punch string) string {
+	return "punches." + stance + "." + punch
+}
+
+func defensiveSampleKey(move string) string {
+	return "defensive." + move
+}
+
+func numberSampleKey(number string) string {
+	return "numbers." + number
+}
+
+// GenerateSamplePack writes a sample pack to dir, speaking every callout in the locale's language (English if nil)
+// with a text-to-speech engine and recording round numbers up to rounds. The countdown clip is the synthesized pip.
+func GenerateSamplePack(dir string, engine TTSEngine, rounds int, locale *i18n.Locale) error {
+	if locale == nil {
+		locale = i18n.Default()
+	}
+	round, of, err := roundCalloutWords(locale)
+	if err != nil {
+		return err
+	}
+	texts := map[string]string{
+		SampleWork:            locale.PeriodName(types.PeriodWork),
+		SampleRest:            locale.PeriodName(types.PeriodRest),
+		SampleWorkoutComplete: locale.WorkoutComplete(),
+		SampleRound:           round,
+		SampleOf:              of,
+	}
+	manifest := SamplePackManifest{
+		Name:       filepath.Base(dir),
+		Language:   locale.Language,
+		ComboGapMs: int(DefaultComboGap / time.Millisecond),
+		WordGapMs:  int(DefaultWordGap / time.Millisecond),
+		Clips:      make(map[string]string),
+		Punches:    make(map[string]map[string]string),
+		Defensive:  make(map[string]string),
+		Numbers:    make(map[string]string),
+	}
+	speech := NewTTSSpeechClips(engine) // Caches clips said the same in both stances
+	write := func(file string, clip []int16) error {
+		filePath := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return fmt.Errorf("failed to create sample pack directory: %w", err)
+		}
+		out, err := os.Create(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to create sample: %w", err)
+		}
+		if err := synth.WriteWAV(out, synth.DefaultSampleRate, clip); err != nil {
+			out.Close()
+			return fmt.Errorf("failed to write sample %s: %w", file, err)
+		}
+		return out.Close()
+	}
+	say := func(files map[string]string, name, file, text string) error {
+		clip, err := speech.SpeechClip(text, synth.DefaultSampleRate)
+		if err != nil {
+			return err
+		}
+		files[name] = file
+		return write(file, clip)
+	}
+
+	countdown := synth.ToPCM(synth.Pip().Render(synth.DefaultSampleRate))
+	if err := write(SampleCountdown+".wav", countdown); err != nil {
+		return err
+	}
+	manifest.Clips[SampleCountdown] = SampleCountdown + ".wav"
+	for _, name := range sampleClipNames {
+		if name != SampleCountdown {
+			if err := say(manifest.Clips, name, name+".wav", texts[name]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, stance := range []models.Stance{models.Orthodox, models.Southpaw} {
+		manifest.Punches[stance.String()] = make(map[string]string)
+		for _, punch := range models.AllPunches() {
+			name := punchSampleName(punch)
+			if err := say(manifest.Punches[stance.String()], name, path.Join("punches", stance.String(), name+".wav"), locale.MoveName(models.NewPunchMove(punch), stance)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, move := range models.AllDefensiveMoves() {
+		name := defensiveSampleName(move)
+		if err := say(manifest.Defensive, name, path.Join("defensive", name+".wav"), locale.MoveName(models.NewDefensiveMove(move), models.Orthodox)); err != nil {
+			return err
+		}
+	}
+	for n := 1; n <= rounds; n++ {
+		number := strconv.Itoa(n)
+		if err := say(manifest.Numbers, number, path.Join("numbers", number+".wav"), number); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sample pack manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SamplePackManifestFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write sample pack manifest: %w", err)
+	}
+	return nil
+}
+
+// roundCalloutWords splits the locale's round callout into the words said before the round number and between the
+// two numbers, which a pack joins with its number clips
+func roundCalloutWords(locale *i18n.Locale) (round, of string, err error) {
+	template := locale.Text("callout.round", nil)
+	round, rest, foundRound := strings.Cut(template, "{round}")
+	of, after, foundTotal := strings.Cut(rest, "{total}")
+	if !foundRound || !foundTotal || strings.TrimSpace(after) != "" {
+		return "", "", fmt.Errorf("round callout %q can't be recorded as \"round N of M\"", template)
+	}
+	return strings.TrimSpace(round), strings.TrimSpace(of), nil
+}
@@ -0,0 +1,211 @@
+package timer
+
+import (
+	"bytes"
+	"encoding/json"
+	"heavybagworkout/internal/i18n"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/synth"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testRecordingSamples is the length of every clip in a pack from generateTestSamplePack
+const testRecordingSamples = 100
+
+// generateTestSamplePack generates a pack counting to rounds with an engine that "speaks" every callout by copying
+// the same recording
+func generateTestSamplePack(t *testing.T, rounds int) string {
+	t.Helper()
+	dir := t.TempDir()
+	recording := filepath.Join(dir, "recording.wav")
+	var wav bytes.Buffer
+	if err := synth.WriteWAV(&wav, synth.DefaultSampleRate, make([]int16, testRecordingSamples)); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+	if err := os.WriteFile(recording, wav.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write recording: %v", err)
+	}
+
+	engine, err := NewTTSEngine(TTSCommandEngine, TTSSettings{FileCommand: "cp " + recording + " {output}"})
+	if err != nil {
+		t.Fatalf("NewTTSEngine failed: %v", err)
+	}
+	pack := filepath.Join(dir, "coach")
+	if err := GenerateSamplePack(pack, engine, rounds, nil); err != nil {
+		t.Fatalf("GenerateSamplePack failed: %v", err)
+	}
+	return pack
+}
+
+func TestGenerateSamplePack_LoadsBack(t *testing.T) {
+	pack, err := LoadSamplePack(generateTestSamplePack(t, 3))
+	if err != nil {
+		t.Fatalf("LoadSamplePack failed: %v", err)
+	}
+	if pack.Name != "coach" || pack.MaxRound() != 3 {
+		t.Errorf("expected pack coach counting to 3, got %s counting to %d", pack.Name, pack.MaxRound())
+	}
+
+	countdown, err := pack.Clip(SampleCountdown)
+	if err != nil {
+		t.Fatalf("Clip failed: %v", err)
+	}
+	if want := synth.ToPCM(synth.Pip().Render(synth.DefaultSampleRate)); !reflect.DeepEqual(countdown, want) {
+		t.Error("expected the countdown clip to be the synthesized pip")
+	}
+
+	combo := models.NewCombo([]models.Move{
+		models.NewPunchMove(models.Jab),
+		models.NewDefensiveMove(models.Duck),
+		models.NewPunchMove(models.LeadHook),
+	})
+	for _, stance := range []models.Stance{models.Orthodox, models.Southpaw} {
+		clip, err := pack.ComboClip(combo, stance)
+		if err != nil {
+			t.Fatalf("ComboClip failed for %s: %v", stance, err)
+		}
+		if want := 3*testRecordingSamples + 2*synth.SamplesFor(DefaultComboGap, pack.SampleRate()); len(clip) != want {
+			t.Errorf("expected three moves and two gaps (%d samples) for %s, got %d", want, stance, len(clip))
+		}
+	}
+
+	clip, err := pack.RoundClip(2, 3)
+	if err != nil {
+		t.Fatalf("RoundClip failed: %v", err)
+	}
+	if want := 4*testRecordingSamples + 3*synth.SamplesFor(DefaultWordGap, pack.SampleRate()); len(clip) != want {
+		t.Errorf("expected four words and three gaps (%d samples), got %d", want, len(clip))
+	}
+	if _, err := pack.RoundClip(2, 4); err == nil {
+		t.Error("expected an error for a round past the pack's numbers")
+	}
+}
+
+func TestLoadSamplePack_Validates(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(m *SamplePackManifest, dir string)
+		wantErr string
+	}{
+		{
+			name:    "missing clip",
+			mutate:  func(m *SamplePackManifest, dir string) { delete(m.Clips, SampleRest) },
+			wantErr: `clips: missing "rest"`,
+		},
+		{
+			name:    "missing punch for a stance",
+			mutate:  func(m *SamplePackManifest, dir string) { delete(m.Punches["southpaw"], "rear_uppercut") },
+			wantErr: `punches.southpaw: missing "rear_uppercut"`,
+		},
+		{
+			name:    "unknown stance",
+			mutate:  func(m *SamplePackManifest, dir string) { m.Punches["switch"] = m.Punches["orthodox"] },
+			wantErr: `punches: unknown stance "switch"`,
+		},
+		{
+			name:    "unknown defensive move",
+			mutate:  func(m *SamplePackManifest, dir string) { m.Defensive["parry"] = "defensive/parry.wav" },
+			wantErr: `defensive: unknown "parry"`,
+		},
+		{
+			name:    "gap in round numbers",
+			mutate:  func(m *SamplePackManifest, dir string) { delete(m.Numbers, "2") },
+			wantErr: `numbers: missing "2"`,
+		},
+		{
+			name:    "negative gap",
+			mutate:  func(m *SamplePackManifest, dir string) { m.ComboGapMs = -1 },
+			wantErr: "gaps must not be negative",
+		},
+		{
+			name: "clip isn't a WAV file",
+			mutate: func(m *SamplePackManifest, dir string) {
+				os.WriteFile(filepath.Join(dir, "work.wav"), []byte("not a wav"), 0644)
+			},
+			wantErr: "work: ",
+		},
+		{
+			name: "clip is missing",
+			mutate: func(m *SamplePackManifest, dir string) {
+				os.Remove(filepath.Join(dir, "numbers", "3.wav"))
+			},
+			wantErr: "numbers.3: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := generateTestSamplePack(t, 3)
+			manifestPath := filepath.Join(dir, SamplePackManifestFile)
+			data, err := os.ReadFile(manifestPath)
+			if err != nil {
+				t.Fatalf("failed to read manifest: %v", err)
+			}
+			var manifest SamplePackManifest
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				t.Fatalf("failed to parse manifest: %v", err)
+			}
+			tt.mutate(&manifest, dir)
+			data, _ = json.Marshal(manifest)
+			if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+				t.Fatalf("failed to write manifest: %v", err)
+			}
+
+			_, err = LoadSamplePack(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFileAudioCueHandler_PlaysFromPack(t *testing.T) {
+	pack, err := LoadSamplePack(generateTestSamplePack(t, 3))
+	if err != nil {
+		t.Fatalf("LoadSamplePack failed: %v", err)
+	}
+	sink := synth.NewBufferSink()
+	handler := NewFileAudioCueHandler(true, pack)
+	handler.SetSink(sink)
+	handler.SetTTSEngine(nil)
+
+	combo := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab), models.NewPunchMove(models.Cross)})
+	handler.PlayComboCallout(combo, models.Orthodox)
+	handler.PlayRoundCallout(4, 4) // Past the pack's numbers, so spoken instead (silently, without an engine)
+	handler.PlayBeep()
+
+	comboClip, _ := pack.ComboClip(combo, models.Orthodox)
+	countdown, _ := pack.Clip(SampleCountdown)
+	samples, sampleRate := sink.Samples()
+	if sampleRate != pack.SampleRate() {
+		t.Errorf("expected clips played at %d Hz, got %d", pack.SampleRate(), sampleRate)
+	}
+	if want := append(append([]int16(nil), comboClip...), countdown...); !reflect.DeepEqual(samples, want) {
+		t.Errorf("expected the combo then the countdown (%d samples), got %d samples", len(want), len(samples))
+	}
+}
+
+func TestRoundCalloutWords(t *testing.T) {
+	spanish, err := i18n.Load("es")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	for _, tt := range []struct {
+		locale   *i18n.Locale
+		round    string
+		of       string
+		language string
+	}{
+		{nil, "round", "of", "en"},
+		{spanish, "asalto", "de", "es"},
+	} {
+		round, of, err := roundCalloutWords(tt.locale)
+		if err != nil || round != tt.round || of != tt.of {
+			t.Errorf("%s: expected %q and %q, got %q and %q (%v)", tt.language, tt.round, tt.of, round, of, err)
+		}
+	}
+}
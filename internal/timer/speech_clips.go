@@ -0,0 +1,69 @@
+package timer
+
+import (
+	"fmt"
+	"heavybagworkout/internal/synth"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpeechClipSource provides recordings of spoken text for the offline renderer
+type SpeechClipSource interface {
+	// SpeechClip returns the text spoken as mono 16-bit PCM at the given sample rate
+	SpeechClip(text string, sampleRate int) ([]int16, error)
+}
+
+// TTSSpeechClips renders speech clips with a text-to-speech engine. Clips are cached by text.
+type TTSSpeechClips struct {
+	engine TTSEngine
+	mu     sync.Mutex
+	cache  map[string][]int16
+}
+
+// NewTTSSpeechClips creates a clip source that renders clips with the given engine
+func NewTTSSpeechClips(engine TTSEngine) *TTSSpeechClips {
+	return &TTSSpeechClips{engine: engine, cache: make(map[string][]int16)}
+}
+
+// SpeechClip renders the text with the text-to-speech engine
+func (c *TTSSpeechClips) SpeechClip(text string, sampleRate int) ([]int16, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := fmt.Sprintf("%d:%s", sampleRate, text)
+	if clip, ok := c.cache[key]; ok {
+		return clip, nil
+	}
+
+	samples, rate, err := renderSpeech(c.engine, text, func(cmd *exec.Cmd) error {
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	clip := synth.Resample(samples, rate, sampleRate)
+	c.cache[key] = clip
+	return clip, nil
+}
+
+// silentWordDuration is how long SilentSpeechClips gives each word
+const silentWordDuration = 400 * time.Millisecond
+
+// SilentSpeechClips stands in for speech with silence about as long as saying the text,
+// so a rendered workout keeps its timing on a system without a text-to-speech engine
+type SilentSpeechClips struct{}
+
+// NewSilentSpeechClips creates a clip source of silence
+func NewSilentSpeechClips() *SilentSpeechClips {
+	return &SilentSpeechClips{}
+}
+
+// SpeechClip returns silence for each word of the text
+func (s *SilentSpeechClips) SpeechClip(text string, sampleRate int) ([]int16, error) {
+	words := len(strings.Fields(text))
+	return make([]int16, synth.SamplesFor(time.Duration(words)*silentWordDuration, sampleRate)), nil
+}
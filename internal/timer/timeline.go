@@ -0,0 +1,421 @@
+package timer
+
+import (
+	"encoding/json"
+	"fmt"
+	"heavybagworkout/internal/i18n"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the audio cues in a timeline, which are also the names they are queued under
+const (
+	CueWorkoutStart    = "workout start"
+	CueWork            = "work"
+	CueRest            = "rest"
+	CueRoundCallout    = "round callout"
+	CueComboCallout    = "combo callout"
+	CueCoaching        = "coaching cue"
+	CueBeep            = "beep"
+	CueCountdown       = "countdown" // The rest countdown in a cue profile's sound other than the beep
+	CueWarning         = "warning"
+	CueHalfway         = "halfway"
+	CueLastRound       = "last round"
+	CueWorkoutComplete = "workout complete"
+)
+
+// countdownBeeps is how many seconds before the end of a rest period the countdown beeps start
+const countdownBeeps = 3
+
+// TimelineKind identifies an entry of a timeline
+type TimelineKind int
+
+const (
+	TimelinePeriodStart TimelineKind = iota
+	TimelinePeriodEnd
+	TimelineBeat      // A tempo beat: throw the combo, starting with its first move
+	TimelineMove      // The next move of the combo within a beat
+	TimelineComboDone // Every move of the combo has been thrown
+	TimelineCue       // An audio cue
+)
+
+// String returns the timeline kind name
+func (k TimelineKind) String() string {
+	switch k {
+	case TimelinePeriodStart:
+		return "period start"
+	case TimelinePeriodEnd:
+		return "period end"
+	case TimelineBeat:
+		return "beat"
+	case TimelineMove:
+		return "move"
+	case TimelineComboDone:
+		return "combo done"
+	case TimelineCue:
+		return "cue"
+	default:
+		return "unknown"
+	}
+}
+
+// TimelineEntry is one thing that happens at a set moment of a workout.
+// Fields that don't apply to the entry kind are left at their zero value.
+type TimelineEntry struct {
+	Offset    time.Duration // From the start of the workout
+	Kind      TimelineKind
+	Round     int              // Round the entry belongs to (1-indexed); for callouts, the round called out
+	Period    types.PeriodType // Period the entry happens in
+	Duration  time.Duration    // Period start: how long the period lasts
+	Beat      int              // Beat, move and combo done: beat within the work period, from 1
+	MoveIndex int              // Beat and move: move of the combo to throw. Combo done: the number of moves
+	Cue       string           // Cue: which cue plays, one of the Cue constants
+	Text      string           // Cue: what is said. Beat and move: the move thrown.
+	Sound     string           // Cue: the cue profile's sound, e.g. "bell x3"; empty for speech, or the beep of CueBeep
+	InLine    bool             // Cue: spoken before its period starts, which waits for it to finish
+}
+
+// sound returns the sound a cue entry plays
+func (e TimelineEntry) sound() models.CueSound {
+	if e.Sound != "" {
+		sound, _ := models.ParseCueSound(e.Sound)
+		return sound
+	}
+	if e.Cue == CueBeep {
+		return models.CueSound{Sound: models.SoundBeep, Times: 1}
+	}
+	return models.Voice
+}
+
+// String describes the entry, e.g. "beat 2: jab" or "cue combo callout: jab, then cross"
+func (e TimelineEntry) String() string {
+	switch e.Kind {
+	case TimelinePeriodStart:
+		return fmt.Sprintf("%s %d starts (%v)", periodLabel(e.Period), e.Round, e.Duration)
+	case TimelinePeriodEnd:
+		return fmt.Sprintf("%s %d ends", periodLabel(e.Period), e.Round)
+	case TimelineBeat:
+		return fmt.Sprintf("beat %d: %s", e.Beat, e.Text)
+	case TimelineMove:
+		return fmt.Sprintf("  move: %s", e.Text)
+	case TimelineComboDone:
+		return "  combo done"
+	case TimelineCue:
+		description := "cue " + e.Cue
+		if e.Text != "" {
+			description += ": " + e.Text
+		}
+		if e.Sound != "" {
+			description += " [" + e.Sound + "]"
+		}
+		if e.InLine {
+			description += " (before the period starts)"
+		}
+		return description
+	default:
+		return e.Kind.String()
+	}
+}
+
+// MarshalJSON exports the entry with millisecond offsets and named kinds and periods
+func (e TimelineEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		OffsetMs   int64  `json:"offset_ms"`
+		Kind       string `json:"kind"`
+		Round      int    `json:"round,omitempty"`
+		Period     string `json:"period,omitempty"`
+		DurationMs int64  `json:"duration_ms,omitempty"`
+		Beat       int    `json:"beat,omitempty"`
+		MoveIndex  int    `json:"move_index,omitempty"`
+		Cue        string `json:"cue,omitempty"`
+		Text       string `json:"text,omitempty"`
+		Sound      string `json:"sound,omitempty"`
+		InLine     bool   `json:"in_line,omitempty"`
+	}{
+		OffsetMs:   e.Offset.Milliseconds(),
+		Kind:       e.Kind.String(),
+		Round:      e.Round,
+		Period:     entryPeriodName(e),
+		DurationMs: e.Duration.Milliseconds(),
+		Beat:       e.Beat,
+		MoveIndex:  e.MoveIndex,
+		Cue:        e.Cue,
+		Text:       e.Text,
+		Sound:      e.Sound,
+		InLine:     e.InLine,
+	})
+}
+
+// entryPeriodName returns the name of the period an entry happens in, or "" for the cues around the workout
+func entryPeriodName(e TimelineEntry) string {
+	if e.Cue == CueWorkoutStart || e.Cue == CueWorkoutComplete {
+		return ""
+	}
+	if e.Period == types.PeriodRest {
+		return "rest"
+	}
+	return "work"
+}
+
+// periodLabel returns the display name of a period type
+func periodLabel(period types.PeriodType) string {
+	if period == types.PeriodRest {
+		return "Rest"
+	}
+	return "Work"
+}
+
+// TimelineOptions are the settings a timeline is compiled with
+type TimelineOptions struct {
+	Stance       models.Stance       // Stance for the combo callouts and move names
+	Tempo        time.Duration       // Interval between beats in work periods, 0 for no beats
+	MoveInterval time.Duration       // Time given to each move of a combo, DefaultMoveInterval if 0
+	AudioPreRoll time.Duration       // How long before a work period its callouts start, 0 to announce it in line
+	Locale       *i18n.Locale        // Language of the callouts and move names, nil for English
+	CalloutStyle models.CalloutStyle // How combos are called out and moves named; shortened to fit between beats
+	CueProfile   *models.CueProfile  // Sounds that mark the moments of each round, nil for the spoken profile
+}
+
+// calloutWordDuration is about how long a word of a callout takes to say, for fitting callouts between beats
+const calloutWordDuration = 300 * time.Millisecond
+
+// fitCalloutStyle returns the style to call a combo out in: style, or the next shorter style whose callout can be
+// said between two beats at the tempo, down to numbers. A tempo of 0 has no limit.
+func fitCalloutStyle(locale *i18n.Locale, combo models.Combo, stance models.Stance, style models.CalloutStyle, tempo time.Duration) models.CalloutStyle {
+	for tempo > 0 && style != models.CalloutNumeric {
+		words := len(strings.Fields(locale.StyledComboCallout(combo, stance, style)))
+		if time.Duration(words)*calloutWordDuration <= tempo {
+			break
+		}
+		style = style.Shorter()
+	}
+	return style
+}
+
+// comboStyle returns the style a combo is called out in
+func (o TimelineOptions) comboStyle(combo models.Combo) models.CalloutStyle {
+	return fitCalloutStyle(o.Locale, combo, o.Stance, o.CalloutStyle, o.Tempo)
+}
+
+// comboCallout returns the callout for a combo
+func (o TimelineOptions) comboCallout(combo models.Combo) string {
+	return o.Locale.StyledComboCallout(combo, o.Stance, o.comboStyle(combo))
+}
+
+// timedCue is a cue of a period, timed from the end of the period
+type timedCue struct {
+	entry     TimelineEntry
+	beforeEnd time.Duration
+}
+
+// Timeline is the exact schedule of a workout: the start and end of every period, every beat and every audio cue,
+// at offsets from the start of the workout. Cues spoken in line (the first round's callouts, or every round's
+// without an audio pre-roll) are at the offset of the period they come before, and the workout runs late by the
+// time they take to say. Session controls and the auto and earned rest modes move everything after them.
+type Timeline struct {
+	Entries  []TimelineEntry
+	Duration time.Duration // Length of the workout
+	Rounds   int
+
+	periods   map[periodKey]time.Duration // Length of each period
+	timedCues map[periodKey][]timedCue    // Timed cues of each period, in the order they play
+	locale    *i18n.Locale                // Language of the callouts
+	profile   models.CueProfile           // Sounds that mark the moments of each round
+}
+
+// periodKey identifies a period of a timeline
+type periodKey struct {
+	round  int
+	period types.PeriodType
+}
+
+// CompileTimeline compiles the schedule a WorkoutTimer follows for a workout with the given options
+func CompileTimeline(workout models.Workout, options TimelineOptions) *Timeline {
+	if options.MoveInterval <= 0 {
+		options.MoveInterval = DefaultMoveInterval
+	}
+	profile := models.SpokenCueProfile()
+	if options.CueProfile != nil {
+		profile = *options.CueProfile
+	}
+	total := len(workout.Rounds)
+	tl := &Timeline{
+		Rounds:    total,
+		periods:   make(map[periodKey]time.Duration),
+		timedCues: make(map[periodKey][]timedCue),
+		locale:    options.Locale,
+		profile:   profile,
+	}
+	cue := func(offset time.Duration, name string, round int, period types.PeriodType, text string, inLine bool) TimelineEntry {
+		return TimelineEntry{Offset: offset, Kind: TimelineCue, Round: round, Period: period, Cue: name, Text: text, InLine: inLine}
+	}
+	// profileCue is a cue that plays one of the profile's sounds, saying text if it is spoken
+	profileCue := func(offset time.Duration, name string, round int, period types.PeriodType, sound models.CueSound, text string, inLine bool) TimelineEntry {
+		entry := cue(offset, name, round, period, "", inLine)
+		if sound.IsVoice() {
+			entry.Text = text
+		} else {
+			entry.Sound = sound.String()
+		}
+		return entry
+	}
+	announcesLastRound := total > 1 && !profile.LastRound.IsSilent()
+
+	var at time.Duration
+	announced := 0 // Round whose callouts have been placed
+	tl.add(cue(at, CueWorkoutStart, 0, types.PeriodWork, "", true))
+	for i, round := range workout.Rounds {
+		number := i + 1
+
+		// Work period, after its callouts unless they were placed in the rest before it
+		inLine := announced != number
+		tl.add(profileCue(at, CueWork, number, types.PeriodWork, profile.RoundStart, "", inLine))
+		if inLine {
+			if number == total && announcesLastRound {
+				tl.add(profileCue(at, CueLastRound, number, types.PeriodWork, profile.LastRound, options.Locale.LastRound(), true))
+			}
+			tl.add(cue(at, CueRoundCallout, number, types.PeriodWork, options.Locale.RoundCallout(number, total), true))
+			tl.add(cue(at, CueComboCallout, number, types.PeriodWork, options.comboCallout(round.Combo), true))
+			announced = number
+		}
+		tl.addPeriod(at, number, types.PeriodWork, round.WorkDuration)
+		tl.addBeats(at, number, round, options)
+		work, workEnd := periodKey{number, types.PeriodWork}, at+round.WorkDuration
+		if !profile.Halfway.IsSilent() {
+			beforeEnd := round.WorkDuration / 2
+			tl.addTimedCue(work, beforeEnd, profileCue(workEnd-beforeEnd, CueHalfway, number, types.PeriodWork, profile.Halfway, options.Locale.Halfway(), false))
+		}
+		if beforeEnd := profile.WarningBefore; !profile.Warning.IsSilent() && beforeEnd > 0 && beforeEnd < round.WorkDuration {
+			text := options.Locale.SecondsLeft(int(beforeEnd.Round(time.Second) / time.Second))
+			tl.addTimedCue(work, beforeEnd, profileCue(workEnd-beforeEnd, CueWarning, number, types.PeriodWork, profile.Warning, text, false))
+		}
+		at = workEnd
+		tl.add(TimelineEntry{Offset: at, Kind: TimelinePeriodEnd, Round: number, Period: types.PeriodWork})
+
+		// Rest period
+		rest := round.RestDuration
+		restEnd, restKey := at+rest, periodKey{number, types.PeriodRest}
+		tl.addPeriod(at, number, types.PeriodRest, rest)
+		tl.add(profileCue(at, CueRest, number, types.PeriodRest, profile.RoundEnd, "", false))
+		if round.Cue != "" {
+			tl.add(cue(at, CueCoaching, number, types.PeriodRest, tl.locale.Cue(round.Cue), false))
+		}
+		if next := number + 1; next <= total && options.AudioPreRoll > 0 {
+			// The next round is called out in the last part of the rest, so its work period starts on time
+			beforeEnd := min(options.AudioPreRoll, rest)
+			nextRound := workout.Rounds[next-1]
+			if next == total && announcesLastRound {
+				tl.addTimedCue(restKey, beforeEnd, profileCue(restEnd-beforeEnd, CueLastRound, next, types.PeriodRest, profile.LastRound, options.Locale.LastRound(), false))
+			}
+			tl.addTimedCue(restKey, beforeEnd, cue(restEnd-beforeEnd, CueRoundCallout, next, types.PeriodRest, options.Locale.RoundCallout(next, total), false))
+			tl.addTimedCue(restKey, beforeEnd, cue(restEnd-beforeEnd, CueComboCallout, next, types.PeriodRest, options.comboCallout(nextRound.Combo), false))
+			announced = next
+		}
+		for second := countdownBeeps; second > 0 && !profile.Countdown.IsSilent(); second-- {
+			beforeEnd := time.Duration(second) * time.Second
+			if beforeEnd > rest {
+				continue
+			}
+			if profile.Countdown == (models.CueSound{Sound: models.SoundBeep, Times: 1}) {
+				tl.addTimedCue(restKey, beforeEnd, cue(restEnd-beforeEnd, CueBeep, number, types.PeriodRest, "", false))
+			} else {
+				tl.addTimedCue(restKey, beforeEnd, profileCue(restEnd-beforeEnd, CueCountdown, number, types.PeriodRest, profile.Countdown, strconv.Itoa(second), false))
+			}
+		}
+		at = restEnd
+		tl.add(TimelineEntry{Offset: at, Kind: TimelinePeriodEnd, Round: number, Period: types.PeriodRest})
+	}
+	tl.add(cue(at, CueWorkoutComplete, 0, types.PeriodRest, "", false))
+	tl.Duration = at
+
+	// Beats are placed a work period at a time, so put everything in time order; entries at the same offset keep
+	// the order they happen in
+	sort.SliceStable(tl.Entries, func(i, j int) bool { return tl.Entries[i].Offset < tl.Entries[j].Offset })
+	return tl
+}
+
+// add appends an entry
+func (tl *Timeline) add(entry TimelineEntry) {
+	tl.Entries = append(tl.Entries, entry)
+}
+
+// addPeriod adds the start of a period
+func (tl *Timeline) addPeriod(at time.Duration, round int, period types.PeriodType, duration time.Duration) {
+	tl.periods[periodKey{round, period}] = duration
+	tl.add(TimelineEntry{Offset: at, Kind: TimelinePeriodStart, Round: round, Period: period, Duration: duration})
+}
+
+// addTimedCue adds a cue timed from the end of a period, keeping the period's cues in the order they play
+func (tl *Timeline) addTimedCue(period periodKey, beforeEnd time.Duration, entry TimelineEntry) {
+	cues := append(tl.timedCues[period], timedCue{entry: entry, beforeEnd: beforeEnd})
+	sort.SliceStable(cues, func(i, j int) bool { return cues[i].beforeEnd > cues[j].beforeEnd })
+	tl.timedCues[period] = cues
+	tl.add(entry)
+}
+
+// addBeats adds the beats of a work period, as a BeatScheduler paces them
+func (tl *Timeline) addBeats(start time.Duration, number int, round models.WorkoutRound, options TimelineOptions) {
+	if options.Tempo <= 0 {
+		return
+	}
+	moves := round.Combo.Moves
+	style := options.comboStyle(round.Combo)
+	offsets := beatOffsets(options.Tempo, options.MoveInterval, len(moves))
+	for beat := 0; ; beat++ {
+		for step, offset := range offsets {
+			at := time.Duration(beat)*options.Tempo + offset
+			if at >= round.WorkDuration {
+				return // The period has run out
+			}
+			entry := TimelineEntry{Offset: start + at, Kind: TimelineComboDone, Round: number, Period: types.PeriodWork, Beat: beat + 1, MoveIndex: step}
+			switch {
+			case step == 0:
+				entry.Kind = TimelineBeat
+			case step < len(moves):
+				entry.Kind = TimelineMove
+			}
+			if step < len(moves) {
+				entry.Text = options.Locale.StyledMoveName(moves[step], options.Stance, style)
+			}
+			tl.add(entry)
+		}
+	}
+}
+
+// periodDuration returns how long a period of the timeline lasts
+func (tl *Timeline) periodDuration(round int, period types.PeriodType) time.Duration {
+	return tl.periods[periodKey{round, period}]
+}
+
+// Format writes the timeline as text, one entry per line with its offset
+func (tl *Timeline) Format(w io.Writer) error {
+	for _, entry := range tl.Entries {
+		if _, err := fmt.Fprintf(w, "%s  %s\n", formatOffset(entry.Offset), entry); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%s  end of workout (%d rounds)\n", formatOffset(tl.Duration), tl.Rounds)
+	return err
+}
+
+// WriteJSON writes the timeline as JSON, with offsets and durations in milliseconds
+func (tl *Timeline) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		DurationMs int64           `json:"duration_ms"`
+		Rounds     int             `json:"rounds"`
+		Entries    []TimelineEntry `json:"entries"`
+	}{tl.Duration.Milliseconds(), tl.Rounds, tl.Entries})
+}
+
+// formatOffset formats an offset as M:SS.s
+func formatOffset(offset time.Duration) string {
+	tenths := offset.Milliseconds() / 100
+	return fmt.Sprintf("%d:%02d.%d", tenths/600, tenths/10%60, tenths%10)
+}
@@ -0,0 +1,225 @@
+package timer
+
+import (
+	"bytes"
+	"encoding/json"
+	"heavybagworkout/internal/models"
+	"heavybagworkout/internal/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTimelineTestWorkout returns two rounds of jab, cross: 3s work with 5s then 2s of rest
+func newTimelineTestWorkout() models.Workout {
+	combo := models.NewCombo([]models.Move{models.NewPunchMove(models.Jab), models.NewPunchMove(models.Cross)})
+	rounds := []models.WorkoutRound{
+		models.NewWorkoutRound(1, combo, 3*time.Second, 5*time.Second),
+		models.NewWorkoutRound(2, combo, 3*time.Second, 2*time.Second),
+	}
+	rounds[0].Cue = "Keep your hands up"
+	return models.NewWorkout(models.NewWorkoutConfig(3*time.Second, 5*time.Second, 2), rounds)
+}
+
+func TestCompileTimeline_Format(t *testing.T) {
+	tl := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{
+		Tempo:        2 * time.Second,
+		MoveInterval: 500 * time.Millisecond,
+		AudioPreRoll: 4 * time.Second,
+	})
+
+	var out bytes.Buffer
+	if err := tl.Format(&out); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	want := `0:00.0  cue workout start (before the period starts)
+0:00.0  cue work (before the period starts)
+0:00.0  cue round callout: round 1 of 2 (before the period starts)
+0:00.0  cue combo callout: jab, then cross (before the period starts)
+0:00.0  Work 1 starts (3s)
+0:00.0  beat 1: jab
+0:00.5    move: cross
+0:01.0    combo done
+0:02.0  beat 2: jab
+0:02.5    move: cross
+0:03.0  Work 1 ends
+0:03.0  Rest 1 starts (5s)
+0:03.0  cue rest
+0:03.0  cue coaching cue: Keep your hands up
+0:04.0  cue round callout: round 2 of 2
+0:04.0  cue combo callout: jab, then cross
+0:05.0  cue beep
+0:06.0  cue beep
+0:07.0  cue beep
+0:08.0  Rest 1 ends
+0:08.0  cue work
+0:08.0  Work 2 starts (3s)
+0:08.0  beat 1: jab
+0:08.5    move: cross
+0:09.0    combo done
+0:10.0  beat 2: jab
+0:10.5    move: cross
+0:11.0  Work 2 ends
+0:11.0  Rest 2 starts (2s)
+0:11.0  cue rest
+0:11.0  cue beep
+0:12.0  cue beep
+0:13.0  Rest 2 ends
+0:13.0  cue workout complete
+0:13.0  end of workout (2 rounds)
+`
+	if out.String() != want {
+		t.Errorf("unexpected timeline:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if tl.Duration != 13*time.Second || tl.Rounds != 2 {
+		t.Errorf("expected 2 rounds over 13s, got %d over %v", tl.Rounds, tl.Duration)
+	}
+}
+
+func TestCompileTimeline_CueProfiles(t *testing.T) {
+	gym := models.GymCueProfile()
+	gym.WarningBefore = time.Second // The test workout's work periods are shorter than 10s
+	gym.Halfway = models.Voice
+	tl := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{AudioPreRoll: 4 * time.Second, CueProfile: &gym})
+
+	var out bytes.Buffer
+	for _, entry := range tl.Entries {
+		if entry.Kind == TimelineCue {
+			out.WriteString(formatOffset(entry.Offset) + "  " + entry.String() + "\n")
+		}
+	}
+	want := `0:00.0  cue workout start (before the period starts)
+0:00.0  cue work [bell] (before the period starts)
+0:00.0  cue round callout: round 1 of 2 (before the period starts)
+0:00.0  cue combo callout: jab, then cross (before the period starts)
+0:01.5  cue halfway: halfway
+0:02.0  cue warning [clapper]
+0:03.0  cue rest [bell x3]
+0:03.0  cue coaching cue: Keep your hands up
+0:04.0  cue last round: last round
+0:04.0  cue round callout: round 2 of 2
+0:04.0  cue combo callout: jab, then cross
+0:08.0  cue work [bell]
+0:09.5  cue halfway: halfway
+0:10.0  cue warning [clapper]
+0:11.0  cue rest [bell x3]
+0:13.0  cue workout complete
+`
+	if out.String() != want {
+		t.Errorf("unexpected cues:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	// The warning plays before halfway when it is further from the end
+	gym.WarningBefore = 2 * time.Second
+	tl = CompileTimeline(newTimelineTestWorkout(), TimelineOptions{CueProfile: &gym})
+	var order []string
+	for _, cue := range tl.timedCues[periodKey{1, types.PeriodWork}] {
+		order = append(order, cue.entry.Cue)
+	}
+	if got := strings.Join(order, ", "); got != "warning, halfway" {
+		t.Errorf("expected the timed cues in the order they play, got %s", got)
+	}
+
+	// A spoken countdown says the seconds
+	spoken := models.SpokenCueProfile()
+	spoken.Countdown = models.Voice
+	tl = CompileTimeline(newTimelineTestWorkout(), TimelineOptions{CueProfile: &spoken})
+	var countdown []string
+	for _, entry := range tl.Entries {
+		if entry.Cue == CueCountdown {
+			countdown = append(countdown, entry.Text)
+		}
+	}
+	if got := strings.Join(countdown, " "); got != "3 2 1 2 1" {
+		t.Errorf("expected a spoken countdown, got %q", got)
+	}
+}
+
+func TestCompileTimeline_WithoutPreRoll(t *testing.T) {
+	tl := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{})
+
+	var callouts []string
+	for _, entry := range tl.Entries {
+		if entry.Kind == TimelineBeat {
+			t.Fatalf("expected no beats without a tempo, got %v", entry)
+		}
+		if entry.Cue == CueRoundCallout {
+			if !entry.InLine || entry.Period != types.PeriodWork {
+				t.Errorf("expected round callouts in line before the work period, got %+v", entry)
+			}
+			callouts = append(callouts, formatOffset(entry.Offset)+" "+entry.Text)
+		}
+	}
+	if got := strings.Join(callouts, ", "); got != "0:00.0 round 1 of 2, 0:08.0 round 2 of 2" {
+		t.Errorf("unexpected round callouts: %s", got)
+	}
+}
+
+func TestCompileTimeline_CalloutStyles(t *testing.T) {
+	tests := []struct {
+		name      string
+		style     models.CalloutStyle
+		tempo     time.Duration
+		wantCombo string
+		wantBeat  string
+	}{
+		{"numbers", models.CalloutNumeric, 2 * time.Second, "1 2", "1"},
+		{"short names", models.CalloutShort, 5 * time.Second, "jab cross", "jab"},
+		{"coach with time for it", models.CalloutCoach, 5 * time.Second, "jab to the head, then cross to the head", "jab to the head"},
+		{"coach at superfast tempo", models.CalloutCoach, 2 * time.Second, "jab, then cross", "jab"},
+		{"nothing but numbers fit", models.CalloutFull, 500 * time.Millisecond, "1 2", "1"},
+		{"no tempo to fit", models.CalloutCoach, 0, "jab to the head, then cross to the head", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tl := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{Tempo: tt.tempo, MoveInterval: 200 * time.Millisecond, CalloutStyle: tt.style})
+			var combo, beat string
+			for _, entry := range tl.Entries {
+				if entry.Cue == CueComboCallout && combo == "" {
+					combo = entry.Text
+				}
+				if entry.Kind == TimelineBeat && beat == "" {
+					beat = entry.Text
+				}
+			}
+			if combo != tt.wantCombo || beat != tt.wantBeat {
+				t.Errorf("expected callout %q and beat %q, got %q and %q", tt.wantCombo, tt.wantBeat, combo, beat)
+			}
+		})
+	}
+}
+
+func TestTimeline_WriteJSON(t *testing.T) {
+	tl := CompileTimeline(newTimelineTestWorkout(), TimelineOptions{AudioPreRoll: 4 * time.Second})
+
+	var out bytes.Buffer
+	if err := tl.WriteJSON(&out); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var exported struct {
+		DurationMs int64 `json:"duration_ms"`
+		Entries    []struct {
+			OffsetMs int64  `json:"offset_ms"`
+			Kind     string `json:"kind"`
+			Period   string `json:"period"`
+			Cue      string `json:"cue"`
+			Text     string `json:"text"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &exported); err != nil {
+		t.Fatalf("failed to parse exported timeline: %v", err)
+	}
+	if exported.DurationMs != 13000 || len(exported.Entries) != len(tl.Entries) {
+		t.Fatalf("expected %d entries over 13000ms, got %d over %dms", len(tl.Entries), len(exported.Entries), exported.DurationMs)
+	}
+	for _, entry := range exported.Entries {
+		if entry.Cue == CueComboCallout && entry.Period == "rest" {
+			if entry.OffsetMs != 4000 || entry.Kind != "cue" || entry.Text != "jab, then cross" {
+				t.Errorf("unexpected pre-rolled combo callout: %+v", entry)
+			}
+			return
+		}
+	}
+	t.Error("expected a pre-rolled combo callout in the rest period")
+}
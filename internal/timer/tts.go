@@ -0,0 +1,381 @@
+package timer
+
+import (
+	"errors"
+	"fmt"
+	"heavybagworkout/internal/synth"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// defaultSpeechRate is the speaking rate engines are assumed to default to, in words per minute
+const defaultSpeechRate = 180
+
+// TTSSettings configures a text-to-speech engine. Zero values leave the engine's defaults.
+type TTSSettings struct {
+	Voice        string  // Engine voice name; for piper, the path of a voice model
+	Rate         int     // Words per minute
+	Volume       float64 // From 0 to 1
+	Language     string  // Language code of the callouts, e.g. "es", used to pick a voice if none is set
+	SpeakCommand string  // Command engine: speaks {text} aloud, with {voice}, {rate}, {volume} and {language} filled in
+	FileCommand  string  // Command engine: speaks {text} into the WAV file {output}
+}
+
+// sayVoices are the macOS voices used for each language when no voice is set
+var sayVoices = map[string]string{
+	"en": "Alex", // The clear voice callouts have always used
+	"es": "Monica",
+	"pt": "Luciana",
+}
+
+// TTSEngine is a text-to-speech backend
+type TTSEngine interface {
+	// Name returns the engine name, as used in config
+	Name() string
+	// Available returns nil if the engine can be used on this system, or why it can't
+	Available() error
+	// SpeakCommand returns the command that speaks text aloud, or nil if the engine can only write files
+	SpeakCommand(text string) *exec.Cmd
+	// FileCommand returns the command that speaks text into a WAV file at path
+	FileCommand(text, path string) *exec.Cmd
+	// FileGain returns the gain to apply to the engine's WAV files, for engines that can't set their own volume
+	FileGain() float64
+}
+
+// TTSEngineNames lists the built-in engines, in the order "auto" tries them
+var TTSEngineNames = []string{"say", "espeak-ng", "espeak", "festival", "piper", "sapi"}
+
+// TTSCommandEngine is the name of the engine configured with command templates
+const TTSCommandEngine = "command"
+
+// TTSStatus reports whether an engine is available
+type TTSStatus struct {
+	Name string
+	Err  error // Why the engine can't be used, nil if it can
+}
+
+// NewTTSEngine creates the named engine: one of TTSEngineNames, or TTSCommandEngine
+func NewTTSEngine(name string, settings TTSSettings) (TTSEngine, error) {
+	engine := &commandTTS{name: name, settings: settings, gain: 1}
+	switch name {
+	case "say":
+		engine.binary = "say"
+		engine.speak = engine.sayArgs
+	case "espeak-ng", "espeak":
+		engine.binary = name
+		engine.speak = engine.espeakArgs
+	case "festival":
+		// text2wave writes files with a volume of their own, which are then played like any other sound
+		engine.binary = "text2wave"
+		engine.file = engine.festivalArgs
+	case "piper":
+		engine.binary = "piper"
+		engine.file = engine.piperArgs
+		engine.gain = volumeOrFull(settings.Volume)
+	case "sapi":
+		engine.binary = "powershell"
+		engine.speak = engine.sapiArgs
+	case TTSCommandEngine:
+		if settings.SpeakCommand == "" && settings.FileCommand == "" {
+			return nil, errors.New("the command engine needs a speak or file command")
+		}
+		if settings.FileCommand != "" && !strings.Contains(settings.FileCommand, "{output}") {
+			return nil, errors.New("the command engine's file command must write to {output}")
+		}
+		template := settings.SpeakCommand
+		if template == "" {
+			template = settings.FileCommand
+		}
+		if fields := strings.Fields(template); len(fields) > 0 {
+			engine.binary = fields[0]
+		}
+		engine.templates = true
+	default:
+		return nil, fmt.Errorf("unknown text-to-speech engine %q (valid engines: auto, %s, %s)", name, strings.Join(TTSEngineNames, ", "), TTSCommandEngine)
+	}
+	return engine, nil
+}
+
+// SelectTTSEngine returns the named engine, or the first available built-in engine for "auto" or "",
+// with the status of every engine it considered
+func SelectTTSEngine(name string, settings TTSSettings) (TTSEngine, []TTSStatus, error) {
+	candidates := []string{name}
+	if name == "" || name == "auto" {
+		candidates = TTSEngineNames
+	}
+
+	var statuses []TTSStatus
+	var selected TTSEngine
+	for _, candidate := range candidates {
+		engine, err := NewTTSEngine(candidate, settings)
+		if err == nil {
+			err = engine.Available()
+		}
+		statuses = append(statuses, TTSStatus{Name: candidate, Err: err})
+		if err == nil && selected == nil {
+			selected = engine
+		}
+	}
+	if selected == nil {
+		if len(candidates) == 1 {
+			return nil, statuses, fmt.Errorf("text-to-speech engine %s is not available: %w", name, statuses[0].Err)
+		}
+		return nil, statuses, fmt.Errorf("no text-to-speech engine found (tried %s)", strings.Join(candidates, ", "))
+	}
+	return selected, statuses, nil
+}
+
+// WriteTTSReport writes which engines are available and which one was selected
+func WriteTTSReport(w io.Writer, statuses []TTSStatus, selected TTSEngine) error {
+	if _, err := fmt.Fprintln(w, "Text-to-speech engines:"); err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		state := "available"
+		if status.Err != nil {
+			state = "not available: " + status.Err.Error()
+		}
+		if selected != nil && selected.Name() == status.Name {
+			state += " (selected)"
+		}
+		if _, err := fmt.Fprintf(w, "  %-10s %s\n", status.Name, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commandTTS is an engine run as a command line program
+type commandTTS struct {
+	name      string
+	binary    string // Program that must be installed
+	settings  TTSSettings
+	speak     func(text string) []string       // Arguments that speak aloud, nil if the engine can't
+	file      func(text, path string) []string // Arguments that write a file from the text on standard input, nil to add an output option to speak's
+	templates bool                             // Commands come from the settings' templates
+	gain      float64
+}
+
+func (e *commandTTS) Name() string { return e.name }
+
+func (e *commandTTS) FileGain() float64 { return e.gain }
+
+func (e *commandTTS) Available() error {
+	if e.binary == "" {
+		return errors.New("no command configured")
+	}
+	if _, err := exec.LookPath(e.binary); err != nil {
+		return fmt.Errorf("%s not found", e.binary)
+	}
+	if e.name == "piper" && e.settings.Voice == "" {
+		return errors.New("needs a voice model (set the voice to the model file)")
+	}
+	if e.name == "sapi" && runtime.GOOS != "windows" {
+		return errors.New("only available on Windows")
+	}
+	return nil
+}
+
+func (e *commandTTS) SpeakCommand(text string) *exec.Cmd {
+	if e.templates {
+		if e.settings.SpeakCommand == "" {
+			return nil
+		}
+		return e.fromTemplate(e.settings.SpeakCommand, text, "")
+	}
+	if e.speak == nil {
+		return nil
+	}
+	return exec.Command(e.binary, e.speak(text)...)
+}
+
+func (e *commandTTS) FileCommand(text, path string) *exec.Cmd {
+	if e.templates {
+		if e.settings.FileCommand == "" {
+			return nil
+		}
+		return e.fromTemplate(e.settings.FileCommand, text, path)
+	}
+	if e.file != nil {
+		cmd := exec.Command(e.binary, e.file(text, path)...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd
+	}
+	args := e.speak(text)
+	switch e.name {
+	case "say":
+		args = append([]string{"-o", path, "--data-format=LEI16@22050"}, args...)
+	case "espeak-ng", "espeak":
+		args = append([]string{"-w", path}, args...)
+	case "sapi":
+		args = e.sapiScript(text, path)
+	}
+	return exec.Command(e.binary, args...)
+}
+
+func (e *commandTTS) sayArgs(text string) []string {
+	voice := e.settings.Voice
+	if voice == "" {
+		voice = sayVoices[e.language()]
+	}
+	if voice == "" {
+		voice = sayVoices["en"]
+	}
+	args := []string{"-v", voice}
+	if e.settings.Rate > 0 {
+		args = append(args, "-r", strconv.Itoa(e.settings.Rate))
+	}
+	if e.settings.Volume > 0 {
+		text = fmt.Sprintf("[[volm %.2f]] %s", e.settings.Volume, text)
+	}
+	return append(args, text)
+}
+
+func (e *commandTTS) espeakArgs(text string) []string {
+	var args []string
+	if e.settings.Voice != "" {
+		args = append(args, "-v", e.settings.Voice)
+	} else if e.language() != "en" {
+		args = append(args, "-v", e.language()) // espeak names its default voice for each language by its code
+	}
+	if e.settings.Rate > 0 {
+		args = append(args, "-s", strconv.Itoa(e.settings.Rate))
+	}
+	if e.settings.Volume > 0 {
+		args = append(args, "-a", strconv.Itoa(int(math.Round(e.settings.Volume*100)))) // Amplitude, 100 by default
+	}
+	return append(args, text)
+}
+
+func (e *commandTTS) festivalArgs(text, path string) []string {
+	args := []string{"-o", path}
+	if e.settings.Voice != "" {
+		args = append(args, "-eval", fmt.Sprintf("(voice_%s)", e.settings.Voice))
+	}
+	if e.settings.Rate > 0 {
+		args = append(args, "-eval", fmt.Sprintf("(Parameter.set 'Duration_Stretch %.2f)", e.stretch()))
+	}
+	if e.settings.Volume > 0 {
+		args = append(args, "-scale", fmt.Sprintf("%.2f", e.settings.Volume))
+	}
+	return args
+}
+
+func (e *commandTTS) piperArgs(text, path string) []string {
+	args := []string{"--model", e.settings.Voice, "--output_file", path}
+	if e.settings.Rate > 0 {
+		args = append(args, "--length_scale", fmt.Sprintf("%.2f", e.stretch()))
+	}
+	return args
+}
+
+func (e *commandTTS) sapiArgs(text string) []string {
+	return e.sapiScript(text, "")
+}
+
+// sapiScript returns the PowerShell arguments that speak with System.Speech, into a WAV file if path isn't empty
+func (e *commandTTS) sapiScript(text, path string) []string {
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+	script := "Add-Type -AssemblyName System.Speech; $synth = New-Object System.Speech.Synthesis.SpeechSynthesizer; "
+	if e.settings.Voice != "" {
+		script += fmt.Sprintf("$synth.SelectVoice(%s); ", quote(e.settings.Voice))
+	} else if e.language() != "en" {
+		script += fmt.Sprintf("$synth.SelectVoiceByHints('NotSet', 'NotSet', 0, [Globalization.CultureInfo]%s); ", quote(e.language()))
+	}
+	if e.settings.Rate > 0 {
+		// System.Speech rates run from -10 to 10, about 20 words per minute apart
+		rate := max(-10, min(10, (e.settings.Rate-defaultSpeechRate)/20))
+		script += fmt.Sprintf("$synth.Rate = %d; ", rate)
+	}
+	if e.settings.Volume > 0 {
+		script += fmt.Sprintf("$synth.Volume = %d; ", int(math.Round(e.settings.Volume*100)))
+	}
+	if path != "" {
+		script += fmt.Sprintf("$synth.SetOutputToWaveFile(%s); ", quote(path))
+	}
+	script += fmt.Sprintf("$synth.Speak(%s); $synth.Dispose()", quote(text))
+	return []string{"-c", script}
+}
+
+// stretch returns how much longer than the default to make speech for the configured rate
+func (e *commandTTS) stretch() float64 {
+	return float64(defaultSpeechRate) / float64(e.settings.Rate)
+}
+
+// fromTemplate fills in a command template. Each word of the template is one argument, so the text
+// needs no quoting.
+func (e *commandTTS) fromTemplate(template, text, path string) *exec.Cmd {
+	replacer := strings.NewReplacer(
+		"{text}", text,
+		"{voice}", e.settings.Voice,
+		"{rate}", strconv.Itoa(e.settings.Rate),
+		"{volume}", strconv.FormatFloat(volumeOrFull(e.settings.Volume), 'f', 2, 64),
+		"{language}", e.language(),
+		"{output}", path,
+	)
+	fields := strings.Fields(template)
+	args := make([]string, len(fields))
+	for i, field := range fields {
+		args[i] = replacer.Replace(field)
+	}
+	return exec.Command(args[0], args[1:]...)
+}
+
+// language returns the language code of the callouts, English if it isn't set
+func (e *commandTTS) language() string {
+	if e.settings.Language == "" {
+		return "en"
+	}
+	return e.settings.Language
+}
+
+// volumeOrFull returns the volume, or full volume if it isn't set
+func volumeOrFull(volume float64) float64 {
+	if volume <= 0 {
+		return 1
+	}
+	return volume
+}
+
+// renderSpeech speaks text into a WAV file with the engine and returns its samples and sample rate.
+// run runs the engine's command and returns once it has finished.
+func renderSpeech(engine TTSEngine, text string, run func(cmd *exec.Cmd) error) ([]int16, int, error) {
+	dir, err := os.MkdirTemp("", "heavybag-speech-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create speech clip directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "clip.wav")
+	cmd := engine.FileCommand(text, path)
+	if cmd == nil {
+		return nil, 0, fmt.Errorf("%s can't write speech to a file", engine.Name())
+	}
+	if err := run(cmd); err != nil {
+		return nil, 0, fmt.Errorf("failed to speak %q with %s: %w", text, engine.Name(), err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open speech clip: %w", err)
+	}
+	defer file.Close()
+	samples, rate, err := synth.ReadWAV(file)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read speech clip for %q: %w", text, err)
+	}
+
+	if gain := engine.FileGain(); gain != 1 {
+		scaled := synth.FromPCM(samples)
+		for i := range scaled {
+			scaled[i] *= gain
+		}
+		samples = synth.ToPCM(scaled)
+	}
+	return samples, rate, nil
+}
@@ -0,0 +1,179 @@
+package timer
+
+import (
+	"bytes"
+	"heavybagworkout/internal/synth"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewTTSEngine_Commands(t *testing.T) {
+	settings := TTSSettings{Voice: "v1", Rate: 90, Volume: 0.5}
+	tests := []struct {
+		engine    string
+		wantSpeak string // Empty if the engine can only write files
+		wantFile  string
+		wantStdin bool
+	}{
+		{
+			engine:    "say",
+			wantSpeak: "say -v v1 -r 90 [[volm 0.50]] jab",
+			wantFile:  "say -o out.wav --data-format=LEI16@22050 -v v1 -r 90 [[volm 0.50]] jab",
+		},
+		{
+			engine:    "espeak-ng",
+			wantSpeak: "espeak-ng -v v1 -s 90 -a 50 jab",
+			wantFile:  "espeak-ng -w out.wav -v v1 -s 90 -a 50 jab",
+		},
+		{
+			engine:    "festival",
+			wantFile:  "text2wave -o out.wav -eval (voice_v1) -eval (Parameter.set 'Duration_Stretch 2.00) -scale 0.50",
+			wantStdin: true,
+		},
+		{
+			engine:    "piper",
+			wantFile:  "piper --model v1 --output_file out.wav --length_scale 2.00",
+			wantStdin: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			engine, err := NewTTSEngine(tt.engine, settings)
+			if err != nil {
+				t.Fatalf("NewTTSEngine failed: %v", err)
+			}
+			speak := engine.SpeakCommand("jab")
+			if tt.wantSpeak == "" && speak != nil {
+				t.Errorf("expected no speak command, got %v", speak.Args)
+			} else if tt.wantSpeak != "" && (speak == nil || strings.Join(speak.Args, " ") != tt.wantSpeak) {
+				t.Errorf("expected speak command %q, got %v", tt.wantSpeak, speak)
+			}
+
+			file := engine.FileCommand("jab", "out.wav")
+			if got := strings.Join(file.Args, " "); got != tt.wantFile {
+				t.Errorf("expected file command %q, got %q", tt.wantFile, got)
+			}
+			if tt.wantStdin {
+				stdin, _ := io.ReadAll(file.Stdin)
+				if string(stdin) != "jab" {
+					t.Errorf("expected the text on standard input, got %q", stdin)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTTSEngine_LanguagePicksVoice(t *testing.T) {
+	tests := []struct {
+		engine string
+		want   string
+	}{
+		{"say", "say -v Monica hola"},
+		{"espeak-ng", "espeak-ng -v es hola"},
+	}
+	for _, tt := range tests {
+		engine, err := NewTTSEngine(tt.engine, TTSSettings{Language: "es"})
+		if err != nil {
+			t.Fatalf("NewTTSEngine failed: %v", err)
+		}
+		if got := strings.Join(engine.SpeakCommand("hola").Args, " "); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+
+	// A voice that is set wins over the language
+	engine, _ := NewTTSEngine("say", TTSSettings{Voice: "Jorge", Language: "pt"})
+	if got := strings.Join(engine.SpeakCommand("oi").Args, " "); got != "say -v Jorge oi" {
+		t.Errorf("expected the configured voice, got %q", got)
+	}
+}
+
+func TestNewTTSEngine_CommandTemplates(t *testing.T) {
+	engine, err := NewTTSEngine(TTSCommandEngine, TTSSettings{
+		Voice:        "amy",
+		SpeakCommand: "mytts --voice {voice} --volume {volume} {text}",
+		FileCommand:  "mytts --voice {voice} --out {output} {text}",
+	})
+	if err != nil {
+		t.Fatalf("NewTTSEngine failed: %v", err)
+	}
+
+	// The text is one argument, however many words it has
+	if got, want := engine.SpeakCommand("jab, then cross").Args, []string{"mytts", "--voice", "amy", "--volume", "1.00", "jab, then cross"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected speak command %q, got %q", want, got)
+	}
+	if got, want := engine.FileCommand("rest", "out.wav").Args, []string{"mytts", "--voice", "amy", "--out", "out.wav", "rest"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected file command %q, got %q", want, got)
+	}
+
+	if _, err := NewTTSEngine(TTSCommandEngine, TTSSettings{FileCommand: "mytts {text}"}); err == nil {
+		t.Error("expected an error for a file command without an output")
+	}
+	if _, err := NewTTSEngine("alexa", TTSSettings{}); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
+
+func TestSelectTTSEngine_Report(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // No engines installed
+
+	engine, statuses, err := SelectTTSEngine("auto", TTSSettings{})
+	if err == nil || engine != nil {
+		t.Fatalf("expected no engine to be found, got %v", engine)
+	}
+	if len(statuses) != len(TTSEngineNames) {
+		t.Fatalf("expected a status for each of %v, got %v", TTSEngineNames, statuses)
+	}
+
+	var report bytes.Buffer
+	if err := WriteTTSReport(&report, statuses, nil); err != nil {
+		t.Fatalf("WriteTTSReport failed: %v", err)
+	}
+	if !strings.Contains(report.String(), "  espeak-ng  not available: espeak-ng not found\n") {
+		t.Errorf("expected espeak-ng to be reported missing, got:\n%s", report.String())
+	}
+
+	if _, _, err := SelectTTSEngine("piper", TTSSettings{}); err == nil || !strings.Contains(err.Error(), "piper") {
+		t.Errorf("expected an error naming piper, got %v", err)
+	}
+}
+
+func TestTTSSpeechClips_RendersAndCaches(t *testing.T) {
+	dir := t.TempDir()
+	recording := filepath.Join(dir, "recording.wav")
+	var wav bytes.Buffer
+	if err := synth.WriteWAV(&wav, 4, []int16{0, 100, 200, 300}); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+	if err := os.WriteFile(recording, wav.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write recording: %v", err)
+	}
+
+	// An engine that "speaks" by copying the recording
+	engine, err := NewTTSEngine(TTSCommandEngine, TTSSettings{FileCommand: "cp " + recording + " {output}"})
+	if err != nil {
+		t.Fatalf("NewTTSEngine failed: %v", err)
+	}
+	clips := NewTTSSpeechClips(engine)
+
+	clip, err := clips.SpeechClip("work", 2)
+	if err != nil {
+		t.Fatalf("SpeechClip failed: %v", err)
+	}
+	if want := []int16{0, 200}; !reflect.DeepEqual(clip, want) {
+		t.Errorf("expected the recording resampled to %v, got %v", want, clip)
+	}
+
+	os.Remove(recording)
+	if cached, err := clips.SpeechClip("work", 2); err != nil || !reflect.DeepEqual(cached, clip) {
+		t.Errorf("expected the cached clip, got %v, %v", cached, err)
+	}
+	if _, err := clips.SpeechClip("rest", 2); err == nil {
+		t.Error("expected an error when the engine fails")
+	}
+}
@@ -1,9 +1,13 @@
 package timer
 
 import (
+	"context"
 	"fmt"
+	"heavybagworkout/internal/i18n"
 	"heavybagworkout/internal/models"
 	"heavybagworkout/internal/types"
+	"os"
+	"sync"
 	"time"
 )
 
@@ -16,6 +20,33 @@ type TimerDisplayHandler interface {
 	OnWorkoutComplete()
 }
 
+// FineTickHandler is implemented by display handlers that want sub-second updates (e.g. for a smooth progress bar).
+// It is only called when WorkoutTimer.SetFineTickInterval has set an interval.
+type FineTickHandler interface {
+	OnTimerFineUpdate(remaining time.Duration, periodType types.PeriodType, roundNumber int)
+}
+
+// ElapsedDisplayHandler is implemented by display handlers that show open-ended periods (auto rest) as the time
+// elapsed rather than a countdown. Without it, open-ended periods count down to the maximum rest.
+type ElapsedDisplayHandler interface {
+	OnElapsedUpdate(elapsed time.Duration, periodType types.PeriodType, roundNumber int)
+}
+
+// TempoClickHandler is implemented by audio handlers with a sound of their own for tempo beats.
+// Without it, tempo beats play the beep.
+type TempoClickHandler interface {
+	PlayTempoClick()
+}
+
+// PlayTempoBeat plays an audio handler's tempo click, or its beep if it has none
+func PlayTempoBeat(audio AudioCueHandler) {
+	if clicker, ok := audio.(TempoClickHandler); ok {
+		clicker.PlayTempoClick()
+	} else {
+		audio.PlayBeep()
+	}
+}
+
 // AudioCueHandler handles audio cues for period transitions
 type AudioCueHandler interface {
 	PlayBeep()
@@ -24,269 +55,1278 @@ type AudioCueHandler interface {
 	PlayWorkoutComplete()
 	PlayComboCallout(combo models.Combo, stance models.Stance)
 	PlayRoundCallout(roundNumber int, totalRounds int)
-	Stop() // Stop/cancel all running audio commands
+	PlayCoachingCue(cue string) // Speaks a round's coaching cue during its rest period
+	Stop()                      // Stop/cancel all running audio commands
 }
 
-// WorkoutTimer manages the execution of a workout with work and rest periods
+// workoutEventKind identifies an event handled by the workout timer's event loop
+type workoutEventKind int
+
+const (
+	eventWorkoutStart workoutEventKind = iota
+	eventTick
+	eventFineTick
+	eventBeat
+	eventPeriodComplete
+	eventSkipRest
+	eventJumpToRound
+	eventAddTime
+	eventReady
+	eventDone
+)
+
+// workoutEvent is delivered to the event loop by Start, by the period timers and by the session controls
+type workoutEvent struct {
+	kind      workoutEventKind
+	seq       int // Period the event belongs to, for period timer events
+	period    types.PeriodType
+	round     int
+	remaining time.Duration // Remaining time for ticks, or the time to add for eventAddTime
+	target    int           // Round to jump to for eventJumpToRound
+	beat      Beat          // Beat step for eventBeat
+}
+
+// DefaultAudioPreRoll is how long before a work period its round and combo callouts start, when the frontends
+// enable the audio pre-roll
+const DefaultAudioPreRoll = 5 * time.Second
+
+// DefaultMinRest and DefaultMaxRest bound auto rest periods until SetRestBounds is called. DefaultMaxRest also caps
+// the rest earned by finishing work periods early.
+const (
+	DefaultMinRest = 10 * time.Second
+	DefaultMaxRest = 2 * time.Minute
+)
+
+// lateCueTolerance is how late a cue timed to a moment of the workout (a period transition or countdown beep) may
+// start before it is dropped
+const lateCueTolerance = 500 * time.Millisecond
+
+// eventQueueSize is how many events the period timers can queue while a handler is busy (e.g. speaking)
+const eventQueueSize = 16
+
+// workoutRun is one run of a workout, from Start until it completes or is stopped
+type workoutRun struct {
+	events chan workoutEvent
+	done   chan struct{}   // Closed when the run completes or is stopped
+	ctx    context.Context // The audio handler's cues observe it; cancelled once the run has ended
+	cancel context.CancelFunc
+}
+
+// WorkoutTimer manages the execution of a workout with work and rest periods.
+// All methods are safe for concurrent use. Display and audio handlers, and the completion callbacks,
+// are called on a single event loop goroutine per run and never while the timer's lock is held.
+// Any number of observers can also follow the workout through the event bus returned by Events.
 type WorkoutTimer struct {
+	mu                sync.Mutex
+	bus               *EventBus
 	workout           models.Workout
+	state             TimerState
+	run               *workoutRun
 	currentRound      int
 	currentPeriod     types.PeriodType
+	periodSeq         int  // Identifies the current period; events from earlier period timers carry an older value
+	periodReady       bool // The current period's announcements are done, so its timer runs whenever the workout does
 	workTimer         *WorkPeriodTimer
 	restTimer         *RestPeriodTimer
+	beats             *BeatScheduler // Beat scheduler for the current work period, nil outside work periods
 	displayHandler    TimerDisplayHandler
 	audioHandler      AudioCueHandler
-	stance            models.Stance // Stance for combo callouts
+	audioQueue        *AudioQueue         // Plays the run's cues in the background when the audio pre-roll is enabled
+	audioPreRoll      time.Duration       // How long before a work period its callouts start, 0 to announce it in line
+	stance            models.Stance       // Stance for combo callouts
+	locale            *i18n.Locale        // Language of the timeline's callouts, nil for English
+	calloutStyle      models.CalloutStyle // How the timeline's combos are called out
+	cueProfile        *models.CueProfile  // Sounds that mark the moments of each round, nil for the spoken profile
+	clock             Clock               // Drives the period timers
+	fineTickInterval  time.Duration       // Interval for FineTickHandler updates, 0 if disabled
+	tempo             time.Duration       // Interval between beats in work periods, 0 if disabled
+	restMode          models.RestMode
+	minRest           time.Duration // Shortest auto rest period
+	maxRest           time.Duration // Longest auto rest period, and the most a rest period can be with earned rest
+	openRest          bool          // The current rest period lasts until Ready (auto rest)
+	earnedRest        time.Duration // Rest banked by finishing the current round's work period early
 	onRoundComplete   func(roundNumber int)
 	onWorkoutComplete func()
+	checkpoints       *CheckpointStore // Where the running workout is checkpointed, nil if disabled
+	checkpointMu      sync.Mutex       // Serializes checkpoint saves and clears; taken before mu
+	checkpointWarned  bool             // A failed checkpoint has been reported this run
+	startedAt         time.Time        // When the workout was first started, kept across a resume
+	resumeRemaining   time.Duration    // Length of the first period when starting from a checkpoint, 0 otherwise
+	lastCheckpoint    time.Time        // When the workout was last checkpointed
+	timeline          *Timeline        // Schedule of the current run
+	timedCuesFired    []bool           // Which of the current period's timed cues have played, only touched by the event loop
+	announcedRound    int              // Round whose callouts have been played or queued, only touched by the event loop
 }
 
 // NewWorkoutTimer creates a new workout timer
 func NewWorkoutTimer(workout models.Workout) *WorkoutTimer {
 	return &WorkoutTimer{
+		bus:           NewEventBus(),
 		workout:       workout,
+		state:         StateIdle,
 		currentRound:  0,
 		currentPeriod: types.PeriodWork,
 		stance:        models.Orthodox, // Default stance
+		clock:         RealClock(),
+		restMode:      models.RestFixed,
+		minRest:       DefaultMinRest,
+		maxRest:       DefaultMaxRest,
 	}
 }
 
 // SetStance sets the boxer's stance for combo callouts
 func (wt *WorkoutTimer) SetStance(stance models.Stance) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	wt.stance = stance
 }
 
+// SetLocale sets the language of the callouts compiled into the workout's timeline
+func (wt *WorkoutTimer) SetLocale(locale *i18n.Locale) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.locale = locale
+}
+
+// SetCalloutStyle sets how the combos compiled into the workout's timeline are called out
+func (wt *WorkoutTimer) SetCalloutStyle(style models.CalloutStyle) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.calloutStyle = style
+}
+
+// SetCueProfile sets the sounds that mark the moments of each round, nil for the spoken profile
+func (wt *WorkoutTimer) SetCueProfile(profile *models.CueProfile) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.cueProfile = profile
+}
+
+// SetClock sets the clock that drives the period timers (e.g. a FakeClock in tests)
+func (wt *WorkoutTimer) SetClock(clock Clock) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.clock = clock
+}
+
+// SetFineTickInterval enables sub-second updates for display handlers that implement FineTickHandler
+func (wt *WorkoutTimer) SetFineTickInterval(interval time.Duration) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.fineTickInterval = interval
+}
+
+// SetTempo enables beat events: each work period publishes an EventBeat every tempo interval, starting as the
+// period starts, with the combo's moves paced DefaultMoveInterval apart. A tempo of 0 disables beats.
+func (wt *WorkoutTimer) SetTempo(tempo time.Duration) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.tempo = tempo
+}
+
+// SetAudioPreRoll makes the audio non-blocking: cues are played in the background by an AudioQueue, and each
+// round's callouts are spoken during the last preRoll of the rest period before it, so its work period starts
+// exactly on time. Cues that can't start in time are dropped. A pre-roll of 0 plays every cue in line, with the
+// work period starting once its announcements finish.
+func (wt *WorkoutTimer) SetAudioPreRoll(preRoll time.Duration) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.audioPreRoll = preRoll
+}
+
+// SetRestMode sets how long rest periods last. With RestAuto each rest period lasts until Ready is called, within
+// the rest bounds, instead of the round's rest duration. With RestEarned, Done ends a work period early and adds
+// the time left in it to the round's rest.
+func (wt *WorkoutTimer) SetRestMode(mode models.RestMode) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.restMode = mode
+}
+
+// SetRestBounds sets the shortest and longest auto rest period. The longest also caps a rest period with earned rest.
+func (wt *WorkoutTimer) SetRestBounds(min, max time.Duration) error {
+	if min < 0 || max <= 0 || min > max {
+		return fmt.Errorf("invalid rest bounds %v-%v: need 0 <= minimum <= maximum and a positive maximum", min, max)
+	}
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.minRest = min
+	wt.maxRest = max
+	return nil
+}
+
+// SetCheckpointStore makes the timer checkpoint the running workout: as each period starts, every
+// DefaultCheckpointInterval, and when it is paused or stopped. The checkpoint is cleared when the workout completes.
+func (wt *WorkoutTimer) SetCheckpointStore(store *CheckpointStore) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	wt.checkpoints = store
+}
+
+// Events returns the bus the timer publishes its events on
+func (wt *WorkoutTimer) Events() *EventBus {
+	return wt.bus
+}
+
+// publish stamps an event with the clock time and publishes it. It must not be called while holding wt.mu.
+func (wt *WorkoutTimer) publish(event Event) {
+	wt.mu.Lock()
+	event.Time = wt.clock.Now()
+	wt.mu.Unlock()
+	wt.bus.Publish(event)
+}
+
 // SetDisplayHandler sets the display handler for timer updates
 func (wt *WorkoutTimer) SetDisplayHandler(handler TimerDisplayHandler) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	wt.displayHandler = handler
 }
 
 // SetAudioHandler sets the audio handler for period transitions
 func (wt *WorkoutTimer) SetAudioHandler(handler AudioCueHandler) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	wt.audioHandler = handler
 }
 
 // OnRoundComplete sets a callback for when a round completes
 func (wt *WorkoutTimer) OnRoundComplete(callback func(roundNumber int)) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	wt.onRoundComplete = callback
 }
 
 // OnWorkoutComplete sets a callback for when the workout completes
 func (wt *WorkoutTimer) OnWorkoutComplete(callback func()) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	wt.onWorkoutComplete = callback
 }
 
-// Start begins the workout timer
+// Start begins the workout timer. The announcements and the first work period start on the event loop,
+// so Start returns straight away.
 func (wt *WorkoutTimer) Start() error {
+	return wt.start(nil)
+}
+
+// StartFrom begins the workout at the point a checkpoint of it was taken: the checkpoint's period of its round,
+// with the time that was left. The checkpoint must be of this timer's workout.
+func (wt *WorkoutTimer) StartFrom(checkpoint Checkpoint) error {
+	if err := checkpoint.Validate(); err != nil {
+		return err
+	}
+	if len(checkpoint.Workout.Rounds) != len(wt.workout.Rounds) {
+		return fmt.Errorf("checkpoint is of a %d round workout, not %d rounds", len(checkpoint.Workout.Rounds), len(wt.workout.Rounds))
+	}
+	return wt.start(&checkpoint)
+}
+
+// start starts a run from the first round, or from a checkpoint
+func (wt *WorkoutTimer) start(from *Checkpoint) error {
+	wt.mu.Lock()
 	if len(wt.workout.Rounds) == 0 {
+		wt.mu.Unlock()
 		return fmt.Errorf("workout has no rounds")
 	}
+	if !CanTransition(wt.state, StateRunning) || wt.state == StatePaused {
+		state := wt.state
+		wt.mu.Unlock()
+		return fmt.Errorf("workout timer is already %s", state)
+	}
 
 	wt.currentRound = 1
 	wt.currentPeriod = types.PeriodWork
-
-	if wt.displayHandler != nil {
-		wt.displayHandler.OnWorkoutStart(len(wt.workout.Rounds))
+	wt.startedAt = wt.clock.Now()
+	wt.resumeRemaining = 0
+	if from != nil {
+		wt.currentRound = from.Round
+		wt.currentPeriod = from.Period
+		wt.resumeRemaining = from.Remaining
+		if !from.StartedAt.IsZero() {
+			wt.startedAt = from.StartedAt
+		}
 	}
-
-	if wt.audioHandler != nil {
-		wt.audioHandler.PlayWorkoutStart()
+	wt.checkpointWarned = false
+	wt.earnedRest = 0
+	wt.timeline = wt.compileTimelineLocked()
+	wt.workTimer = nil
+	wt.restTimer = nil
+	wt.beats = nil
+	if wt.audioPreRoll > 0 && wt.audioHandler != nil {
+		wt.audioQueue = NewAudioQueueWithClock(wt.audioHandler, wt.clock)
 	}
+	wt.state = StateRunning
+	run := &workoutRun{
+		events: make(chan workoutEvent, eventQueueSize),
+		done:   make(chan struct{}),
+	}
+	run.ctx, run.cancel = context.WithCancel(context.Background())
+	// Queue the start before any control can be posted
+	run.events <- workoutEvent{kind: eventWorkoutStart}
+	wt.run = run
+	audio, queue := wt.audioHandler, wt.audioQueue
+	wt.mu.Unlock()
 
-	return wt.startWorkPeriod()
+	// Nothing the handler plays outlives the run
+	if queue != nil {
+		queue.SetContext(run.ctx)
+	} else if handler, ok := audio.(ContextAudioCueHandler); ok {
+		handler.SetContext(run.ctx)
+	}
+	go wt.loop(run)
+	return nil
 }
 
 // Pause pauses the current timer
 func (wt *WorkoutTimer) Pause() {
-	if wt.workTimer != nil && wt.workTimer.State() == StateRunning {
-		wt.workTimer.Pause()
+	wt.mu.Lock()
+	if wt.state != StateRunning {
+		wt.mu.Unlock()
+		return
+	}
+	wt.state = StatePaused
+	if timer := wt.periodTimerLocked(); timer != nil {
+		timer.Pause()
 	}
-	if wt.restTimer != nil && wt.restTimer.State() == StateRunning {
-		wt.restTimer.Pause()
+	if wt.beats != nil {
+		wt.beats.Pause()
 	}
+	event := Event{Type: EventPaused, Round: wt.currentRound, Period: wt.currentPeriod}
+	run, audio, queue := wt.run, wt.audioHandler, wt.audioQueue
+	wt.mu.Unlock()
+
+	// Mute the cue playing; the queued cues wait for Resume
+	if queue != nil {
+		queue.Pause()
+	} else if audio != nil {
+		audio.Stop()
+	}
+	wt.publish(event)
+	wt.saveCheckpoint(run)
 }
 
 // Resume resumes the paused timer
 func (wt *WorkoutTimer) Resume() error {
-	if wt.workTimer != nil && wt.workTimer.State() == StatePaused {
-		return wt.workTimer.Start()
+	wt.mu.Lock()
+	if wt.state != StatePaused {
+		wt.mu.Unlock()
+		return nil
 	}
-	if wt.restTimer != nil && wt.restTimer.State() == StatePaused {
-		return wt.restTimer.Start()
+	wt.state = StateRunning
+	var err error
+	// If the workout was paused during the period's announcements, the period timer and beats start once the
+	// remaining announcements have played
+	if timer := wt.periodTimerLocked(); timer != nil && (timer.State() == StatePaused || timer.State() == StateIdle && wt.periodReady) {
+		err = timer.Start()
+		if wt.beats != nil && err == nil {
+			err = wt.beats.Start()
+		}
 	}
-	return nil
+	event := Event{Type: EventResumed, Round: wt.currentRound, Period: wt.currentPeriod}
+	queue := wt.audioQueue
+	wt.mu.Unlock()
+
+	if queue != nil {
+		queue.Resume()
+	}
+	wt.publish(event)
+	return err
 }
 
-// Stop stops the workout timer
+// Stop stops the workout timer, checkpointing the point it was stopped at
 func (wt *WorkoutTimer) Stop() {
+	wt.checkpointMu.Lock()
+	defer wt.checkpointMu.Unlock()
+	wt.mu.Lock()
+	store := wt.checkpoints
+	checkpoint, checkpointed := Checkpoint{}, false
+	if wt.run != nil && store != nil {
+		checkpoint, checkpointed = wt.checkpointLocked()
+	}
 	if wt.workTimer != nil {
 		wt.workTimer.Stop()
 	}
 	if wt.restTimer != nil {
 		wt.restTimer.Stop()
 	}
-	// Stop all running audio commands to prevent announcements from continuing
-	if wt.audioHandler != nil {
-		wt.audioHandler.Stop()
+	wt.stopBeatsLocked()
+	stopped := wt.run != nil
+	if wt.run != nil {
+		close(wt.run.done)
+		wt.run.cancel() // Cuts off the cues in flight
+		wt.run = nil
 	}
+	if wt.state != StateCompleted {
+		wt.state = StateStopped
+	}
+	event := Event{Type: EventStopped, Round: wt.currentRound, Period: wt.currentPeriod}
 	wt.currentRound = 0
+	audioHandler, queue := wt.audioHandler, wt.audioQueue
+	wt.audioQueue = nil
+	wt.mu.Unlock()
+
+	if stopped {
+		wt.publish(event)
+	}
+	if checkpointed {
+		wt.writeCheckpoint(store, checkpoint)
+	}
+
+	// Stop all running audio commands to prevent announcements from continuing
+	cutAudio(audioHandler, queue)
+	if queue != nil {
+		queue.Close()
+	}
+}
+
+// SkipRest ends the current rest period early and moves on to the next round, as if the rest had run out
+func (wt *WorkoutTimer) SkipRest() error {
+	wt.mu.Lock()
+	run, err := wt.controllableRunLocked()
+	if err == nil && wt.currentPeriod != types.PeriodRest {
+		err = fmt.Errorf("not in a rest period")
+	}
+	round, audio, queue := wt.currentRound, wt.audioHandler, wt.audioQueue
+	wt.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	cutAudio(audio, queue) // Cut the coaching cue short
+	wt.post(run, workoutEvent{kind: eventSkipRest, period: types.PeriodRest, round: round})
+	return nil
+}
+
+// RepeatRound restarts the current round from the beginning of its work period
+func (wt *WorkoutTimer) RepeatRound() error {
+	return wt.JumpToRound(wt.CurrentRound())
+}
+
+// JumpToRound ends the current period and starts the work period of the given round (1-indexed)
+func (wt *WorkoutTimer) JumpToRound(roundNumber int) error {
+	wt.mu.Lock()
+	run, err := wt.controllableRunLocked()
+	if err == nil && (roundNumber < 1 || roundNumber > len(wt.workout.Rounds)) {
+		err = fmt.Errorf("round %d is out of range (1-%d)", roundNumber, len(wt.workout.Rounds))
+	}
+	audio, queue := wt.audioHandler, wt.audioQueue
+	wt.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	cutAudio(audio, queue) // Cut any announcement short
+	wt.post(run, workoutEvent{kind: eventJumpToRound, target: roundNumber})
+	return nil
+}
+
+// AddTime extends the current period by d
+func (wt *WorkoutTimer) AddTime(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("time to add must be positive, got %v", d)
+	}
+	wt.mu.Lock()
+	run, err := wt.controllableRunLocked()
+	period, round := wt.currentPeriod, wt.currentRound
+	wt.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	wt.post(run, workoutEvent{kind: eventAddTime, period: period, round: round, remaining: d})
+	return nil
+}
+
+// Ready ends an auto rest period: straight away once the minimum rest has passed, otherwise when it passes
+func (wt *WorkoutTimer) Ready() error {
+	wt.mu.Lock()
+	run, err := wt.controllableRunLocked()
+	if err == nil && wt.restMode != models.RestAuto {
+		err = fmt.Errorf("ready is only used with auto rest")
+	} else if err == nil && wt.currentPeriod != types.PeriodRest {
+		err = fmt.Errorf("not in a rest period")
+	}
+	round := wt.currentRound
+	wt.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	wt.post(run, workoutEvent{kind: eventReady, period: types.PeriodRest, round: round})
+	return nil
+}
+
+// Done ends the current work period early, adding the time left in it to the round's rest (earned rest)
+func (wt *WorkoutTimer) Done() error {
+	wt.mu.Lock()
+	run, err := wt.controllableRunLocked()
+	if err == nil && wt.restMode != models.RestEarned {
+		err = fmt.Errorf("done is only used with earned rest")
+	} else if err == nil && wt.currentPeriod != types.PeriodWork {
+		err = fmt.Errorf("not in a work period")
+	}
+	round := wt.currentRound
+	wt.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	wt.post(run, workoutEvent{kind: eventDone, period: types.PeriodWork, round: round})
+	return nil
+}
+
+// cutAudio stops the cue playing and drops any waiting in the queue
+func cutAudio(audio AudioCueHandler, queue *AudioQueue) {
+	if queue != nil {
+		queue.Clear()
+	} else if audio != nil {
+		audio.Stop()
+	}
+}
+
+// controllableRunLocked returns the active run if the workout is running or paused. The caller must hold wt.mu.
+func (wt *WorkoutTimer) controllableRunLocked() (*workoutRun, error) {
+	if wt.run == nil || (wt.state != StateRunning && wt.state != StatePaused) {
+		return nil, fmt.Errorf("workout timer is %s", wt.state)
+	}
+	return wt.run, nil
+}
+
+// State returns the state of the workout as a whole
+func (wt *WorkoutTimer) State() TimerState {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	return wt.state
 }
 
 // CurrentRound returns the current round number (1-indexed, 0 if not started)
 func (wt *WorkoutTimer) CurrentRound() int {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	return wt.currentRound
 }
 
 // CurrentPeriod returns the current period type
 func (wt *WorkoutTimer) CurrentPeriod() types.PeriodType {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
 	return wt.currentPeriod
 }
 
 // RemainingTime returns the remaining time for the current period
 func (wt *WorkoutTimer) RemainingTime() time.Duration {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	if timer := wt.periodTimerLocked(); timer != nil {
+		return timer.Remaining()
+	}
+	return 0
+}
+
+// periodTimerLocked returns the timer for the current period, or nil. The caller must hold wt.mu.
+func (wt *WorkoutTimer) periodTimerLocked() *CountdownTimer {
 	if wt.currentPeriod == types.PeriodWork && wt.workTimer != nil {
-		return wt.workTimer.Remaining()
+		return wt.workTimer.CountdownTimer
 	}
 	if wt.currentPeriod == types.PeriodRest && wt.restTimer != nil {
-		return wt.restTimer.Remaining()
+		return wt.restTimer.CountdownTimer
 	}
-	return 0
+	return nil
 }
 
-// startWorkPeriod starts a work period for the current round
-func (wt *WorkoutTimer) startWorkPeriod() error {
-	// Check if workout is complete (all rounds done)
-	if wt.currentRound > len(wt.workout.Rounds) {
-		wt.completeWorkout()
-		return nil
+// post queues an event for the run's event loop, dropping it if the run has ended
+func (wt *WorkoutTimer) post(run *workoutRun, event workoutEvent) {
+	select {
+	case run.events <- event:
+	case <-run.done:
+	}
+}
+
+// loop is the event loop for one run: every handler call happens here
+func (wt *WorkoutTimer) loop(run *workoutRun) {
+	for {
+		select {
+		case <-run.done:
+			return
+		case event := <-run.events:
+			wt.handleEvent(run, event)
+		}
+	}
+}
+
+// handleEvent handles one event on the event loop
+func (wt *WorkoutTimer) handleEvent(run *workoutRun, event workoutEvent) {
+	switch event.kind {
+	case eventWorkoutStart:
+		if !wt.isCurrentRun(run) {
+			return
+		}
+		display, _ := wt.handlers()
+		wt.announcedRound = 0
+		wt.publish(Event{Type: EventWorkoutStarted, TotalRounds: len(wt.workout.Rounds)})
+		if display != nil {
+			display.OnWorkoutStart(len(wt.workout.Rounds))
+		}
+		wt.playCue(CueWorkoutStart, PriorityHigh, time.Time{}, func(audio AudioCueHandler) {
+			audio.PlayWorkoutStart()
+		})
+		if wt.CurrentPeriod() == types.PeriodRest {
+			wt.startRestPeriod(run) // Starting from a checkpoint taken in a rest period
+		} else {
+			wt.startWorkPeriod(run)
+		}
+	case eventTick:
+		if wt.isCurrentPeriod(run, event.seq) {
+			wt.onPeriodTick(event.period, event.round, event.remaining)
+		}
+	case eventFineTick:
+		if !wt.isCurrentPeriod(run, event.seq) {
+			return
+		}
+		if display, _ := wt.handlers(); display != nil {
+			if fine, ok := display.(FineTickHandler); ok {
+				fine.OnTimerFineUpdate(event.remaining, event.period, event.round)
+			}
+		}
+	case eventBeat:
+		if wt.isCurrentPeriod(run, event.seq) {
+			wt.onBeat(event.round, event.beat)
+		}
+	case eventPeriodComplete:
+		if !wt.isCurrentPeriod(run, event.seq) {
+			return
+		}
+		if event.period == types.PeriodWork {
+			wt.onWorkPeriodComplete(run, event.round)
+		} else {
+			wt.onRestPeriodComplete(run, event.round)
+		}
+	case eventSkipRest:
+		wt.skipRest(run, event.round)
+	case eventJumpToRound:
+		wt.jumpToRound(run, event.target)
+	case eventAddTime:
+		wt.addTime(run, event.period, event.round, event.remaining)
+	case eventReady:
+		wt.readyRest(run, event.round)
+	case eventDone:
+		wt.doneWork(run, event.round)
+	}
+}
+
+// skipRest ends the given round's rest period if it is still the current period
+func (wt *WorkoutTimer) skipRest(run *workoutRun, roundNumber int) {
+	wt.mu.Lock()
+	if wt.run != run || wt.currentPeriod != types.PeriodRest || wt.currentRound != roundNumber {
+		wt.mu.Unlock()
+		return
+	}
+	if wt.restTimer != nil {
+		wt.restTimer.Stop()
+	}
+	wt.periodSeq++ // Drop events from the stopped rest timer
+	wt.mu.Unlock()
+
+	wt.announcedRound = 0 // SkipRest cut the callouts short, if they had started
+	wt.onRestPeriodComplete(run, roundNumber)
+}
+
+// jumpToRound ends the current period and starts the work period of the target round
+func (wt *WorkoutTimer) jumpToRound(run *workoutRun, target int) {
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
+		return
+	}
+	period, round := wt.currentPeriod, wt.currentRound
+	if timer := wt.periodTimerLocked(); timer != nil {
+		timer.Stop()
+	}
+	wt.periodSeq++ // Drop events from the stopped period timer
+	display := wt.displayHandler
+	wt.mu.Unlock()
+
+	wt.publish(Event{Type: EventPeriodEnded, Round: round, Period: period})
+	if display != nil {
+		display.OnPeriodEnd(period, round)
+	}
+
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
+		return
 	}
-	// Add bounds check before array access (prevents panic when currentRound is 0 or invalid)
-	// This can happen if Stop() is called during PlayWorkoutStart() beeps
-	if wt.currentRound <= 0 || wt.currentRound > len(wt.workout.Rounds) {
-		return fmt.Errorf("invalid currentRound: %d (expected 1-%d)", wt.currentRound, len(wt.workout.Rounds))
+	wt.currentRound = target
+	wt.currentPeriod = types.PeriodWork
+	wt.mu.Unlock()
+	wt.announcedRound = 0 // Announce the target round in full, even when repeating it
+	wt.startWorkPeriod(run)
+}
+
+// readyRest ends the given round's auto rest period, or shortens it to end once the minimum rest has passed
+func (wt *WorkoutTimer) readyRest(run *workoutRun, roundNumber int) {
+	wt.mu.Lock()
+	if wt.run != run || wt.currentPeriod != types.PeriodRest || wt.currentRound != roundNumber || wt.restTimer == nil || !wt.openRest {
+		wt.mu.Unlock()
+		return
+	}
+	timer := wt.restTimer.CountdownTimer
+	left := wt.minRest - (timer.Duration() - timer.Remaining())
+	if left > 0 {
+		// Count down the rest of the minimum
+		wt.openRest = false
+		timer.TrimTo(left)
+		remaining := timer.Remaining()
+		wt.mu.Unlock()
+		wt.onPeriodTick(types.PeriodRest, roundNumber, remaining)
+		return
 	}
-	round := wt.workout.Rounds[wt.currentRound-1]
-	wt.workTimer = NewWorkPeriodTimer(round.WorkDuration)
+	audio, queue := wt.audioHandler, wt.audioQueue
+	wt.mu.Unlock()
+
+	cutAudio(audio, queue) // Cut the coaching cue short
+	wt.skipRest(run, roundNumber)
+}
 
-	// Set up callbacks
-	wt.workTimer.OnTick(func(remaining time.Duration) {
-		if wt.displayHandler != nil {
-			wt.displayHandler.OnTimerUpdate(remaining, types.PeriodWork, wt.currentRound)
+// doneWork ends the given round's work period early and banks the time left in it as earned rest
+func (wt *WorkoutTimer) doneWork(run *workoutRun, roundNumber int) {
+	wt.mu.Lock()
+	if wt.run != run || wt.currentPeriod != types.PeriodWork || wt.currentRound != roundNumber || wt.workTimer == nil {
+		wt.mu.Unlock()
+		return
+	}
+	wt.earnedRest = wt.workTimer.Remaining()
+	wt.workTimer.Stop()
+	wt.stopBeatsLocked()
+	wt.periodSeq++ // Drop events from the stopped work timer
+	wt.mu.Unlock()
+
+	wt.onWorkPeriodComplete(run, roundNumber)
+}
+
+// restDurationLocked returns how long the given round's rest period lasts in the current rest mode, and whether it
+// is open-ended (auto rest). The caller must hold wt.mu.
+func (wt *WorkoutTimer) restDurationLocked(roundNumber int) (time.Duration, bool) {
+	rest := wt.timeline.periodDuration(roundNumber, types.PeriodRest)
+	switch wt.restMode {
+	case models.RestAuto:
+		return wt.maxRest, true
+	case models.RestEarned:
+		duration := rest + wt.earnedRest
+		wt.earnedRest = 0
+		if duration > wt.maxRest {
+			duration = max(rest, wt.maxRest)
 		}
+		return duration, false
+	default:
+		return rest, false
+	}
+}
+
+// compileTimelineLocked compiles the workout's timeline. The caller must hold wt.mu.
+func (wt *WorkoutTimer) compileTimelineLocked() *Timeline {
+	options := TimelineOptions{Stance: wt.stance, Tempo: wt.tempo, Locale: wt.locale, CalloutStyle: wt.calloutStyle, CueProfile: wt.cueProfile}
+	if wt.audioHandler != nil {
+		// Callouts are only pre-rolled when there is an audio queue to play them in the background
+		options.AudioPreRoll = wt.audioPreRoll
+	}
+	return CompileTimeline(wt.workout, options)
+}
+
+// addTime extends the given period if it is still the current period and reports the new remaining time
+func (wt *WorkoutTimer) addTime(run *workoutRun, period types.PeriodType, roundNumber int, d time.Duration) {
+	wt.mu.Lock()
+	timer := wt.periodTimerLocked()
+	if wt.run != run || wt.currentPeriod != period || wt.currentRound != roundNumber || timer == nil {
+		wt.mu.Unlock()
+		return
+	}
+	timer.AddTime(d)
+	remaining := timer.Remaining()
+	wt.mu.Unlock()
+
+	wt.onPeriodTick(period, roundNumber, remaining)
+}
+
+// handlers returns the display and audio handlers
+func (wt *WorkoutTimer) handlers() (TimerDisplayHandler, AudioCueHandler) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	return wt.displayHandler, wt.audioHandler
+}
+
+// firstPeriodDurationLocked returns the duration for a period, or the time left in it for the first period
+// started from a checkpoint. The caller must hold wt.mu.
+func (wt *WorkoutTimer) firstPeriodDurationLocked(duration time.Duration) time.Duration {
+	if wt.resumeRemaining > 0 {
+		duration, wt.resumeRemaining = wt.resumeRemaining, 0
+	}
+	return duration
+}
+
+// checkpointLocked returns a checkpoint of the current point of the workout, or false if it is between periods.
+// The caller must hold wt.mu.
+func (wt *WorkoutTimer) checkpointLocked() (Checkpoint, bool) {
+	timer := wt.periodTimerLocked()
+	if wt.currentRound < 1 || timer == nil || timer.Remaining() <= 0 {
+		return Checkpoint{}, false
+	}
+	return Checkpoint{
+		Workout:   wt.workout,
+		Stance:    wt.stance,
+		Tempo:     wt.tempo,
+		Round:     wt.currentRound,
+		Period:    wt.currentPeriod,
+		Remaining: timer.Remaining(),
+		StartedAt: wt.startedAt,
+		SavedAt:   wt.clock.Now(),
+	}, true
+}
+
+// saveCheckpoint checkpoints the workout if run is still the active run and checkpoints are enabled
+func (wt *WorkoutTimer) saveCheckpoint(run *workoutRun) {
+	wt.checkpointMu.Lock()
+	defer wt.checkpointMu.Unlock()
+	wt.mu.Lock()
+	store := wt.checkpoints
+	if store == nil || run == nil || wt.run != run {
+		wt.mu.Unlock()
+		return
+	}
+	checkpoint, ok := wt.checkpointLocked()
+	if ok {
+		wt.lastCheckpoint = checkpoint.SavedAt
+	}
+	wt.mu.Unlock()
+
+	if ok {
+		wt.writeCheckpoint(store, checkpoint)
+	}
+}
+
+// writeCheckpoint saves a checkpoint. A failure is reported once per run and doesn't stop the workout.
+func (wt *WorkoutTimer) writeCheckpoint(store *CheckpointStore, checkpoint Checkpoint) {
+	if err := store.Save(checkpoint); err != nil {
+		wt.warnCheckpoint(err)
+	}
+}
+
+// clearCheckpoint removes the checkpoint of a completed workout
+func (wt *WorkoutTimer) clearCheckpoint() {
+	wt.checkpointMu.Lock()
+	defer wt.checkpointMu.Unlock()
+	wt.mu.Lock()
+	store := wt.checkpoints
+	wt.mu.Unlock()
+	if store == nil {
+		return
+	}
+	if err := store.Clear(); err != nil {
+		wt.warnCheckpoint(err)
+	}
+}
+
+// warnCheckpoint prints a checkpoint failure, once per run
+func (wt *WorkoutTimer) warnCheckpoint(err error) {
+	wt.mu.Lock()
+	warned := wt.checkpointWarned
+	wt.checkpointWarned = true
+	wt.mu.Unlock()
+	if !warned {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// playCue plays a cue on the run's audio queue, or straight away on the audio handler if the audio pre-roll is
+// disabled. The returned cue is done once the cue has been played or dropped.
+func (wt *WorkoutTimer) playCue(name string, priority AudioPriority, deadline time.Time, play func(AudioCueHandler)) *QueuedCue {
+	wt.mu.Lock()
+	audio, queue := wt.audioHandler, wt.audioQueue
+	wt.mu.Unlock()
+
+	if queue != nil {
+		return queue.Enqueue(name, priority, deadline, play)
+	}
+	cue := &QueuedCue{name: name, priority: priority, deadline: deadline, play: play, done: make(chan struct{})}
+	if audio != nil {
+		play(audio)
+	}
+	cue.finish(audio != nil)
+	return cue
+}
+
+// timedCueDeadline returns the deadline for a cue timed to the current moment of the workout
+func (wt *WorkoutTimer) timedCueDeadline() time.Time {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	return wt.clock.Now().Add(lateCueTolerance)
+}
+
+// waitCue waits until the cue has been played or dropped, or the run has ended
+func (wt *WorkoutTimer) waitCue(run *workoutRun, cue *QueuedCue) {
+	select {
+	case <-cue.Done():
+	case <-run.done:
+	}
+}
+
+// isCurrentRun reports whether run is still the active run (not stopped, completed or restarted)
+func (wt *WorkoutTimer) isCurrentRun(run *workoutRun) bool {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	return wt.run == run
+}
+
+// isCurrentPeriod reports whether an event for the given period belongs to the active run's current period
+func (wt *WorkoutTimer) isCurrentPeriod(run *workoutRun, seq int) bool {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	return wt.run == run && wt.periodSeq == seq
+}
+
+// newPeriodTimer starts a new period and creates its countdown, forwarding the countdown's callbacks to the
+// event loop. It returns the timer and the new period's sequence number. The caller must hold wt.mu.
+func (wt *WorkoutTimer) newPeriodTimer(run *workoutRun, period types.PeriodType, round int, duration time.Duration) (*CountdownTimer, int) {
+	wt.stopBeatsLocked()
+	wt.openRest = false
+	wt.periodReady = false
+	wt.periodSeq++
+	seq := wt.periodSeq
+	timer := NewCountdownTimerWithClock(duration, wt.clock)
+	timer.OnTick(func(remaining time.Duration) {
+		wt.post(run, workoutEvent{kind: eventTick, seq: seq, period: period, round: round, remaining: remaining})
 	}).OnComplete(func() {
-		wt.onWorkPeriodComplete()
+		wt.post(run, workoutEvent{kind: eventPeriodComplete, seq: seq, period: period, round: round})
 	})
+	if _, ok := wt.displayHandler.(FineTickHandler); ok && wt.fineTickInterval > 0 {
+		timer.OnFineTick(wt.fineTickInterval, func(remaining time.Duration) {
+			wt.post(run, workoutEvent{kind: eventFineTick, seq: seq, period: period, round: round, remaining: remaining})
+		})
+	}
+	return timer, seq
+}
 
-	// Play audio announcements FIRST and wait for them to complete
-	// This ensures the timer and beeps only start after announcements finish
-	if wt.audioHandler != nil {
-		wt.audioHandler.PlayPeriodTransition(types.PeriodWork)
-		// Call out the round number (blocking - waits for completion)
-		totalRounds := len(wt.workout.Rounds)
-		wt.audioHandler.PlayRoundCallout(wt.currentRound, totalRounds)
-		// Call out the combo for this round (blocking - waits for completion)
-		wt.audioHandler.PlayComboCallout(round.Combo, wt.stance)
+// newBeatSchedulerLocked creates the beat scheduler for a work period, forwarding its beats to the event loop,
+// or returns nil if beats are disabled. The caller must hold wt.mu.
+func (wt *WorkoutTimer) newBeatSchedulerLocked(run *workoutRun, seq int, timer *CountdownTimer, round int, combo models.Combo) *BeatScheduler {
+	if wt.tempo <= 0 {
+		return nil
+	}
+	beats := NewBeatSchedulerWithClock(wt.tempo, DefaultMoveInterval, len(combo.Moves), wt.clock)
+	return beats.OnBeat(func(beat Beat) {
+		if timer.Remaining() <= 0 {
+			beats.Stop() // The period has run out
+			return
+		}
+		wt.post(run, workoutEvent{kind: eventBeat, seq: seq, period: types.PeriodWork, round: round, beat: beat})
+	})
+}
+
+// stopBeatsLocked stops the current work period's beats. The caller must hold wt.mu.
+func (wt *WorkoutTimer) stopBeatsLocked() {
+	if wt.beats != nil {
+		wt.beats.Stop()
+		wt.beats = nil
+	}
+}
+
+// startPeriodTimer starts a period's countdown, and a work period's beats, unless the period has ended or the
+// workout is paused (Resume starts them then)
+func (wt *WorkoutTimer) startPeriodTimer(run *workoutRun, seq int, timer *CountdownTimer) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	if wt.run != run || wt.periodSeq != seq {
+		return
+	}
+	wt.periodReady = true
+	if wt.state == StateRunning {
+		timer.Start()
+		if wt.beats != nil {
+			wt.beats.Start()
+		}
+	}
+}
+
+// onPeriodTick updates the display and plays the period's timed cues
+func (wt *WorkoutTimer) onPeriodTick(period types.PeriodType, round int, remaining time.Duration) {
+	display, audio := wt.handlers()
+	event := Event{Type: EventTick, Round: round, Period: period, Remaining: remaining}
+	wt.mu.Lock()
+	if period == types.PeriodRest && wt.openRest && wt.restTimer != nil {
+		event.OpenEnded = true
+		event.Elapsed = wt.restTimer.Duration() - remaining
+	}
+	wt.mu.Unlock()
+	wt.publish(event)
+	if elapsed, ok := display.(ElapsedDisplayHandler); ok && event.OpenEnded {
+		elapsed.OnElapsedUpdate(event.Elapsed, period, round)
+	} else if display != nil {
+		display.OnTimerUpdate(remaining, period, round)
 	}
 
-	// Now that audio announcements are complete, notify display handler
-	// This will start the tempo ticker, which will play the first beep when timer starts
-	if wt.displayHandler != nil {
-		wt.displayHandler.OnPeriodStart(types.PeriodWork, wt.currentRound, round.WorkDuration)
+	wt.mu.Lock()
+	run, due := wt.run, wt.clock.Since(wt.lastCheckpoint) >= DefaultCheckpointInterval
+	wt.mu.Unlock()
+	if due {
+		wt.saveCheckpoint(run)
+	}
+
+	if audio != nil {
+		wt.playTimedCues(period, round, remaining)
+	}
+}
+
+// playTimedCues plays the timeline's cues for a period that are due with the given time remaining: a work period's
+// warning and halfway cues, and a rest period's pre-rolled callouts for the next round and its countdown. They are
+// timed from the end of the period, so they follow it when it is lengthened or cut short. A cue that has been
+// passed without playing, because the period was cut short, is dropped when a later cue of the same kind is due,
+// so the countdown doesn't beep twice in one tick. Cues that are due only when the period has ended are dropped.
+func (wt *WorkoutTimer) playTimedCues(period types.PeriodType, round int, remaining time.Duration) {
+	wt.mu.Lock()
+	cues := wt.timeline.timedCues[periodKey{round, period}]
+	periodEnd := wt.clock.Now().Add(remaining)
+	wt.mu.Unlock()
+	if len(wt.timedCuesFired) != len(cues) {
+		wt.timedCuesFired = make([]bool, len(cues))
+	}
+
+	var due []TimelineEntry
+	for i, cue := range cues {
+		if remaining > cue.beforeEnd {
+			wt.timedCuesFired[i] = false // Time was added, so the cue is ahead again
+			continue
+		}
+		if wt.timedCuesFired[i] {
+			continue
+		}
+		wt.timedCuesFired[i] = true
+		if remaining <= 0 {
+			continue
+		}
+		if last := len(due) - 1; last >= 0 && due[last].Cue == cue.entry.Cue {
+			due[last] = cue.entry
+		} else {
+			due = append(due, cue.entry)
+		}
+	}
+
+	for _, entry := range due {
+		switch entry.Cue {
+		case CueRoundCallout:
+			// Queue the next round's callouts so they are spoken before its work period starts instead of
+			// delaying it, unless a session control has already announced it
+			if wt.announcedRound != entry.Round {
+				wt.announcedRound = entry.Round
+				wt.announceRound(entry.Round, periodEnd)
+			}
+		case CueLastRound, CueComboCallout:
+			// Queued with the round callout
+		case CueBeep:
+			wt.playCue(CueBeep, PriorityHigh, wt.timedCueDeadline(), func(audio AudioCueHandler) {
+				audio.PlayBeep()
+			})
+		case CueCountdown, CueWarning, CueHalfway:
+			sound, text := entry.sound(), entry.Text
+			wt.playCue(entry.Cue, PriorityHigh, wt.timedCueDeadline(), func(audio AudioCueHandler) {
+				PlayCueSound(audio, sound, text)
+			})
+		}
+	}
+}
+
+// announceRound publishes a round's combo cue and calls out the round and its combo, after the cue profile's last
+// round cue if it is the last. It returns the last callout.
+func (wt *WorkoutTimer) announceRound(roundNumber int, deadline time.Time) *QueuedCue {
+	wt.mu.Lock()
+	stance, profile, locale := wt.stance, wt.timeline.profile, wt.timeline.locale
+	wt.mu.Unlock()
+	round, totalRounds := wt.workout.Rounds[roundNumber-1], len(wt.workout.Rounds)
+
+	wt.publish(Event{Type: EventComboCue, Round: roundNumber, Combo: round.Combo, Stance: stance})
+	if roundNumber == totalRounds && totalRounds > 1 && !profile.LastRound.IsSilent() {
+		wt.playCue(CueLastRound, PriorityNormal, deadline, func(audio AudioCueHandler) {
+			PlayCueSound(audio, profile.LastRound, locale.LastRound())
+		})
+	}
+	wt.playCue(CueRoundCallout, PriorityNormal, deadline, func(audio AudioCueHandler) {
+		audio.PlayRoundCallout(roundNumber, totalRounds)
+	})
+	return wt.playCue(CueComboCallout, PriorityNormal, deadline, func(audio AudioCueHandler) {
+		audio.PlayComboCallout(round.Combo, stance)
+	})
+}
+
+// startWorkPeriod starts a work period for the current round
+func (wt *WorkoutTimer) startWorkPeriod(run *workoutRun) {
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
+		return
+	}
+	roundNumber := wt.currentRound
+	// Check if workout is complete (all rounds done)
+	if roundNumber < 1 || roundNumber > len(wt.workout.Rounds) {
+		wt.mu.Unlock()
+		wt.completeWorkout(run)
+		return
+	}
+	round := wt.workout.Rounds[roundNumber-1]
+	duration := wt.firstPeriodDurationLocked(wt.timeline.periodDuration(roundNumber, types.PeriodWork))
+	timer, seq := wt.newPeriodTimer(run, types.PeriodWork, roundNumber, duration)
+	wt.workTimer = &WorkPeriodTimer{CountdownTimer: timer}
+	wt.beats = wt.newBeatSchedulerLocked(run, seq, timer, roundNumber, round.Combo)
+	display, roundStart := wt.displayHandler, wt.timeline.profile.RoundStart
+	wt.mu.Unlock()
+	wt.saveCheckpoint(run)
+
+	wt.timedCuesFired = nil
+	playWork := func(audio AudioCueHandler) { PlayPeriodCue(audio, types.PeriodWork, roundStart) }
+	if wt.announcedRound == roundNumber {
+		// The round and combo were called out during the rest period's pre-roll, so the period starts on time
+		wt.playCue(CueWork, PriorityHigh, wt.timedCueDeadline(), playWork)
+	} else {
+		// Nothing has been called out yet (the first round, or after a session control or without a pre-roll):
+		// play the announcements FIRST and wait for them to complete, so the timer and beeps only start after
+		// they finish
+		wt.playCue(CueWork, PriorityHigh, time.Time{}, playWork)
+		wt.announcedRound = roundNumber
+		wt.waitCue(run, wt.announceRound(roundNumber, time.Time{}))
+	}
+
+	// Stop() or a session control may have ended the period during the announcements
+	if !wt.isCurrentPeriod(run, seq) {
+		return
+	}
+
+	// Now that the announcements are complete, notify display handler
+	wt.publish(Event{Type: EventPeriodStarted, Round: roundNumber, Period: types.PeriodWork, Duration: duration})
+	if display != nil {
+		display.OnPeriodStart(types.PeriodWork, roundNumber, duration)
 	}
 
 	// Start the timer AFTER announcements complete
-	// The first beep will play immediately when the timer starts (via tempo ticker)
-	return wt.workTimer.Start()
+	// The first beat is published as soon as the timer starts
+	wt.startPeriodTimer(run, seq, timer)
+}
+
+// onBeat publishes a beat step of the given round's work period
+func (wt *WorkoutTimer) onBeat(round int, beat Beat) {
+	combo := wt.workout.Rounds[round-1].Combo
+	event := Event{Type: EventComboDone, Round: round, Period: types.PeriodWork, Combo: combo, Beat: beat.Number, MoveIndex: beat.MoveIndex}
+	switch {
+	case beat.MoveIndex == 0:
+		event.Type = EventBeat
+	case beat.MoveIndex < len(combo.Moves):
+		event.Type = EventMove
+	}
+	wt.publish(event)
 }
 
 // onWorkPeriodComplete handles the completion of a work period
-func (wt *WorkoutTimer) onWorkPeriodComplete() {
-	if wt.displayHandler != nil {
-		wt.displayHandler.OnPeriodEnd(types.PeriodWork, wt.currentRound)
+func (wt *WorkoutTimer) onWorkPeriodComplete(run *workoutRun, roundNumber int) {
+	display, _ := wt.handlers()
+	wt.publish(Event{Type: EventPeriodEnded, Round: roundNumber, Period: types.PeriodWork})
+	if display != nil {
+		display.OnPeriodEnd(types.PeriodWork, roundNumber)
 	}
 
 	// Start rest period
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
+		return
+	}
 	wt.currentPeriod = types.PeriodRest
-	wt.startRestPeriod()
+	wt.mu.Unlock()
+	wt.startRestPeriod(run)
 }
 
 // startRestPeriod starts a rest period for the current round
-func (wt *WorkoutTimer) startRestPeriod() error {
-	// Add bounds check before array access (same as startWorkPeriod has)
-	// This prevents panic when currentRound is 0 or out of bounds (e.g., after Stop() is called)
-	if wt.currentRound <= 0 || wt.currentRound > len(wt.workout.Rounds) {
-		return fmt.Errorf("invalid currentRound: %d (expected 1-%d)", wt.currentRound, len(wt.workout.Rounds))
-	}
-	round := wt.workout.Rounds[wt.currentRound-1]
-	wt.restTimer = NewRestPeriodTimer(round.RestDuration)
-
-	// Track last beep time to avoid multiple beeps in the same second
-	lastBeepSecond := -1
-
-	// Set up callbacks
-	wt.restTimer.OnTick(func(remaining time.Duration) {
-		if wt.displayHandler != nil {
-			wt.displayHandler.OnTimerUpdate(remaining, types.PeriodRest, wt.currentRound)
-		}
+func (wt *WorkoutTimer) startRestPeriod(run *workoutRun) {
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
+		return
+	}
+	roundNumber := wt.currentRound
+	round := wt.workout.Rounds[roundNumber-1]
+	duration, open := wt.restDurationLocked(roundNumber)
+	duration = wt.firstPeriodDurationLocked(duration)
+	timer, seq := wt.newPeriodTimer(run, types.PeriodRest, roundNumber, duration)
+	wt.restTimer = &RestPeriodTimer{CountdownTimer: timer}
+	wt.openRest = open
+	display, roundEnd := wt.displayHandler, wt.timeline.profile.RoundEnd
+	restEnd := wt.clock.Now().Add(duration)
+	wt.mu.Unlock()
+	wt.saveCheckpoint(run)
 
-		// Play beep in the last 3 seconds of rest period (3, 2, 1 seconds remaining)
-		if wt.audioHandler != nil {
-			remainingSeconds := int(remaining.Seconds())
-			// Only beep if we're in the last 3 seconds and haven't beeped for this second yet
-			if remainingSeconds <= 3 && remainingSeconds > 0 && remainingSeconds != lastBeepSecond {
-				wt.audioHandler.PlayBeep()
-				lastBeepSecond = remainingSeconds
-			}
-		}
-	}).OnComplete(func() {
-		wt.onRestPeriodComplete()
-	})
+	wt.timedCuesFired = nil
 
-	if wt.displayHandler != nil {
-		wt.displayHandler.OnPeriodStart(types.PeriodRest, wt.currentRound, round.RestDuration)
+	wt.publish(Event{Type: EventPeriodStarted, Round: roundNumber, Period: types.PeriodRest, Duration: duration, OpenEnded: open})
+	if display != nil {
+		display.OnPeriodStart(types.PeriodRest, roundNumber, duration)
 	}
 
-	if wt.audioHandler != nil {
-		wt.audioHandler.PlayPeriodTransition(types.PeriodRest)
+	wt.playCue(CueRest, PriorityHigh, wt.timedCueDeadline(), func(audio AudioCueHandler) {
+		PlayPeriodCue(audio, types.PeriodRest, roundEnd)
+	})
+	// Speak the round's coaching cue (in line like the work period announcements, unless queued)
+	if round.Cue != "" {
+		wt.playCue(CueCoaching, PriorityLow, restEnd, func(audio AudioCueHandler) {
+			audio.PlayCoachingCue(round.Cue)
+		})
 	}
 
-	return wt.restTimer.Start()
+	wt.startPeriodTimer(run, seq, timer)
 }
 
 // onRestPeriodComplete handles the completion of a rest period
-func (wt *WorkoutTimer) onRestPeriodComplete() {
-	if wt.displayHandler != nil {
-		wt.displayHandler.OnPeriodEnd(types.PeriodRest, wt.currentRound)
+func (wt *WorkoutTimer) onRestPeriodComplete(run *workoutRun, roundNumber int) {
+	display, _ := wt.handlers()
+	wt.publish(Event{Type: EventPeriodEnded, Round: roundNumber, Period: types.PeriodRest})
+	if display != nil {
+		display.OnPeriodEnd(types.PeriodRest, roundNumber)
 	}
+	wt.publish(Event{Type: EventRoundCompleted, Round: roundNumber})
 
 	// Notify round completion
-	if wt.onRoundComplete != nil {
-		wt.onRoundComplete(wt.currentRound)
+	wt.mu.Lock()
+	onRoundComplete := wt.onRoundComplete
+	wt.mu.Unlock()
+	if onRoundComplete != nil {
+		onRoundComplete(roundNumber)
 	}
 
 	// Move to next round
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
+		return
+	}
 	wt.currentRound++
 	wt.currentPeriod = types.PeriodWork
+	wt.mu.Unlock()
 
 	// Start next work period or complete workout
-	if wt.currentRound <= len(wt.workout.Rounds) {
-		wt.startWorkPeriod()
-	} else {
-		wt.completeWorkout()
-	}
+	wt.startWorkPeriod(run)
 }
 
 // completeWorkout handles workout completion
-func (wt *WorkoutTimer) completeWorkout() {
-	// Ensure we only complete once
-	if wt.currentRound == 0 {
-		// Already completed, avoid double completion
+func (wt *WorkoutTimer) completeWorkout(run *workoutRun) {
+	// Ensure we only complete once, and not after Stop()
+	wt.mu.Lock()
+	if wt.run != run {
+		wt.mu.Unlock()
 		return
 	}
-
-	// Store current round before marking as complete
-	completedRound := wt.currentRound
-	wt.currentRound = 0 // Mark as completed early to prevent re-entry
+	wt.currentRound = 0
+	wt.state = StateCompleted
+	wt.run = nil
+	close(run.done) // Ends the event loop once this handler returns
+	display, queue, onWorkoutComplete := wt.displayHandler, wt.audioQueue, wt.onWorkoutComplete
+	wt.mu.Unlock()
+	wt.clearCheckpoint()
 
 	// Use defer with recover to ensure callback is called even if handlers panic
 	// This MUST be declared at the very beginning, BEFORE any handler calls,
@@ -294,29 +1334,37 @@ func (wt *WorkoutTimer) completeWorkout() {
 	defer func() {
 		if r := recover(); r != nil {
 			// If there was a panic, still try to call the callback
-			if wt.onWorkoutComplete != nil {
-				wt.onWorkoutComplete()
+			if onWorkoutComplete != nil {
+				onWorkoutComplete()
 			}
 			panic(r) // Re-panic after calling callback
 		}
 	}()
 
 	// Call handlers - these are now protected by the defer above
-	if wt.displayHandler != nil {
-		wt.displayHandler.OnWorkoutComplete()
+	wt.publish(Event{Type: EventWorkoutCompleted, TotalRounds: len(wt.workout.Rounds)})
+	if display != nil {
+		display.OnWorkoutComplete()
 	}
 
-	if wt.audioHandler != nil {
-		wt.audioHandler.PlayWorkoutComplete()
+	// Wait for the last cue so it isn't cut off by whatever the completion callback does
+	complete := wt.playCue(CueWorkoutComplete, PriorityHigh, time.Time{}, func(audio AudioCueHandler) {
+		audio.PlayWorkoutComplete()
+	})
+	if queue != nil {
+		wt.mu.Lock()
+		if wt.audioQueue == queue {
+			wt.audioQueue = nil
+		}
+		wt.mu.Unlock()
+		queue.Close()
+		<-complete.Done()
 	}
+	run.cancel()
 
 	// Call the completion callback last, after all handlers have been notified
 	// This is also protected by the defer, so it will be called even if handlers panic
-	if wt.onWorkoutComplete != nil {
-		wt.onWorkoutComplete()
-	} else {
-		// Debug: callback is nil - this shouldn't happen if test sets it up correctly
-		// But we can't log here without importing log package, so just continue
-		_ = completedRound // Use variable to avoid unused warning
+	if onWorkoutComplete != nil {
+		onWorkoutComplete()
 	}
 }
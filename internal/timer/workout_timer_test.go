@@ -1,10 +1,13 @@
 package timer
 
 import (
+	"fmt"
 	"heavybagworkout/internal/mocks"
 	"heavybagworkout/internal/models"
 	"heavybagworkout/internal/types"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -242,3 +245,721 @@ func TestWorkoutTimer_StopCancelsAudio(t *testing.T) {
 		t.Errorf("expected round 0 after stop, got %d", timer.CurrentRound())
 	}
 }
+
+func TestWorkoutTimer_PlaysCoachingCueDuringRest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cuedRound := models.NewWorkoutRound(1, models.NewCombo([]models.Move{}), 500*time.Millisecond, 500*time.Millisecond)
+	cuedRound.Cue = "Snap the jab back"
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(500*time.Millisecond, 500*time.Millisecond, 2),
+		[]models.WorkoutRound{
+			cuedRound,
+			models.NewWorkoutRound(2, models.NewCombo([]models.Move{}), 500*time.Millisecond, 500*time.Millisecond),
+		},
+	)
+
+	audio := mocks.NewMockAudioCueHandler(ctrl)
+	timer := NewWorkoutTimer(workout)
+	timer.SetAudioHandler(audio)
+
+	audio.EXPECT().PlayWorkoutStart().AnyTimes()
+	audio.EXPECT().PlayWorkoutComplete().AnyTimes()
+	audio.EXPECT().PlayBeep().AnyTimes()
+	audio.EXPECT().PlayRoundCallout(gomock.Any(), gomock.Any()).AnyTimes()
+	audio.EXPECT().PlayComboCallout(gomock.Any(), gomock.Any()).AnyTimes()
+	audio.EXPECT().PlayPeriodTransition(types.PeriodWork).AnyTimes()
+	// The cue is spoken right after "rest", and only for the round that has one
+	gomock.InOrder(
+		audio.EXPECT().PlayPeriodTransition(types.PeriodRest).Times(1),
+		audio.EXPECT().PlayCoachingCue("Snap the jab back").Times(1),
+		audio.EXPECT().PlayPeriodTransition(types.PeriodRest).Times(1),
+	)
+
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	select {
+	case <-completed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("workout should have completed")
+	}
+}
+
+func TestWorkoutTimer_FakeClockSimulatesFullWorkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const totalRounds = 10
+	workDuration := 3 * time.Minute
+	restDuration := 1 * time.Minute
+	rounds := make([]models.WorkoutRound, 0, totalRounds)
+	for i := 1; i <= totalRounds; i++ {
+		rounds = append(rounds, models.NewWorkoutRound(i, models.NewCombo([]models.Move{}), workDuration, restDuration))
+	}
+	workout := models.NewWorkout(models.NewWorkoutConfig(workDuration, restDuration, totalRounds), rounds)
+
+	// Every period gets an initial update, one per second, and a final zero
+	display := mocks.NewMockTimerDisplayHandler(ctrl)
+	display.EXPECT().OnWorkoutStart(totalRounds).Times(1)
+	for i := 1; i <= totalRounds; i++ {
+		display.EXPECT().OnPeriodStart(types.PeriodWork, i, workDuration).Times(1)
+		display.EXPECT().OnTimerUpdate(gomock.Any(), types.PeriodWork, i).Times(int(workDuration/time.Second) + 1)
+		display.EXPECT().OnPeriodEnd(types.PeriodWork, i).Times(1)
+		display.EXPECT().OnPeriodStart(types.PeriodRest, i, restDuration).Times(1)
+		display.EXPECT().OnTimerUpdate(gomock.Any(), types.PeriodRest, i).Times(int(restDuration/time.Second) + 1)
+		display.EXPECT().OnPeriodEnd(types.PeriodRest, i).Times(1)
+	}
+	display.EXPECT().OnWorkoutComplete().Times(1)
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	timer := NewWorkoutTimer(workout)
+	timer.SetClock(clock)
+	timer.SetDisplayHandler(display)
+
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() {
+		close(completed)
+	})
+
+	started := time.Now()
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	// 10 * (3m + 1m) = 40 minutes of workout; allow slack for period handovers
+	maxSteps := int(totalRounds*(workDuration+restDuration)/time.Second) * 2
+	if !advanceUntilDone(clock, completed, maxSteps) {
+		t.Fatalf("workout did not complete after %d simulated seconds", maxSteps)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("expected simulated workout to finish quickly, took %v", elapsed)
+	}
+	if simulated := clock.Since(time.Unix(0, 0)); simulated < totalRounds*(workDuration+restDuration) {
+		t.Errorf("expected at least 40m of simulated time, got %v", simulated)
+	}
+}
+
+// advanceUntilDone advances the clock a second at a time until done is closed, giving up after maxSteps.
+// Before each step it waits for a deadline to be registered, since periods start on the timer's event loop.
+func advanceUntilDone(clock *FakeClock, done <-chan struct{}, maxSteps int) bool {
+	for step := 0; step < maxSteps; step++ {
+		for clock.Waiters() == 0 {
+			select {
+			case <-done:
+				return true
+			case <-time.After(time.Millisecond):
+			}
+		}
+		select {
+		case <-done:
+			return true
+		default:
+		}
+		clock.Advance(time.Second)
+	}
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// overlapDisplayHandler counts display callbacks that run concurrently with another
+type overlapDisplayHandler struct {
+	active   atomic.Int32
+	overlaps atomic.Int32
+}
+
+func (h *overlapDisplayHandler) enter() {
+	if h.active.Add(1) > 1 {
+		h.overlaps.Add(1)
+	}
+	time.Sleep(10 * time.Microsecond)
+	h.active.Add(-1)
+}
+
+func (h *overlapDisplayHandler) OnTimerUpdate(time.Duration, types.PeriodType, int) { h.enter() }
+func (h *overlapDisplayHandler) OnPeriodStart(types.PeriodType, int, time.Duration) { h.enter() }
+func (h *overlapDisplayHandler) OnPeriodEnd(types.PeriodType, int)                  { h.enter() }
+func (h *overlapDisplayHandler) OnWorkoutStart(int)                                 { h.enter() }
+func (h *overlapDisplayHandler) OnWorkoutComplete()                                 { h.enter() }
+
+// TestWorkoutTimer_ConcurrentControl pauses, resumes and reads a running workout from several goroutines; run with -race
+func TestWorkoutTimer_ConcurrentControl(t *testing.T) {
+	rounds := make([]models.WorkoutRound, 0, 3)
+	for i := 1; i <= 3; i++ {
+		rounds = append(rounds, models.NewWorkoutRound(i, models.NewCombo([]models.Move{}), 5*time.Second, 5*time.Second))
+	}
+	workout := models.NewWorkout(models.NewWorkoutConfig(5*time.Second, 5*time.Second, 3), rounds)
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	display := &overlapDisplayHandler{}
+	timer := NewWorkoutTimer(workout)
+	timer.SetClock(clock)
+	timer.SetDisplayHandler(display)
+	timer.SetAudioHandler(NewNoOpAudioCueHandler())
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	controls := []func(){
+		timer.Pause,
+		func() { timer.Resume() },
+		func() { _ = timer.CurrentRound() },
+		func() { _ = timer.CurrentPeriod() },
+		func() { _ = timer.RemainingTime() },
+		func() { _ = timer.State() },
+	}
+	for _, control := range controls {
+		wg.Add(1)
+		go func(control func()) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				control()
+			}
+		}(control)
+	}
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				clock.Advance(250 * time.Millisecond)
+			}
+		}
+	}()
+
+	wg.Wait()
+	timer.Stop()
+	close(stop)
+
+	if display.overlaps.Load() > 0 {
+		t.Errorf("display callbacks overlapped %d times", display.overlaps.Load())
+	}
+	if state := timer.State(); state != StateStopped && state != StateCompleted {
+		t.Errorf("expected state Stopped or Completed, got %v", state)
+	}
+	if timer.CurrentRound() != 0 {
+		t.Errorf("expected round 0 after stop, got %d", timer.CurrentRound())
+	}
+}
+
+// fineDisplayHandler records fine updates per period
+type fineDisplayHandler struct {
+	*overlapDisplayHandler
+	mu   sync.Mutex
+	fine map[types.PeriodType][]time.Duration
+}
+
+func (h *fineDisplayHandler) OnTimerFineUpdate(remaining time.Duration, periodType types.PeriodType, roundNumber int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fine[periodType] = append(h.fine[periodType], remaining)
+}
+
+func TestWorkoutTimer_ForwardsFineTicks(t *testing.T) {
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(2*time.Second, 1*time.Second, 1),
+		[]models.WorkoutRound{models.NewWorkoutRound(1, models.NewCombo([]models.Move{}), 2*time.Second, 1*time.Second)},
+	)
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	display := &fineDisplayHandler{overlapDisplayHandler: &overlapDisplayHandler{}, fine: make(map[types.PeriodType][]time.Duration)}
+	timer := NewWorkoutTimer(workout)
+	timer.SetClock(clock)
+	timer.SetDisplayHandler(display)
+	timer.SetAudioHandler(NewNoOpAudioCueHandler())
+	timer.SetFineTickInterval(500 * time.Millisecond)
+
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() {
+		close(completed)
+	})
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	if !advanceUntilDone(clock, completed, 10) {
+		t.Fatal("workout did not complete")
+	}
+
+	display.mu.Lock()
+	defer display.mu.Unlock()
+	if work := display.fine[types.PeriodWork]; len(work) != 5 || work[0] != 2*time.Second || work[4] != 0 {
+		t.Errorf("expected 5 work fine ticks from 2s to 0, got %v", work)
+	}
+	if rest := display.fine[types.PeriodRest]; len(rest) != 3 || rest[1] != 500*time.Millisecond {
+		t.Errorf("expected 3 rest fine ticks from 1s to 0, got %v", rest)
+	}
+}
+
+// blockingComboAudio blocks in PlayComboCallout until released
+type blockingComboAudio struct {
+	*NoOpAudioCueHandler
+	calling chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingComboAudio) PlayComboCallout(models.Combo, models.Stance) {
+	a.calling <- struct{}{}
+	<-a.release
+}
+
+func TestWorkoutTimer_PauseDuringAnnouncements(t *testing.T) {
+	workout := models.NewWorkout(
+		models.NewWorkoutConfig(5*time.Second, 5*time.Second, 1),
+		[]models.WorkoutRound{
+			models.NewWorkoutRound(1, models.NewCombo([]models.Move{}), 5*time.Second, 5*time.Second),
+		},
+	)
+
+	clock := NewFakeClock(time.Unix(0, 0))
+	audio := &blockingComboAudio{NoOpAudioCueHandler: NewNoOpAudioCueHandler(), calling: make(chan struct{}), release: make(chan struct{})}
+	timer := NewWorkoutTimer(workout)
+	timer.SetClock(clock)
+	timer.SetAudioHandler(audio)
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	if err := timer.Start(); err == nil {
+		t.Error("expected an error starting a running workout")
+	}
+
+	// Pause while the combo is being called out, then let the callout finish
+	<-audio.calling
+	timer.Pause()
+	close(audio.release)
+
+	// The work period must not start counting down while paused
+	time.Sleep(50 * time.Millisecond) // Let the event loop finish the announcements
+	clock.Advance(2 * time.Second)
+	if got := timer.RemainingTime(); got != 5*time.Second {
+		t.Errorf("expected the full 5s while paused, got %v", got)
+	}
+	if timer.State() != StatePaused {
+		t.Errorf("expected state Paused, got %v", timer.State())
+	}
+
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+	clock.BlockUntil(1) // The work period starts once the announcements are done
+	clock.Advance(2 * time.Second)
+	if got := timer.RemainingTime(); got != 3*time.Second {
+		t.Errorf("expected 3s remaining after resuming, got %v", got)
+	}
+	timer.Stop()
+}
+
+// mutedRoundAudio calls out the round until it is stopped
+type mutedRoundAudio struct {
+	*cueRecorder
+	calling chan struct{}
+	muted   chan struct{}
+	once    sync.Once
+}
+
+func (a *mutedRoundAudio) PlayRoundCallout(roundNumber int, totalRounds int) {
+	a.cueRecorder.PlayRoundCallout(roundNumber, totalRounds)
+	close(a.calling)
+	<-a.muted
+}
+
+func (a *mutedRoundAudio) Stop() { a.once.Do(func() { close(a.muted) }) }
+
+func TestWorkoutTimer_ResumeDuringAnnouncements(t *testing.T) {
+	timer, clock, display := newControlTestTimer(1)
+	audio := &mutedRoundAudio{cueRecorder: newCueRecorder("combo"), calling: make(chan struct{}), muted: make(chan struct{})}
+	timer.SetAudioHandler(audio)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	// Pause during the round callout and resume while the combo is still to be called out
+	<-audio.calling
+	timer.Pause()
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+	audio.waitForCount(t, "combo", 1)
+
+	// The work period waits for the combo callout
+	clock.Advance(time.Second)
+	if got := timer.RemainingTime(); got != 3*time.Second {
+		t.Errorf("expected the work period to wait for the announcements, got %v remaining", got)
+	}
+	display.mu.Lock()
+	events := periodEvents(display.events)
+	display.mu.Unlock()
+	if strings.Contains(strings.Join(events, ", "), "start Work 1") {
+		t.Errorf("expected the work period to start after the announcements, got %v", events)
+	}
+
+	audio.release()
+	display.waitFor(t, "start Work 1 3s")
+	if !advanceUntilDone(clock, completed, 40) {
+		t.Fatal("workout did not complete")
+	}
+}
+
+// eventRecorder records display events as strings such as "start work 1 3s"
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *eventRecorder) record(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *eventRecorder) OnTimerUpdate(remaining time.Duration, periodType types.PeriodType, roundNumber int) {
+	r.record("update %s %d %v", periodName(periodType), roundNumber, remaining)
+}
+func (r *eventRecorder) OnPeriodStart(periodType types.PeriodType, roundNumber int, duration time.Duration) {
+	r.record("start %s %d %v", periodName(periodType), roundNumber, duration)
+}
+func (r *eventRecorder) OnPeriodEnd(periodType types.PeriodType, roundNumber int) {
+	r.record("end %s %d", periodName(periodType), roundNumber)
+}
+func (r *eventRecorder) OnWorkoutStart(totalRounds int) { r.record("workout start %d", totalRounds) }
+func (r *eventRecorder) OnWorkoutComplete()             { r.record("workout complete") }
+
+func periodName(periodType types.PeriodType) string {
+	if periodType == types.PeriodRest {
+		return "Rest"
+	}
+	return "Work"
+}
+
+// waitFor waits until event has been recorded and returns the events up to and including it
+func (r *eventRecorder) waitFor(t *testing.T, event string) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		for i, recorded := range r.events {
+			if recorded == event {
+				events := append([]string(nil), r.events[:i+1]...)
+				r.mu.Unlock()
+				return events
+			}
+		}
+		r.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t.Fatalf("timed out waiting for %q, got %v", event, r.events)
+	return nil
+}
+
+// periodEvents filters out timer updates
+func periodEvents(events []string) []string {
+	var filtered []string
+	for _, event := range events {
+		if !strings.HasPrefix(event, "update") {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// newControlTestTimer creates a fake-clock workout of the given rounds of 3s work and 10s rest
+func newControlTestTimer(totalRounds int) (*WorkoutTimer, *FakeClock, *eventRecorder) {
+	rounds := make([]models.WorkoutRound, 0, totalRounds)
+	for i := 1; i <= totalRounds; i++ {
+		rounds = append(rounds, models.NewWorkoutRound(i, models.NewCombo([]models.Move{}), 3*time.Second, 10*time.Second))
+	}
+	clock := NewFakeClock(time.Unix(0, 0))
+	display := &eventRecorder{}
+	timer := NewWorkoutTimer(models.NewWorkout(models.NewWorkoutConfig(3*time.Second, 10*time.Second, totalRounds), rounds))
+	timer.SetClock(clock)
+	timer.SetDisplayHandler(display)
+	timer.SetAudioHandler(NewNoOpAudioCueHandler())
+	return timer, clock, display
+}
+
+func TestWorkoutTimer_SkipRest(t *testing.T) {
+	timer, clock, display := newControlTestTimer(2)
+	skipped := 0
+	timer.OnRoundComplete(func(int) { skipped++ })
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	display.waitFor(t, "start Work 1 3s")
+	clock.Advance(3 * time.Second)
+	display.waitFor(t, "start Rest 1 10s")
+	clock.Advance(2 * time.Second)
+	if err := timer.SkipRest(); err != nil {
+		t.Fatalf("unexpected error skipping rest: %v", err)
+	}
+	display.waitFor(t, "start Work 2 3s")
+	if err := timer.SkipRest(); err == nil {
+		t.Error("expected an error skipping rest during a work period")
+	}
+
+	// The skipped rest timer must not end round 2 early
+	if !advanceUntilDone(clock, completed, 20) {
+		t.Fatal("workout did not complete")
+	}
+	events := periodEvents(display.waitFor(t, "workout complete"))
+	want := []string{
+		"workout start 2",
+		"start Work 1 3s", "end Work 1", "start Rest 1 10s", "end Rest 1",
+		"start Work 2 3s", "end Work 2", "start Rest 2 10s", "end Rest 2",
+		"workout complete",
+	}
+	if strings.Join(events, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected events %v, got %v", want, events)
+	}
+	if skipped != 2 {
+		t.Errorf("expected the skipped rest to complete the round, got %d rounds completed", skipped)
+	}
+	if simulated := clock.Since(time.Unix(0, 0)); simulated != 18*time.Second {
+		t.Errorf("expected the workout to take 18s, took %v", simulated)
+	}
+}
+
+func TestWorkoutTimer_JumpToRoundAndRepeat(t *testing.T) {
+	timer, clock, display := newControlTestTimer(3)
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	display.waitFor(t, "start Work 1 3s")
+	clock.Advance(1 * time.Second)
+	if err := timer.JumpToRound(3); err != nil {
+		t.Fatalf("unexpected error jumping to round 3: %v", err)
+	}
+	display.waitFor(t, "start Work 3 3s")
+	if timer.CurrentRound() != 3 {
+		t.Errorf("expected round 3, got %d", timer.CurrentRound())
+	}
+
+	clock.Advance(3 * time.Second)
+	display.waitFor(t, "start Rest 3 10s")
+	if err := timer.RepeatRound(); err != nil {
+		t.Fatalf("unexpected error repeating round: %v", err)
+	}
+	events := periodEvents(display.waitFor(t, "end Rest 3"))
+	if !advanceUntilDone(clock, completed, 20) {
+		t.Fatal("workout did not complete")
+	}
+
+	want := []string{"workout start 3", "start Work 1 3s", "end Work 1", "start Work 3 3s", "end Work 3", "start Rest 3 10s", "end Rest 3"}
+	if strings.Join(events, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected events %v, got %v", want, events)
+	}
+	if simulated := clock.Since(time.Unix(0, 0)); simulated != 17*time.Second {
+		t.Errorf("expected the workout to take 17s, took %v", simulated)
+	}
+}
+
+func TestWorkoutTimer_AddTime(t *testing.T) {
+	timer, clock, display := newControlTestTimer(1)
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	display.waitFor(t, "start Work 1 3s")
+	clock.Advance(1 * time.Second)
+	if err := timer.AddTime(30 * time.Second); err != nil {
+		t.Fatalf("unexpected error adding time: %v", err)
+	}
+	display.waitFor(t, "update Work 1 32s")
+
+	// Paused time is extended too
+	timer.Pause()
+	if err := timer.AddTime(30 * time.Second); err != nil {
+		t.Fatalf("unexpected error adding time while paused: %v", err)
+	}
+	display.waitFor(t, "update Work 1 1m2s")
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("unexpected error resuming: %v", err)
+	}
+
+	clock.Advance(61 * time.Second)
+	display.waitFor(t, "update Work 1 1s")
+	if timer.CurrentPeriod() != types.PeriodWork {
+		t.Fatal("expected the extended work period to still be running")
+	}
+	clock.Advance(1 * time.Second)
+	display.waitFor(t, "start Rest 1 10s")
+}
+
+func TestWorkoutTimer_ControlErrors(t *testing.T) {
+	timer, _, display := newControlTestTimer(2)
+
+	if err := timer.SkipRest(); err == nil {
+		t.Error("expected an error skipping rest before starting")
+	}
+	if err := timer.RepeatRound(); err == nil {
+		t.Error("expected an error repeating a round before starting")
+	}
+
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	defer timer.Stop()
+	display.waitFor(t, "start Work 1 3s")
+
+	tests := []struct {
+		name    string
+		control func() error
+	}{
+		{name: "skip rest during work", control: timer.SkipRest},
+		{name: "jump to round 0", control: func() error { return timer.JumpToRound(0) }},
+		{name: "jump past the last round", control: func() error { return timer.JumpToRound(3) }},
+		{name: "add no time", control: func() error { return timer.AddTime(0) }},
+		{name: "add negative time", control: func() error { return timer.AddTime(-time.Second) }},
+		{name: "ready without auto rest", control: timer.Ready},
+		{name: "done without earned rest", control: timer.Done},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.control(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// elapsedRecorder also records the elapsed time of open-ended periods
+type elapsedRecorder struct {
+	*eventRecorder
+}
+
+func (r elapsedRecorder) OnElapsedUpdate(elapsed time.Duration, periodType types.PeriodType, roundNumber int) {
+	r.record("elapsed %s %d %v", periodName(periodType), roundNumber, elapsed)
+}
+
+func TestWorkoutTimer_AutoRest(t *testing.T) {
+	timer, clock, display := newControlTestTimer(2)
+	timer.SetDisplayHandler(elapsedRecorder{display})
+	timer.SetRestMode(models.RestAuto)
+	if err := timer.SetRestBounds(5*time.Second, time.Minute); err != nil {
+		t.Fatalf("unexpected error setting rest bounds: %v", err)
+	}
+	completed := make(chan struct{})
+	timer.OnWorkoutComplete(func() { close(completed) })
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+
+	display.waitFor(t, "start Work 1 3s")
+	if err := timer.Ready(); err == nil {
+		t.Error("expected an error signalling ready during a work period")
+	}
+	clock.Advance(3 * time.Second)
+
+	// The rest period runs up to the maximum, showing the time rested
+	display.waitFor(t, "start Rest 1 1m0s")
+	display.waitFor(t, "elapsed Rest 1 0s")
+	clock.Advance(2 * time.Second)
+	display.waitFor(t, "elapsed Rest 1 2s")
+
+	// Ready before the minimum counts down the rest of it
+	if err := timer.Ready(); err != nil {
+		t.Fatalf("unexpected error signalling ready: %v", err)
+	}
+	display.waitFor(t, "update Rest 1 3s")
+	clock.Advance(3 * time.Second)
+	display.waitFor(t, "start Work 2 3s")
+	clock.Advance(3 * time.Second)
+
+	// Ready after the minimum ends the rest straight away
+	display.waitFor(t, "start Rest 2 1m0s")
+	clock.Advance(6 * time.Second)
+	display.waitFor(t, "elapsed Rest 2 6s")
+	if err := timer.Ready(); err != nil {
+		t.Fatalf("unexpected error signalling ready: %v", err)
+	}
+	select {
+	case <-completed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("workout did not complete")
+	}
+
+	events := periodEvents(display.waitFor(t, "workout complete"))
+	want := []string{"workout start 2", "start Work 1 3s", "end Work 1", "start Rest 1 1m0s", "end Rest 1", "start Work 2 3s", "end Work 2", "start Rest 2 1m0s", "end Rest 2", "workout complete"}
+	var got []string
+	for _, event := range events {
+		if !strings.HasPrefix(event, "elapsed") {
+			got = append(got, event)
+		}
+	}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected events %v, got %v", want, got)
+	}
+}
+
+func TestWorkoutTimer_EarnedRest(t *testing.T) {
+	timer, clock, display := newControlTestTimer(2)
+	timer.SetRestMode(models.RestEarned)
+	if err := timer.SetRestBounds(0, 11*time.Second); err != nil {
+		t.Fatalf("unexpected error setting rest bounds: %v", err)
+	}
+	if err := timer.Start(); err != nil {
+		t.Fatalf("unexpected error starting workout timer: %v", err)
+	}
+	defer timer.Stop()
+
+	// Finishing 1s early earns 1s of rest
+	display.waitFor(t, "start Work 1 3s")
+	clock.Advance(2 * time.Second)
+	if err := timer.Done(); err != nil {
+		t.Fatalf("unexpected error signalling done: %v", err)
+	}
+	display.waitFor(t, "start Rest 1 11s")
+	if err := timer.Done(); err == nil {
+		t.Error("expected an error signalling done during a rest period")
+	}
+
+	// Earned rest is capped at the maximum rest
+	clock.Advance(11 * time.Second)
+	display.waitFor(t, "start Work 2 3s")
+	if err := timer.Done(); err != nil {
+		t.Fatalf("unexpected error signalling done: %v", err)
+	}
+	display.waitFor(t, "start Rest 2 11s")
+}
+
+func TestWorkoutTimer_SetRestBoundsRejectsInvalidBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max time.Duration
+	}{
+		{name: "negative minimum", min: -time.Second, max: time.Minute},
+		{name: "no maximum", min: 0, max: 0},
+		{name: "minimum above maximum", min: time.Minute, max: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timer, _, _ := newControlTestTimer(1)
+			if err := timer.SetRestBounds(tt.min, tt.max); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}